@@ -0,0 +1,180 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bipartite
+
+import (
+	"fmt"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+	"github.com/gonum/graph/topo"
+)
+
+// Side is a side of the partition of a bipartite graph.
+type Side int
+
+const (
+	// Top is the top side of a bipartition.
+	Top Side = iota
+
+	// Bottom is the bottom side of a bipartition.
+	Bottom
+)
+
+// Graph implements a generalized undirected bipartite graph. Each node
+// belongs to one side of the partition and edges may only join nodes on
+// opposite sides.
+type Graph struct {
+	g    *simple.UndirectedGraph
+	side map[int]Side
+}
+
+// NewGraph returns a Graph with the specified self and absent
+// edge weight values.
+func NewGraph(self, absent float64) *Graph {
+	return &Graph{
+		g:    simple.NewUndirectedGraph(self, absent),
+		side: make(map[int]Side),
+	}
+}
+
+// NewGraphFrom returns a Graph holding the nodes and edges of the undirected
+// graph g and true if g is bipartite. The side of each node is determined by
+// topo.IsBipartite, with the lowest ID node of each connected component on
+// the Top side. If g is not bipartite, NewGraphFrom returns nil and false.
+func NewGraphFrom(g graph.Undirected, self, absent float64) (*Graph, bool) {
+	ok, parts, _ := topo.IsBipartite(g)
+	if !ok {
+		return nil, false
+	}
+	b := NewGraph(self, absent)
+	for s, part := range parts {
+		for _, n := range part {
+			b.AddNode(n, Side(s))
+		}
+	}
+	for _, u := range g.Nodes() {
+		for _, v := range g.From(u) {
+			if u.ID() < v.ID() {
+				b.SetEdge(g.Edge(u, v))
+			}
+		}
+	}
+	return b, true
+}
+
+// NewNodeID returns a new unique ID for a node to be added to g. The returned ID does
+// not become a valid ID in g until it is added to g.
+func (g *Graph) NewNodeID() int {
+	return g.g.NewNodeID()
+}
+
+// AddNode adds n to the graph on the given side. It panics if the added
+// node ID matches an existing node ID.
+func (g *Graph) AddNode(n graph.Node, side Side) {
+	g.g.AddNode(n)
+	g.side[n.ID()] = side
+}
+
+// RemoveNode removes n from the graph, as well as any edges attached to it. If the node
+// is not in the graph it is a no-op.
+func (g *Graph) RemoveNode(n graph.Node) {
+	g.g.RemoveNode(n)
+	delete(g.side, n.ID())
+}
+
+// SetEdge adds e, an edge from one node to another. It will panic if either
+// node is not in the graph or if the nodes are on the same side.
+func (g *Graph) SetEdge(e graph.Edge) {
+	fs, ok := g.side[e.From().ID()]
+	if !ok {
+		panic(fmt.Sprintf("bipartite: node %d not in graph", e.From().ID()))
+	}
+	ts, ok := g.side[e.To().ID()]
+	if !ok {
+		panic(fmt.Sprintf("bipartite: node %d not in graph", e.To().ID()))
+	}
+	if fs == ts {
+		panic(fmt.Sprintf("bipartite: edge between nodes %d and %d on the same side", e.From().ID(), e.To().ID()))
+	}
+	g.g.SetEdge(e)
+}
+
+// RemoveEdge removes e from the graph, leaving the terminal nodes. If the edge does not exist
+// it is a no-op.
+func (g *Graph) RemoveEdge(e graph.Edge) {
+	g.g.RemoveEdge(e)
+}
+
+// Side returns the side of the node n and whether n is in the graph.
+func (g *Graph) Side(n graph.Node) (side Side, ok bool) {
+	side, ok = g.side[n.ID()]
+	return side, ok
+}
+
+// NodesOn returns the nodes in the graph on the given side.
+func (g *Graph) NodesOn(side Side) []graph.Node {
+	var nodes []graph.Node
+	for _, n := range g.g.Nodes() {
+		if g.side[n.ID()] == side {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
+// Node returns the node in the graph with the given ID.
+func (g *Graph) Node(id int) graph.Node {
+	return g.g.Node(id)
+}
+
+// Has returns whether the node exists within the graph.
+func (g *Graph) Has(n graph.Node) bool {
+	return g.g.Has(n)
+}
+
+// Nodes returns all the nodes in the graph.
+func (g *Graph) Nodes() []graph.Node {
+	return g.g.Nodes()
+}
+
+// Edges returns all the edges in the graph.
+func (g *Graph) Edges() []graph.Edge {
+	return g.g.Edges()
+}
+
+// From returns all nodes in g that can be reached directly from n.
+func (g *Graph) From(n graph.Node) []graph.Node {
+	return g.g.From(n)
+}
+
+// HasEdgeBetween returns whether an edge exists between nodes x and y.
+func (g *Graph) HasEdgeBetween(x, y graph.Node) bool {
+	return g.g.HasEdgeBetween(x, y)
+}
+
+// Edge returns the edge from u to v if such an edge exists and nil otherwise.
+// The node v must be directly reachable from u as defined by the From method.
+func (g *Graph) Edge(u, v graph.Node) graph.Edge {
+	return g.g.Edge(u, v)
+}
+
+// EdgeBetween returns the edge between nodes x and y.
+func (g *Graph) EdgeBetween(x, y graph.Node) graph.Edge {
+	return g.g.EdgeBetween(x, y)
+}
+
+// Weight returns the weight for the edge between x and y if Edge(x, y) returns a non-nil Edge.
+// If x and y are the same node or there is no joining edge between the two nodes the weight
+// value returned is either the graph's absent or self value. Weight returns true if an edge
+// exists between x and y or if x and y have the same ID, false otherwise.
+func (g *Graph) Weight(x, y graph.Node) (w float64, ok bool) {
+	return g.g.Weight(x, y)
+}
+
+// Degree returns the degree of n in g.
+func (g *Graph) Degree(n graph.Node) int {
+	return g.g.Degree(n)
+}
@@ -0,0 +1,92 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bipartite
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+func TestGraph(t *testing.T) {
+	g := NewGraph(0, math.Inf(1))
+	for i := 0; i < 3; i++ {
+		g.AddNode(simple.Node(i), Top)
+	}
+	for i := 3; i < 5; i++ {
+		g.AddNode(simple.Node(i), Bottom)
+	}
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(3), W: 1})
+	g.SetEdge(simple.Edge{F: simple.Node(4), T: simple.Node(1), W: 2})
+
+	if !g.HasEdgeBetween(simple.Node(3), simple.Node(0)) {
+		t.Error("expected edge between 0 and 3")
+	}
+	if w, ok := g.Weight(simple.Node(1), simple.Node(4)); !ok || w != 2 {
+		t.Errorf("unexpected weight: got:%v,%t want:2,true", w, ok)
+	}
+	if got := len(g.NodesOn(Top)); got != 3 {
+		t.Errorf("unexpected number of top nodes: got:%d want:3", got)
+	}
+	if got := len(g.NodesOn(Bottom)); got != 2 {
+		t.Errorf("unexpected number of bottom nodes: got:%d want:2", got)
+	}
+	if s, ok := g.Side(simple.Node(4)); !ok || s != Bottom {
+		t.Errorf("unexpected side for node 4: got:%v,%t want:%v,true", s, ok, Bottom)
+	}
+
+	if !panics(func() { g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)}) }) {
+		t.Error("expected panic for edge within a side")
+	}
+	if !panics(func() { g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(10)}) }) {
+		t.Error("expected panic for edge to an absent node")
+	}
+
+	g.RemoveNode(simple.Node(3))
+	if _, ok := g.Side(simple.Node(3)); ok {
+		t.Error("unexpected side for removed node")
+	}
+	if g.HasEdgeBetween(simple.Node(0), simple.Node(3)) {
+		t.Error("unexpected edge to removed node")
+	}
+}
+
+func TestNewGraphFrom(t *testing.T) {
+	src := simple.NewUndirectedGraph(0, math.Inf(1))
+	for _, e := range [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 0}, {4, 5}} {
+		src.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1]), W: 1})
+	}
+	g, ok := NewGraphFrom(src, 0, math.Inf(1))
+	if !ok {
+		t.Fatal("expected bipartite graph")
+	}
+	want := map[int]Side{0: Top, 1: Bottom, 2: Top, 3: Bottom, 4: Top, 5: Bottom}
+	for id, s := range want {
+		if got, _ := g.Side(simple.Node(id)); got != s {
+			t.Errorf("unexpected side for node %d: got:%v want:%v", id, got, s)
+		}
+	}
+	if len(g.Edges()) != len(src.Edges()) {
+		t.Errorf("unexpected number of edges: got:%d want:%d", len(g.Edges()), len(src.Edges()))
+	}
+
+	src.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(2), W: 1})
+	if _, ok := NewGraphFrom(src, 0, math.Inf(1)); ok {
+		t.Error("unexpected bipartite result for graph with a triangle")
+	}
+}
+
+func panics(fn func()) (ok bool) {
+	defer func() {
+		ok = recover() != nil
+	}()
+	fn()
+	return
+}
+
+var _ graph.Undirected = (*Graph)(nil)
+var _ graph.Weighter = (*Graph)(nil)
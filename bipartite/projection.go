@@ -0,0 +1,91 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bipartite
+
+import (
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/set"
+	"github.com/gonum/graph/simple"
+)
+
+// Weighting specifies how edge weights are calculated in a bipartite
+// projection.
+type Weighting int
+
+const (
+	// Unweighted gives each projected edge a weight of 1.
+	Unweighted Weighting = iota
+
+	// SharedNeighbours weights each projected edge by the number
+	// of neighbours shared by its end nodes.
+	SharedNeighbours
+
+	// Newman weights each projected edge by the sum over shared
+	// neighbours k of 1/(deg(k)-1), the collaboration weighting
+	// described in:
+	//
+	// Newman, "Scientific collaboration networks. II. Shortest paths,
+	// weighted networks, and centrality", Phys. Rev. E 64, 016132 (2001).
+	Newman
+)
+
+// Project returns the projection of the bipartite graph g onto the nodes
+// in onto. Two nodes of onto are joined in the returned graph if they share
+// at least one neighbour in g, and the edge weight is calculated according
+// to weighting. Nodes of onto with no shared neighbours are included in the
+// returned graph without edges.
+//
+// Project does not check that onto is one side of a bipartition of g; nodes
+// of onto that are adjacent in g are not joined unless they share a neighbour.
+func Project(g graph.Undirected, onto []graph.Node, weighting Weighting) *simple.UndirectedGraph {
+	p := simple.NewUndirectedGraph(0, 0)
+	in := make(set.Ints)
+	for _, n := range onto {
+		p.AddNode(n)
+		in.Add(n.ID())
+	}
+
+	weights := make(map[[2]int]float64)
+	for _, k := range g.Nodes() {
+		if in.Has(k.ID()) {
+			continue
+		}
+		var nbrs []graph.Node
+		for _, n := range g.From(k) {
+			if in.Has(n.ID()) {
+				nbrs = append(nbrs, n)
+			}
+		}
+		if len(nbrs) < 2 {
+			continue
+		}
+		var w float64
+		switch weighting {
+		case Unweighted, SharedNeighbours:
+			w = 1
+		case Newman:
+			w = 1 / float64(len(g.From(k))-1)
+		default:
+			panic("bipartite: unknown weighting")
+		}
+		for i, u := range nbrs {
+			for _, v := range nbrs[i+1:] {
+				key := [2]int{u.ID(), v.ID()}
+				if key[0] > key[1] {
+					key[0], key[1] = key[1], key[0]
+				}
+				weights[key] += w
+			}
+		}
+	}
+
+	for key, w := range weights {
+		if weighting == Unweighted {
+			w = 1
+		}
+		p.SetEdge(simple.Edge{F: p.Node(key[0]), T: p.Node(key[1]), W: w})
+	}
+	return p
+}
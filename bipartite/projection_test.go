@@ -0,0 +1,83 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bipartite
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+// authorship is a small author-paper graph. Authors are nodes 0-3 and
+// papers are nodes 10-12.
+func authorship() (*Graph, []graph.Node) {
+	g := NewGraph(0, math.Inf(1))
+	for i := 0; i < 4; i++ {
+		g.AddNode(simple.Node(i), Top)
+	}
+	for i := 10; i < 13; i++ {
+		g.AddNode(simple.Node(i), Bottom)
+	}
+	for _, e := range [][2]int{
+		{0, 10}, {1, 10}, {2, 10},
+		{0, 11}, {1, 11},
+		{3, 12},
+	} {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1]), W: 1})
+	}
+	return g, g.NodesOn(Top)
+}
+
+var projectTests = []struct {
+	weighting Weighting
+	want      map[[2]int]float64
+}{
+	{
+		weighting: Unweighted,
+		want:      map[[2]int]float64{{0, 1}: 1, {0, 2}: 1, {1, 2}: 1},
+	},
+	{
+		weighting: SharedNeighbours,
+		want:      map[[2]int]float64{{0, 1}: 2, {0, 2}: 1, {1, 2}: 1},
+	},
+	{
+		weighting: Newman,
+		want:      map[[2]int]float64{{0, 1}: 0.5 + 1, {0, 2}: 0.5, {1, 2}: 0.5},
+	},
+}
+
+func TestProject(t *testing.T) {
+	const tol = 1e-12
+	for _, test := range projectTests {
+		g, authors := authorship()
+		p := Project(g, authors, test.weighting)
+		if len(p.Nodes()) != len(authors) {
+			t.Errorf("unexpected number of nodes for weighting %d: got:%d want:%d",
+				test.weighting, len(p.Nodes()), len(authors))
+		}
+		edges := p.Edges()
+		if len(edges) != len(test.want) {
+			t.Errorf("unexpected number of edges for weighting %d: got:%d want:%d",
+				test.weighting, len(edges), len(test.want))
+		}
+		for _, e := range edges {
+			key := [2]int{e.From().ID(), e.To().ID()}
+			if key[0] > key[1] {
+				key[0], key[1] = key[1], key[0]
+			}
+			w, ok := test.want[key]
+			if !ok {
+				t.Errorf("unexpected edge %v for weighting %d", key, test.weighting)
+				continue
+			}
+			if math.Abs(e.Weight()-w) > tol {
+				t.Errorf("unexpected weight for edge %v with weighting %d: got:%v want:%v",
+					key, test.weighting, e.Weight(), w)
+			}
+		}
+	}
+}
@@ -0,0 +1,24 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.7
+// +build go1.7
+
+package community
+
+import (
+	"context"
+	"math/rand"
+
+	"github.com/gonum/graph"
+)
+
+// ModularizeContext returns the hierarchical modularization of g at the given
+// resolution in the same way as Modularize, but checks ctx between passes of the
+// Louvain local moving heuristic. If ctx is cancelled or its deadline expires,
+// the modularization found so far is returned with ctx.Err(). The partial result
+// is a valid, but not necessarily optimal, hierarchical community structure.
+func ModularizeContext(ctx context.Context, g graph.Graph, resolution float64, src *rand.Rand) (ReducedGraph, error) {
+	return modularize(g, resolution, src, ctx.Err)
+}
@@ -0,0 +1,73 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.7
+// +build go1.7
+
+package community
+
+import (
+	"context"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+	"github.com/gonum/graph/simple"
+)
+
+func TestModularizeContextUndirected(t *testing.T) {
+	g := simple.NewUndirectedGraph(0, 0)
+	for u, e := range smallDumbell {
+		if !g.Has(simple.Node(u)) {
+			g.AddNode(simple.Node(u))
+		}
+		for v := range e {
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v), W: 1})
+		}
+	}
+
+	want := Modularize(g, 1, rand.New(rand.NewSource(1)))
+	got, err := ModularizeContext(context.Background(), g, 1, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(sortedCommunities(got.Communities()), sortedCommunities(want.Communities())) {
+		t.Errorf("unexpected communities: got:%v want:%v", got.Communities(), want.Communities())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	got, err = ModularizeContext(ctx, g, 1, rand.New(rand.NewSource(1)))
+	if err != context.Canceled {
+		t.Errorf("unexpected error for cancelled context: got:%v want:%v", err, context.Canceled)
+	}
+	if got == nil {
+		t.Fatal("unexpected nil result for cancelled context")
+	}
+	var n int
+	for _, c := range got.Communities() {
+		if len(c) != 1 {
+			t.Errorf("unexpected merged community for cancelled context: %v", c)
+		}
+		n += len(c)
+	}
+	if n != len(g.Nodes()) {
+		t.Errorf("unexpected number of nodes in communities: got:%d want:%d", n, len(g.Nodes()))
+	}
+}
+
+func sortedCommunities(communities [][]graph.Node) [][]int {
+	c := make([][]int, len(communities))
+	for i, comm := range communities {
+		for _, n := range comm {
+			c[i] = append(c[i], n.ID())
+		}
+		sort.Ints(c[i])
+	}
+	sort.Sort(ordered.BySliceValues(c))
+	return c
+}
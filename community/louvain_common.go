@@ -84,11 +84,20 @@ type ReducedGraph interface {
 // graph.Undirect may be used as a shim to allow modularization of
 // directed graphs with the undirected modularity function.
 func Modularize(g graph.Graph, resolution float64, src *rand.Rand) ReducedGraph {
+	r, _ := modularize(g, resolution, src, nil)
+	return r
+}
+
+// modularize returns the hierarchical modularization of g for Modularize. If
+// cancelled is not nil it is called before each pass of the Louvain local
+// moving heuristic, and the modularization found so far is returned with the
+// error if it is not nil.
+func modularize(g graph.Graph, resolution float64, src *rand.Rand, cancelled func() error) (ReducedGraph, error) {
 	switch g := g.(type) {
 	case graph.Undirected:
-		return louvainUndirected(g, resolution, src)
+		return louvainUndirected(g, resolution, src, cancelled)
 	case graph.Directed:
-		return louvainDirected(g, resolution, src)
+		return louvainDirected(g, resolution, src, cancelled)
 	default:
 		panic(fmt.Sprintf("community: invalid graph type: %T", g))
 	}
@@ -72,8 +72,10 @@ func qDirected(g graph.Directed, communities [][]graph.Node, resolution float64)
 // louvainDirected returns the hierarchical modularization of g at the given
 // resolution using the Louvain algorithm. If src is nil, rand.Intn is used
 // as the random generator. louvainDirected will panic if g has any edge with negative
-// edge weight.
-func louvainDirected(g graph.Directed, resolution float64, src *rand.Rand) ReducedGraph {
+// edge weight. If cancelled is not nil and returns a non-nil error before a pass of
+// the local moving heuristic, the modularization found so far is returned with
+// the error.
+func louvainDirected(g graph.Directed, resolution float64, src *rand.Rand, cancelled func() error) (ReducedGraph, error) {
 	// See louvain.tex for a detailed description
 	// of the algorithm used here.
 
@@ -85,10 +87,19 @@ func louvainDirected(g graph.Directed, resolution float64, src *rand.Rand) Reduc
 	for {
 		l := newDirectedLocalMover(c, c.communities, resolution)
 		if l == nil {
-			return c
+			return c, nil
 		}
-		if done := l.localMovingHeuristic(rnd); done {
-			return c
+		done, err := l.localMovingHeuristic(rnd, cancelled)
+		if err != nil {
+			// Keep any moves made before
+			// the cancellation.
+			if l.changed {
+				c = reduceDirected(c, l.communities)
+			}
+			return c, err
+		}
+		if done {
+			return c, nil
 		}
 		c = reduceDirected(c, l.communities)
 	}
@@ -499,9 +510,15 @@ func newDirectedLocalMover(g *ReducedDirected, communities [][]graph.Node, resol
 // localMovingHeuristic performs the Louvain local moving heuristic until
 // no further moves can be made. It returns a boolean indicating that the
 // directedLocalMover has not made any improvement to the community structure and
-// so the Louvain algorithm is done.
-func (l *directedLocalMover) localMovingHeuristic(rnd func(int) int) (done bool) {
+// so the Louvain algorithm is done. If cancelled is not nil and returns a non-nil
+// error before a pass over the nodes, the heuristic stops and returns the error.
+func (l *directedLocalMover) localMovingHeuristic(rnd func(int) int, cancelled func() error) (done bool, err error) {
 	for {
+		if cancelled != nil {
+			if err := cancelled(); err != nil {
+				return false, err
+			}
+		}
 		l.shuffle(rnd)
 		for _, n := range l.nodes {
 			dQ, dst, src := l.deltaQ(n)
@@ -511,7 +528,7 @@ func (l *directedLocalMover) localMovingHeuristic(rnd func(int) int) (done bool)
 			l.move(dst, src)
 		}
 		if !l.moved {
-			return !l.changed
+			return !l.changed, nil
 		}
 	}
 }
@@ -70,10 +70,12 @@ func qUndirected(g graph.Undirected, communities [][]graph.Node, resolution floa
 // louvainUndirected returns the hierarchical modularization of g at the given
 // resolution using the Louvain algorithm. If src is nil, rand.Intn is used as
 // the random generator. louvainUndirected will panic if g has any edge with negative edge
-// weight.
+// weight. If cancelled is not nil and returns a non-nil error before a pass of
+// the local moving heuristic, the modularization found so far is returned with
+// the error.
 //
 // graph.Undirect may be used as a shim to allow modularization of directed graphs.
-func louvainUndirected(g graph.Undirected, resolution float64, src *rand.Rand) *ReducedUndirected {
+func louvainUndirected(g graph.Undirected, resolution float64, src *rand.Rand, cancelled func() error) (*ReducedUndirected, error) {
 	// See louvain.tex for a detailed description
 	// of the algorithm used here.
 
@@ -85,10 +87,19 @@ func louvainUndirected(g graph.Undirected, resolution float64, src *rand.Rand) *
 	for {
 		l := newUndirectedLocalMover(c, c.communities, resolution)
 		if l == nil {
-			return c
+			return c, nil
 		}
-		if done := l.localMovingHeuristic(rnd); done {
-			return c
+		done, err := l.localMovingHeuristic(rnd, cancelled)
+		if err != nil {
+			// Keep any moves made before
+			// the cancellation.
+			if l.changed {
+				c = reduceUndirected(c, l.communities)
+			}
+			return c, err
+		}
+		if done {
+			return c, nil
 		}
 		c = reduceUndirected(c, l.communities)
 	}
@@ -446,9 +457,16 @@ func newUndirectedLocalMover(g *ReducedUndirected, communities [][]graph.Node, r
 // localMovingHeuristic performs the Louvain local moving heuristic until
 // no further moves can be made. It returns a boolean indicating that the
 // undirectedLocalMover has not made any improvement to the community
-// structure and so the Louvain algorithm is done.
-func (l *undirectedLocalMover) localMovingHeuristic(rnd func(int) int) (done bool) {
+// structure and so the Louvain algorithm is done. If cancelled is not nil and
+// returns a non-nil error before a pass over the nodes, the heuristic stops and
+// returns the error.
+func (l *undirectedLocalMover) localMovingHeuristic(rnd func(int) int, cancelled func() error) (done bool, err error) {
 	for {
+		if cancelled != nil {
+			if err := cancelled(); err != nil {
+				return false, err
+			}
+		}
 		l.shuffle(rnd)
 		for _, n := range l.nodes {
 			dQ, dst, src := l.deltaQ(n)
@@ -458,7 +476,7 @@ func (l *undirectedLocalMover) localMovingHeuristic(rnd func(int) int) (done boo
 			l.move(dst, src)
 		}
 		if !l.moved {
-			return !l.changed
+			return !l.changed, nil
 		}
 	}
 }
@@ -5,6 +5,7 @@
 package community
 
 import (
+	"errors"
 	"math"
 	"math/rand"
 	"reflect"
@@ -646,3 +647,43 @@ func BenchmarkLouvain(b *testing.B) {
 		Modularize(dupGraph, 1, src)
 	}
 }
+
+func TestModularizeUndirectedCancelled(t *testing.T) {
+	g := simple.NewUndirectedGraph(0, 0)
+	for u, e := range smallDumbell {
+		if !g.Has(simple.Node(u)) {
+			g.AddNode(simple.Node(u))
+		}
+		for v := range e {
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v), W: 1})
+		}
+	}
+
+	// Allow a single pass of the local moving heuristic.
+	errStop := errors.New("stop")
+	var calls int
+	got, err := modularize(g, 1, rand.New(rand.NewSource(1)), func() error {
+		if calls++; calls > 1 {
+			return errStop
+		}
+		return nil
+	})
+	if err != errStop {
+		t.Errorf("unexpected error for partial modularization: got:%v want:%v", err, errStop)
+	}
+	if got == nil {
+		t.Fatal("unexpected nil result for partial modularization")
+	}
+	seen := make(map[int]bool)
+	for _, c := range got.Communities() {
+		for _, n := range c {
+			if seen[n.ID()] {
+				t.Errorf("node %d in more than one community", n.ID())
+			}
+			seen[n.ID()] = true
+		}
+	}
+	if len(seen) != len(g.Nodes()) {
+		t.Errorf("unexpected number of nodes in communities: got:%d want:%d", len(seen), len(g.Nodes()))
+	}
+}
@@ -0,0 +1,10 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This repository is no longer maintained.
+// Development has moved to https://github.com/gonum/gonum.
+//
+// Package grid provides graphs of nodes laid out on regular square,
+// hexagonal and voxel grids.
+package grid
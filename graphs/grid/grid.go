@@ -2,15 +2,13 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-// This repository is no longer maintained.
-// Development has moved to https://github.com/gonum/gonum.
-//
-package internal
+package grid
 
 import (
 	"errors"
 	"fmt"
 	"math"
+	"strings"
 
 	"github.com/gonum/graph"
 	"github.com/gonum/graph/simple"
@@ -23,6 +21,9 @@ const (
 )
 
 // Grid is a 2D grid planar undirected graph.
+//
+// Grid implements path.HeuristicCoster, so path searches such as AStar
+// and DStarLite use its HeuristicCost when they are given a nil heuristic.
 type Grid struct {
 	// AllowDiagonal specifies whether
 	// diagonally adjacent nodes can
@@ -42,6 +43,11 @@ type Grid struct {
 
 	open []bool
 	r, c int
+
+	// cost holds the per-cell traversal
+	// costs of the grid. A nil cost
+	// indicates all cells have unit cost.
+	cost []float64
 }
 
 // NewGrid returns an r by c grid with all positions
@@ -72,24 +78,64 @@ func NewGridFrom(rows ...string) *Grid {
 			panic("grid: unequal row lengths")
 		}
 	}
+	if len(rows[0]) == 0 {
+		return &Grid{open: []bool{}, r: len(rows)}
+	}
+	g, err := ParseGrid([]byte(strings.Join(rows, "\n")))
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// ParseGrid returns a grid specified by the text in b. Rows of the grid
+// are separated by newlines and must all be the same length. Each row
+// must only contain the Open or Closed characters. A trailing newline
+// is ignored.
+func ParseGrid(b []byte) (*Grid, error) {
+	rows, err := parseRows(b, Open, Closed)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("grid: no rows")
+	}
 	states := make([]bool, 0, len(rows)*len(rows[0]))
 	for _, r := range rows {
-		for _, b := range r {
-			switch b {
-			case Closed:
-				states = append(states, false)
-			case Open:
-				states = append(states, true)
-			default:
-				panic(fmt.Sprintf("grid: invalid state: %q", r))
-			}
-		}
+		states = append(states, r...)
 	}
 	return &Grid{
 		open: states,
 		r:    len(rows),
 		c:    len(rows[0]),
+	}, nil
+}
+
+// parseRows parses newline separated rows of open and closed cell
+// states from b. All rows must be the same length.
+func parseRows(b []byte, open, closed byte) ([][]bool, error) {
+	text := strings.TrimSuffix(string(b), "\n")
+	if text == "" {
+		return nil, nil
 	}
+	var rows [][]bool
+	for i, line := range strings.Split(text, "\n") {
+		if i != 0 && len(line) != len(rows[0]) {
+			return nil, errors.New("grid: unequal row lengths")
+		}
+		row := make([]bool, len(line))
+		for j := 0; j < len(line); j++ {
+			switch line[j] {
+			case closed:
+			case open:
+				row[j] = true
+			default:
+				return nil, fmt.Errorf("grid: invalid state: %q", line)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
 }
 
 // Nodes returns all the open nodes in the grid if AllVisible is
@@ -132,6 +178,54 @@ func (g *Grid) Set(r, c int, open bool) {
 	g.open[r*g.c+c] = open
 }
 
+// SetCost sets the traversal cost of the cell at position (r, c). The
+// weight of an edge between two cells is the distance between the cells
+// multiplied by the mean of their traversal costs. Cells have unit cost
+// unless otherwise set. SetCost will panic if cost is not positive.
+func (g *Grid) SetCost(r, c int, cost float64) {
+	if r < 0 || r >= g.r {
+		panic("grid: illegal row index")
+	}
+	if c < 0 || c >= g.c {
+		panic("grid: illegal column index")
+	}
+	g.cost = setCost(g.cost, len(g.open), r*g.c+c, cost)
+}
+
+// setCost sets the traversal cost of cell i of a grid with n cells
+// and returns the updated costs. If costs is nil, it is allocated
+// with unit costs unless cost is also a unit cost.
+func setCost(costs []float64, n, i int, cost float64) []float64 {
+	if !(cost > 0) {
+		panic("grid: non-positive cost")
+	}
+	if costs == nil {
+		if cost == 1 {
+			return nil
+		}
+		costs = make([]float64, n)
+		for i := range costs {
+			costs[i] = 1
+		}
+	}
+	costs[i] = cost
+	return costs
+}
+
+// Cost returns the traversal cost of the cell at position (r, c).
+func (g *Grid) Cost(r, c int) float64 {
+	if r < 0 || r >= g.r {
+		panic("grid: illegal row index")
+	}
+	if c < 0 || c >= g.c {
+		panic("grid: illegal column index")
+	}
+	if g.cost == nil {
+		return 1
+	}
+	return g.cost[r*g.c+c]
+}
+
 // Dims returns the dimensions of the grid.
 func (g *Grid) Dims() (r, c int) {
 	return g.r, g.c
@@ -210,12 +304,7 @@ func (g *Grid) Edge(u, v graph.Node) graph.Edge {
 // EdgeBetween returns the edge between u and v.
 func (g *Grid) EdgeBetween(u, v graph.Node) graph.Edge {
 	if g.HasEdgeBetween(u, v) {
-		if !g.AllowDiagonal || g.UnitEdgeWeight {
-			return simple.Edge{F: u, T: v, W: 1}
-		}
-		ux, uy := g.XY(u)
-		vx, vy := g.XY(v)
-		return simple.Edge{F: u, T: v, W: math.Hypot(ux-vx, uy-vy)}
+		return simple.Edge{F: u, T: v, W: g.weight(u.ID(), v.ID())}
 	}
 	return nil
 }
@@ -228,15 +317,111 @@ func (g *Grid) Weight(x, y graph.Node) (w float64, ok bool) {
 	if !g.HasEdgeBetween(x, y) {
 		return math.Inf(1), false
 	}
-	if e := g.EdgeBetween(x, y); e != nil {
-		if !g.AllowDiagonal || g.UnitEdgeWeight {
-			return 1, true
-		}
-		ux, uy := g.XY(e.From())
-		vx, vy := g.XY(e.To())
-		return math.Hypot(ux-vx, uy-vy), true
+	return g.weight(x.ID(), y.ID()), true
+}
+
+// weight returns the weight of an edge between the
+// adjacent cells with IDs uid and vid.
+func (g *Grid) weight(uid, vid int) float64 {
+	w := 1.0
+	if g.AllowDiagonal && !g.UnitEdgeWeight {
+		ur, uc := g.RowCol(uid)
+		vr, vc := g.RowCol(vid)
+		w = math.Hypot(float64(ur-vr), float64(uc-vc))
+	}
+	if g.cost != nil {
+		w *= (g.cost[uid] + g.cost[vid]) / 2
+	}
+	return w
+}
+
+// minCost returns the minimum of the given traversal costs,
+// with a nil costs indicating unit costs.
+func minCost(costs []float64) float64 {
+	if costs == nil {
+		return 1
+	}
+	min := math.Inf(1)
+	for _, c := range costs {
+		min = math.Min(min, c)
+	}
+	return min
+}
+
+// HeuristicCost returns an admissible and consistent estimate of the cost
+// of the path between x and y. The heuristic is the ManhattanDistance
+// if AllowDiagonal is false, the ChebyshevDistance if AllowDiagonal and
+// UnitEdgeWeight are true and the OctileDistance otherwise, scaled by the
+// minimum traversal cost of the cells in the grid. HeuristicCost satisfies
+// the path.HeuristicCoster interface.
+func (g *Grid) HeuristicCost(x, y graph.Node) float64 {
+	var d float64
+	switch {
+	case !g.AllowDiagonal:
+		d = g.ManhattanDistance(x, y)
+	case g.UnitEdgeWeight:
+		d = g.ChebyshevDistance(x, y)
+	default:
+		d = g.OctileDistance(x, y)
+	}
+	return d * minCost(g.cost)
+}
+
+// ManhattanDistance returns the Manhattan distance between x and y. This
+// is the length of the shortest path between x and y on an open 4-connected
+// grid. If either of x or y is not in the grid, NaN is returned.
+func (g *Grid) ManhattanDistance(x, y graph.Node) float64 {
+	dr, dc, ok := g.delta(x, y)
+	if !ok {
+		return math.NaN()
+	}
+	return dr + dc
+}
+
+// ChebyshevDistance returns the Chebyshev distance between x and y. This
+// is the length of the shortest path between x and y on an open 8-connected
+// grid with unit edge weights. If either of x or y is not in the grid, NaN
+// is returned.
+func (g *Grid) ChebyshevDistance(x, y graph.Node) float64 {
+	dr, dc, ok := g.delta(x, y)
+	if !ok {
+		return math.NaN()
+	}
+	return math.Max(dr, dc)
+}
+
+// OctileDistance returns the octile distance between x and y. This is the
+// length of the shortest path between x and y on an open 8-connected grid
+// with Euclidean edge weights. If either of x or y is not in the grid, NaN
+// is returned.
+func (g *Grid) OctileDistance(x, y graph.Node) float64 {
+	dr, dc, ok := g.delta(x, y)
+	if !ok {
+		return math.NaN()
+	}
+	return math.Max(dr, dc) + (math.Sqrt2-1)*math.Min(dr, dc)
+}
+
+// EuclideanDistance returns the straight line distance between x and y.
+// If either of x or y is not in the grid, NaN is returned.
+func (g *Grid) EuclideanDistance(x, y graph.Node) float64 {
+	dr, dc, ok := g.delta(x, y)
+	if !ok {
+		return math.NaN()
+	}
+	return math.Hypot(dr, dc)
+}
+
+// delta returns the absolute row and column differences between x and y.
+func (g *Grid) delta(x, y graph.Node) (dr, dc float64, ok bool) {
+	xid := x.ID()
+	yid := y.ID()
+	if xid < 0 || xid >= len(g.open) || yid < 0 || yid >= len(g.open) {
+		return 0, 0, false
 	}
-	return math.Inf(1), true
+	xr, xc := g.RowCol(xid)
+	yr, yc := g.RowCol(yid)
+	return math.Abs(float64(xr - yr)), math.Abs(float64(xc - yc)), true
 }
 
 // String returns a string representation of the grid.
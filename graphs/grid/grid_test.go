@@ -2,16 +2,18 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-package internal
+package grid
 
 import (
 	"bytes"
 	"errors"
+	"math"
 	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/gonum/graph"
+	"github.com/gonum/graph/path"
 	"github.com/gonum/graph/simple"
 )
 
@@ -256,3 +258,133 @@ func TestGrid(t *testing.T) {
 		}
 	}
 }
+
+func TestParseGrid(t *testing.T) {
+	for _, test := range []struct {
+		text    string
+		want    string
+		wantErr bool
+	}{
+		{text: "*..*\n**.*\n", want: join("*..*", "**.*")},
+		{text: "*..*\n**.*", want: join("*..*", "**.*")},
+		{text: "*..*\n**.", wantErr: true},
+		{text: "*..*\n**x*", wantErr: true},
+		{text: "", wantErr: true},
+	} {
+		g, err := ParseGrid([]byte(test.text))
+		if (err != nil) != test.wantErr {
+			t.Errorf("unexpected error for %q: got:%v want error:%t", test.text, err, test.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if got := g.String(); got != test.want {
+			t.Errorf("unexpected grid rendering for %q:\ngot: %q\nwant:%q", test.text, got, test.want)
+		}
+	}
+}
+
+func TestGridCost(t *testing.T) {
+	g := NewGrid(3, 3, true)
+	if c := g.Cost(1, 1); c != 1 {
+		t.Errorf("unexpected default cost: got:%v want:1", c)
+	}
+	g.SetCost(1, 1, 5)
+	if c := g.Cost(1, 1); c != 5 {
+		t.Errorf("unexpected cost: got:%v want:5", c)
+	}
+	if w, _ := g.Weight(g.NodeAt(1, 0), g.NodeAt(1, 1)); w != 3 {
+		t.Errorf("unexpected weight into costly cell: got:%v want:3", w)
+	}
+	if w, _ := g.Weight(g.NodeAt(0, 0), g.NodeAt(0, 1)); w != 1 {
+		t.Errorf("unexpected weight between unit cost cells: got:%v want:1", w)
+	}
+
+	pt := path.DijkstraFrom(g.NodeAt(1, 0), g)
+	p, w := pt.To(g.NodeAt(1, 2))
+	if w != 4 || len(p) != 5 {
+		t.Errorf("unexpected path avoiding costly cell: got:%v weight=%v want weight=4", p, w)
+	}
+
+	var panicked bool
+	func() {
+		defer func() {
+			panicked = recover() != nil
+		}()
+		g.SetCost(0, 0, 0)
+	}()
+	if !panicked {
+		t.Error("expected panic for zero cost")
+	}
+}
+
+func TestGridHeuristic(t *testing.T) {
+	for _, test := range []struct {
+		diagonal, unit bool
+	}{
+		{diagonal: false},
+		{diagonal: true, unit: true},
+		{diagonal: true, unit: false},
+	} {
+		g := NewGrid(5, 6, true)
+		g.AllowDiagonal = test.diagonal
+		g.UnitEdgeWeight = test.unit
+		for _, u := range g.Nodes() {
+			pt := path.DijkstraFrom(u, g)
+			for _, v := range g.Nodes() {
+				h := g.HeuristicCost(u, v)
+				w := pt.WeightTo(v)
+				if math.Abs(h-w) > 1e-12 {
+					t.Errorf("unexpected heuristic on open grid between %d and %d with diagonal=%t unit=%t: h=%v w=%v",
+						u.ID(), v.ID(), test.diagonal, test.unit, h, w)
+				}
+			}
+		}
+
+		g.SetCost(2, 2, 0.5)
+		g.SetCost(2, 3, 5)
+		g.Set(1, 3, false)
+		for _, u := range g.Nodes() {
+			pt := path.DijkstraFrom(u, g)
+			for _, v := range g.Nodes() {
+				h := g.HeuristicCost(u, v)
+				w := pt.WeightTo(v)
+				if h > w+1e-12 {
+					t.Errorf("inadmissible heuristic between %d and %d with diagonal=%t unit=%t: h=%v w=%v",
+						u.ID(), v.ID(), test.diagonal, test.unit, h, w)
+				}
+			}
+		}
+	}
+
+	g := NewGrid(3, 4, true)
+	u, v := g.NodeAt(0, 0), g.NodeAt(2, 3)
+	for _, test := range []struct {
+		name string
+		h    func(x, y graph.Node) float64
+		want float64
+	}{
+		{name: "manhattan", h: g.ManhattanDistance, want: 5},
+		{name: "chebyshev", h: g.ChebyshevDistance, want: 3},
+		{name: "octile", h: g.OctileDistance, want: 1 + 2*math.Sqrt2},
+		{name: "euclidean", h: g.EuclideanDistance, want: math.Hypot(2, 3)},
+	} {
+		if got := test.h(u, v); math.Abs(got-test.want) > 1e-12 {
+			t.Errorf("unexpected %s distance: got:%v want:%v", test.name, got, test.want)
+		}
+		if got := test.h(u, node(-1)); !math.IsNaN(got) {
+			t.Errorf("expected NaN %s distance for node outside grid: got:%v", test.name, got)
+		}
+	}
+}
+
+func TestNewGridFromEmptyRows(t *testing.T) {
+	g := NewGridFrom("")
+	if r, c := g.Dims(); r != 1 || c != 0 {
+		t.Errorf("unexpected dimensions for empty row: got:%dx%d want:1x0", r, c)
+	}
+	if n := len(g.Nodes()); n != 0 {
+		t.Errorf("unexpected number of nodes for empty row: got:%d want:0", n)
+	}
+}
@@ -0,0 +1,331 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package grid
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+// HexGrid is a 2D hexagonal grid planar undirected graph. Cells are
+// laid out in rows with odd numbered rows offset to the right by half
+// a cell, so each cell has up to six neighbours. The distance between
+// the centres of adjacent cells is one.
+type HexGrid struct {
+	// AllVisible specifies whether
+	// non-open nodes are visible
+	// in calls to Nodes and HasNode.
+	AllVisible bool
+
+	open []bool
+	r, c int
+
+	// cost holds the per-cell traversal
+	// costs of the grid. A nil cost
+	// indicates all cells have unit cost.
+	cost []float64
+}
+
+// NewHexGrid returns an r by c hexagonal grid with all positions
+// set to the specified open state.
+func NewHexGrid(r, c int, open bool) *HexGrid {
+	states := make([]bool, r*c)
+	if open {
+		for i := range states {
+			states[i] = true
+		}
+	}
+	return &HexGrid{
+		open: states,
+		r:    r,
+		c:    c,
+	}
+}
+
+// NewHexGridFrom returns a hexagonal grid specified by the rows strings
+// as described for ParseHexGrid. NewHexGridFrom will panic if the rows
+// are not valid.
+func NewHexGridFrom(rows ...string) *HexGrid {
+	if len(rows) == 0 {
+		return nil
+	}
+	g, err := ParseHexGrid([]byte(strings.Join(rows, "\n")))
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// ParseHexGrid returns a hexagonal grid specified by the text in b. Rows
+// of the grid are separated by newlines and must contain the same number
+// of Open or Closed characters. Space characters are ignored, so the
+// format written by the String method, where cells are separated by
+// spaces and odd rows are indented, is accepted. A trailing newline is
+// ignored.
+func ParseHexGrid(b []byte) (*HexGrid, error) {
+	rows, err := parseRows([]byte(strings.Replace(string(b), " ", "", -1)), Open, Closed)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("grid: no rows")
+	}
+	states := make([]bool, 0, len(rows)*len(rows[0]))
+	for _, r := range rows {
+		states = append(states, r...)
+	}
+	return &HexGrid{
+		open: states,
+		r:    len(rows),
+		c:    len(rows[0]),
+	}, nil
+}
+
+// Nodes returns all the open nodes in the grid if AllVisible is
+// false, otherwise all nodes are returned.
+func (g *HexGrid) Nodes() []graph.Node {
+	var nodes []graph.Node
+	for id, ok := range g.open {
+		if ok || g.AllVisible {
+			nodes = append(nodes, simple.Node(id))
+		}
+	}
+	return nodes
+}
+
+// Has returns whether n is a node in the grid. The state of
+// the AllVisible field determines whether a non-open node is
+// present.
+func (g *HexGrid) Has(n graph.Node) bool {
+	id := n.ID()
+	return id >= 0 && id < len(g.open) && (g.AllVisible || g.open[id])
+}
+
+// HasOpen returns whether n is an open node in the grid.
+func (g *HexGrid) HasOpen(n graph.Node) bool {
+	id := n.ID()
+	return id >= 0 && id < len(g.open) && g.open[id]
+}
+
+// Set sets the node at position (r, c) to the specified open state.
+func (g *HexGrid) Set(r, c int, open bool) {
+	g.open[g.index(r, c)] = open
+}
+
+// SetCost sets the traversal cost of the cell at position (r, c). The
+// weight of an edge between two cells is the mean of their traversal
+// costs. Cells have unit cost unless otherwise set. SetCost will panic
+// if cost is not positive.
+func (g *HexGrid) SetCost(r, c int, cost float64) {
+	g.cost = setCost(g.cost, len(g.open), g.index(r, c), cost)
+}
+
+// Cost returns the traversal cost of the cell at position (r, c).
+func (g *HexGrid) Cost(r, c int) float64 {
+	i := g.index(r, c)
+	if g.cost == nil {
+		return 1
+	}
+	return g.cost[i]
+}
+
+// index returns the index of the cell at position (r, c) and
+// panics if the position is outside the grid.
+func (g *HexGrid) index(r, c int) int {
+	if r < 0 || r >= g.r {
+		panic("grid: illegal row index")
+	}
+	if c < 0 || c >= g.c {
+		panic("grid: illegal column index")
+	}
+	return r*g.c + c
+}
+
+// Dims returns the dimensions of the grid.
+func (g *HexGrid) Dims() (r, c int) {
+	return g.r, g.c
+}
+
+// RowCol returns the row and column of the id. RowCol will panic if the
+// node id is outside the range of the grid.
+func (g *HexGrid) RowCol(id int) (r, c int) {
+	if id < 0 || id >= len(g.open) {
+		panic("grid: illegal node id")
+	}
+	return id / g.c, id % g.c
+}
+
+// XY returns the cartesian coordinates of the centre of n. If n is not
+// a node in the grid, (NaN, NaN) is returned.
+func (g *HexGrid) XY(n graph.Node) (x, y float64) {
+	if !g.Has(n) {
+		return math.NaN(), math.NaN()
+	}
+	r, c := g.RowCol(n.ID())
+	return float64(c) + float64(r&1)/2, float64(r) * math.Sqrt(3) / 2
+}
+
+// NodeAt returns the node at (r, c). The returned node may be open or closed.
+func (g *HexGrid) NodeAt(r, c int) graph.Node {
+	if r < 0 || r >= g.r || c < 0 || c >= g.c {
+		return nil
+	}
+	return simple.Node(r*g.c + c)
+}
+
+// hexNeighbours holds the row and column offsets of the neighbours
+// of cells in even and odd rows.
+var hexNeighbours = [2][6][2]int{
+	{{0, -1}, {0, 1}, {-1, -1}, {-1, 0}, {1, -1}, {1, 0}},
+	{{0, -1}, {0, 1}, {-1, 0}, {-1, 1}, {1, 0}, {1, 1}},
+}
+
+// From returns all the nodes reachable from u. Reachabilty requires that both
+// ends of an edge must be open.
+func (g *HexGrid) From(u graph.Node) []graph.Node {
+	if !g.HasOpen(u) {
+		return nil
+	}
+	r, c := g.RowCol(u.ID())
+	var to []graph.Node
+	for _, d := range hexNeighbours[r&1] {
+		if v := g.NodeAt(r+d[0], c+d[1]); v != nil && g.HasOpen(v) {
+			to = append(to, v)
+		}
+	}
+	return to
+}
+
+// HasEdgeBetween returns whether there is an edge between u and v.
+func (g *HexGrid) HasEdgeBetween(u, v graph.Node) bool {
+	if !g.HasOpen(u) || !g.HasOpen(v) {
+		return false
+	}
+	return g.hexDistance(u.ID(), v.ID()) == 1
+}
+
+// Edge returns the edge between u and v.
+func (g *HexGrid) Edge(u, v graph.Node) graph.Edge {
+	return g.EdgeBetween(u, v)
+}
+
+// EdgeBetween returns the edge between u and v.
+func (g *HexGrid) EdgeBetween(u, v graph.Node) graph.Edge {
+	if g.HasEdgeBetween(u, v) {
+		return simple.Edge{F: u, T: v, W: g.weight(u.ID(), v.ID())}
+	}
+	return nil
+}
+
+// Weight returns the weight of the given edge.
+func (g *HexGrid) Weight(x, y graph.Node) (w float64, ok bool) {
+	if x.ID() == y.ID() {
+		return 0, true
+	}
+	if !g.HasEdgeBetween(x, y) {
+		return math.Inf(1), false
+	}
+	return g.weight(x.ID(), y.ID()), true
+}
+
+// weight returns the weight of an edge between the
+// adjacent cells with IDs uid and vid.
+func (g *HexGrid) weight(uid, vid int) float64 {
+	if g.cost == nil {
+		return 1
+	}
+	return (g.cost[uid] + g.cost[vid]) / 2
+}
+
+// HeuristicCost returns an admissible and consistent estimate of the cost
+// of the path between x and y. The heuristic is the HexDistance scaled by
+// the minimum traversal cost of the cells in the grid. HeuristicCost
+// satisfies the path.HeuristicCoster interface.
+func (g *HexGrid) HeuristicCost(x, y graph.Node) float64 {
+	return g.HexDistance(x, y) * minCost(g.cost)
+}
+
+// HexDistance returns the number of steps between x and y on an open
+// hexagonal grid. If either of x or y is not in the grid, NaN is returned.
+func (g *HexGrid) HexDistance(x, y graph.Node) float64 {
+	xid := x.ID()
+	yid := y.ID()
+	if xid < 0 || xid >= len(g.open) || yid < 0 || yid >= len(g.open) {
+		return math.NaN()
+	}
+	return float64(g.hexDistance(xid, yid))
+}
+
+func (g *HexGrid) hexDistance(uid, vid int) int {
+	// Convert offset coordinates to axial
+	// coordinates and use the cube distance.
+	ur, uc := g.RowCol(uid)
+	vr, vc := g.RowCol(vid)
+	uq := uc - (ur-ur&1)/2
+	vq := vc - (vr-vr&1)/2
+	dq := uq - vq
+	dr := ur - vr
+	return (abs(dq) + abs(dr) + abs(dq+dr)) / 2
+}
+
+// String returns a string representation of the grid.
+func (g *HexGrid) String() string {
+	b, _ := g.Render(nil)
+	return string(b)
+}
+
+// Render returns a text representation of the graph with the given path
+// included. Cells are separated by spaces and odd rows are indented by
+// a space. If the path is not a path in the grid Render returns a non-nil
+// error and the path up to that point.
+func (g *HexGrid) Render(path []graph.Node) ([]byte, error) {
+	rows := make([][]byte, g.r)
+	for r := range rows {
+		n := 2*g.c - 1 + r&1
+		if g.c == 0 {
+			n = 0
+		}
+		row := make([]byte, n)
+		for i := range row {
+			row[i] = ' '
+		}
+		for c := 0; c < g.c; c++ {
+			if g.open[r*g.c+c] {
+				row[2*c+r&1] = Open
+			} else {
+				row[2*c+r&1] = Closed
+			}
+		}
+		rows[r] = row
+	}
+
+	// We don't use topo.IsPathIn at the outset because we
+	// want to draw as much as possible before failing.
+	for i, n := range path {
+		if !g.Has(n) || (i != 0 && !g.HasEdgeBetween(path[i-1], n)) {
+			id := n.ID()
+			if id >= 0 && id < len(g.open) {
+				r, c := g.RowCol(n.ID())
+				rows[r][2*c+r&1] = '!'
+			}
+			return bytes.Join(rows, []byte{'\n'}), errors.New("grid: not a path in graph")
+		}
+		r, c := g.RowCol(n.ID())
+		switch i {
+		case len(path) - 1:
+			rows[r][2*c+r&1] = 'G'
+		case 0:
+			rows[r][2*c+r&1] = 'S'
+		default:
+			rows[r][2*c+r&1] = 'o'
+		}
+	}
+	return bytes.Join(rows, []byte{'\n'}), nil
+}
@@ -0,0 +1,130 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package grid
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+	"github.com/gonum/graph/path"
+)
+
+var _ graph.Graph = (*HexGrid)(nil)
+
+func TestHexGrid(t *testing.T) {
+	g := NewHexGridFrom(
+		". . * .",
+		" . . . .",
+		". * . .",
+	)
+	if r, c := g.Dims(); r != 3 || c != 4 {
+		t.Fatalf("unexpected dimensions: got:(%d, %d) want:(3, 4)", r, c)
+	}
+	want := join(
+		". . * .",
+		" . . . .",
+		". * . .",
+	)
+	if got := g.String(); got != want {
+		t.Fatalf("unexpected hex grid rendering:\ngot: %q\nwant:%q", got, want)
+	}
+	if got := NewHexGridFrom("..*.", "....", ".*..").String(); got != want {
+		t.Fatalf("unexpected hex grid rendering from unspaced rows:\ngot: %q\nwant:%q", got, want)
+	}
+
+	var reach = []struct {
+		from graph.Node
+		to   []int
+	}{
+		// Even row.
+		{from: node(1), to: []int{0, 4, 5}},
+		// Odd row.
+		{from: node(5), to: []int{1, 4, 6, 10}},
+		// Closed.
+		{from: node(2), to: nil},
+	}
+	for _, test := range reach {
+		var got []int
+		for _, n := range g.From(test.from) {
+			got = append(got, n.ID())
+			if !g.HasEdgeBetween(test.from, n) {
+				t.Errorf("expected edge between %d and %d", test.from.ID(), n.ID())
+			}
+		}
+		sort.Ints(got)
+		if !reflect.DeepEqual(got, test.to) {
+			t.Errorf("unexpected nodes from %d:\ngot: %v\nwant:%v", test.from.ID(), got, test.to)
+		}
+	}
+
+	b, err := g.Render([]graph.Node{node(0), node(4), node(5), node(10)})
+	if err != nil {
+		t.Fatalf("unexpected error rendering path: %v", err)
+	}
+	want = join(
+		"S . * .",
+		" o o . .",
+		". * G .",
+	)
+	if string(b) != want {
+		t.Errorf("unexpected hex grid path rendering:\ngot: %q\nwant:%q", b, want)
+	}
+	_, err = g.Render([]graph.Node{node(0), node(5)})
+	if err == nil {
+		t.Error("expected error rendering invalid path")
+	}
+
+	if _, err := ParseHexGrid([]byte("..\n...")); err == nil {
+		t.Error("expected error for unequal row lengths")
+	}
+}
+
+func TestHexGridHeuristic(t *testing.T) {
+	g := NewHexGrid(6, 7, true)
+	g.SetCost(2, 3, 4)
+	g.SetCost(3, 3, 0.5)
+	for _, u := range g.Nodes() {
+		pt := path.DijkstraFrom(u, g)
+		for _, v := range g.Nodes() {
+			h := g.HeuristicCost(u, v)
+			w := pt.WeightTo(v)
+			if h > w+1e-12 {
+				t.Errorf("inadmissible heuristic between %d and %d: h=%v w=%v", u.ID(), v.ID(), h, w)
+			}
+		}
+	}
+
+	g = NewHexGrid(6, 7, true)
+	for _, u := range g.Nodes() {
+		pt := path.DijkstraFrom(u, g)
+		for _, v := range g.Nodes() {
+			if d, w := g.HexDistance(u, v), pt.WeightTo(v); d != w {
+				t.Errorf("unexpected hex distance between %d and %d: got:%v want:%v", u.ID(), v.ID(), d, w)
+			}
+		}
+	}
+	if d := g.HexDistance(node(-1), node(0)); !math.IsNaN(d) {
+		t.Errorf("expected NaN distance for node outside grid: got:%v", d)
+	}
+}
+
+func TestHexGridXY(t *testing.T) {
+	g := NewHexGrid(4, 4, true)
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+	for _, u := range nodes {
+		ux, uy := g.XY(u)
+		for _, v := range g.From(u) {
+			vx, vy := g.XY(v)
+			if d := math.Hypot(ux-vx, uy-vy); math.Abs(d-1) > 1e-12 {
+				t.Errorf("unexpected distance between neighbours %d and %d: got:%v want:1", u.ID(), v.ID(), d)
+			}
+		}
+	}
+}
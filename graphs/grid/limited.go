@@ -2,7 +2,7 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-package internal
+package grid
 
 import (
 	"errors"
@@ -16,6 +16,10 @@ import (
 // to determine the presence of edges is dependent on the current and past
 // positions on the grid. In the absence of information, the grid is
 // optimistic.
+//
+// LimitedVisionGrid implements path.HeuristicCoster, so path searches such
+// as AStar and DStarLite use its HeuristicCost when they are given a nil
+// heuristic.
 type LimitedVisionGrid struct {
 	Grid *Grid
 
@@ -223,12 +227,7 @@ func (l *LimitedVisionGrid) Edge(u, v graph.Node) graph.Edge {
 // EdgeBetween optimistically returns the edge between u and v.
 func (l *LimitedVisionGrid) EdgeBetween(u, v graph.Node) graph.Edge {
 	if l.HasEdgeBetween(u, v) {
-		if !l.Grid.AllowDiagonal || l.Grid.UnitEdgeWeight {
-			return simple.Edge{F: u, T: v, W: 1}
-		}
-		ux, uy := l.XY(u)
-		vx, vy := l.XY(v)
-		return simple.Edge{F: u, T: v, W: math.Hypot(ux-vx, uy-vy)}
+		return simple.Edge{F: u, T: v, W: l.Grid.weight(u.ID(), v.ID())}
 	}
 	return nil
 }
@@ -241,16 +240,13 @@ func (l *LimitedVisionGrid) Weight(x, y graph.Node) (w float64, ok bool) {
 	if !l.HasEdgeBetween(x, y) {
 		return math.Inf(1), false
 	}
-	if e := l.EdgeBetween(x, y); e != nil {
-		if !l.Grid.AllowDiagonal || l.Grid.UnitEdgeWeight {
-			return 1, true
-		}
-		ux, uy := l.XY(e.From())
-		vx, vy := l.XY(e.To())
-		return math.Hypot(ux-vx, uy-vy), true
+	return l.Grid.weight(x.ID(), y.ID()), true
+}
 
-	}
-	return math.Inf(1), true
+// HeuristicCost returns an admissible and consistent estimate of the cost
+// of the path between x and y as described for the underlying Grid.
+func (l *LimitedVisionGrid) HeuristicCost(x, y graph.Node) float64 {
+	return l.Grid.HeuristicCost(x, y)
 }
 
 // String returns a string representation of the grid.
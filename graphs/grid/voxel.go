@@ -0,0 +1,427 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package grid
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+// VoxelGrid is a 3D grid undirected graph. Cells are arranged in layers of
+// rows and columns and each cell is connected to the open cells that share
+// a face with it, or if AllowDiagonal is true, that share a face, edge or
+// vertex with it.
+type VoxelGrid struct {
+	// AllowDiagonal specifies whether
+	// diagonally adjacent nodes can
+	// be connected by an edge.
+	AllowDiagonal bool
+	// UnitEdgeWeight specifies whether
+	// finite edge weights are returned as
+	// the unit length. Otherwise edge
+	// weights are the Euclidean distance
+	// between connected nodes.
+	UnitEdgeWeight bool
+
+	// AllVisible specifies whether
+	// non-open nodes are visible
+	// in calls to Nodes and HasNode.
+	AllVisible bool
+
+	open    []bool
+	l, r, c int
+
+	// cost holds the per-cell traversal
+	// costs of the grid. A nil cost
+	// indicates all cells have unit cost.
+	cost []float64
+}
+
+// NewVoxelGrid returns an l by r by c grid with all positions
+// set to the specified open state.
+func NewVoxelGrid(l, r, c int, open bool) *VoxelGrid {
+	states := make([]bool, l*r*c)
+	if open {
+		for i := range states {
+			states[i] = true
+		}
+	}
+	return &VoxelGrid{
+		open: states,
+		l:    l,
+		r:    r,
+		c:    c,
+	}
+}
+
+// NewVoxelGridFrom returns a voxel grid specified by the layers. Each
+// layer is specified by rows strings as for NewGridFrom. All layers must
+// have the same dimensions. NewVoxelGridFrom will panic if the layers
+// are not valid.
+func NewVoxelGridFrom(layers ...[]string) *VoxelGrid {
+	if len(layers) == 0 {
+		return nil
+	}
+	text := make([]string, len(layers))
+	for i, l := range layers {
+		text[i] = strings.Join(l, "\n")
+	}
+	g, err := ParseVoxelGrid([]byte(strings.Join(text, "\n\n")))
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// ParseVoxelGrid returns a voxel grid specified by the text in b. Layers
+// of the grid are separated by blank lines and are specified in the format
+// accepted by ParseGrid. All layers must have the same dimensions.
+func ParseVoxelGrid(b []byte) (*VoxelGrid, error) {
+	var (
+		states []bool
+		l, r   int
+		c      = -1
+	)
+	for _, layer := range strings.Split(strings.TrimSuffix(string(b), "\n"), "\n\n") {
+		rows, err := parseRows([]byte(layer), Open, Closed)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 0 {
+			return nil, errors.New("grid: empty layer")
+		}
+		if c < 0 {
+			r = len(rows)
+			c = len(rows[0])
+		} else if len(rows) != r || len(rows[0]) != c {
+			return nil, errors.New("grid: unequal layer dimensions")
+		}
+		for _, row := range rows {
+			states = append(states, row...)
+		}
+		l++
+	}
+	return &VoxelGrid{
+		open: states,
+		l:    l,
+		r:    r,
+		c:    c,
+	}, nil
+}
+
+// Nodes returns all the open nodes in the grid if AllVisible is
+// false, otherwise all nodes are returned.
+func (g *VoxelGrid) Nodes() []graph.Node {
+	var nodes []graph.Node
+	for id, ok := range g.open {
+		if ok || g.AllVisible {
+			nodes = append(nodes, simple.Node(id))
+		}
+	}
+	return nodes
+}
+
+// Has returns whether n is a node in the grid. The state of
+// the AllVisible field determines whether a non-open node is
+// present.
+func (g *VoxelGrid) Has(n graph.Node) bool {
+	id := n.ID()
+	return id >= 0 && id < len(g.open) && (g.AllVisible || g.open[id])
+}
+
+// HasOpen returns whether n is an open node in the grid.
+func (g *VoxelGrid) HasOpen(n graph.Node) bool {
+	id := n.ID()
+	return id >= 0 && id < len(g.open) && g.open[id]
+}
+
+// Set sets the node at position (l, r, c) to the specified open state.
+func (g *VoxelGrid) Set(l, r, c int, open bool) {
+	g.open[g.index(l, r, c)] = open
+}
+
+// SetCost sets the traversal cost of the cell at position (l, r, c). The
+// weight of an edge between two cells is the distance between the cells
+// multiplied by the mean of their traversal costs. Cells have unit cost
+// unless otherwise set. SetCost will panic if cost is not positive.
+func (g *VoxelGrid) SetCost(l, r, c int, cost float64) {
+	g.cost = setCost(g.cost, len(g.open), g.index(l, r, c), cost)
+}
+
+// Cost returns the traversal cost of the cell at position (l, r, c).
+func (g *VoxelGrid) Cost(l, r, c int) float64 {
+	i := g.index(l, r, c)
+	if g.cost == nil {
+		return 1
+	}
+	return g.cost[i]
+}
+
+// index returns the index of the cell at position (l, r, c) and
+// panics if the position is outside the grid.
+func (g *VoxelGrid) index(l, r, c int) int {
+	if l < 0 || l >= g.l {
+		panic("grid: illegal layer index")
+	}
+	if r < 0 || r >= g.r {
+		panic("grid: illegal row index")
+	}
+	if c < 0 || c >= g.c {
+		panic("grid: illegal column index")
+	}
+	return (l*g.r+r)*g.c + c
+}
+
+// Dims returns the dimensions of the grid.
+func (g *VoxelGrid) Dims() (l, r, c int) {
+	return g.l, g.r, g.c
+}
+
+// LayerRowCol returns the layer, row and column of the id. LayerRowCol
+// will panic if the node id is outside the range of the grid.
+func (g *VoxelGrid) LayerRowCol(id int) (l, r, c int) {
+	if id < 0 || id >= len(g.open) {
+		panic("grid: illegal node id")
+	}
+	return id / (g.r * g.c), (id / g.c) % g.r, id % g.c
+}
+
+// XYZ returns the cartesian coordinates of n. If n is not a node
+// in the grid, (NaN, NaN, NaN) is returned.
+func (g *VoxelGrid) XYZ(n graph.Node) (x, y, z float64) {
+	if !g.Has(n) {
+		return math.NaN(), math.NaN(), math.NaN()
+	}
+	l, r, c := g.LayerRowCol(n.ID())
+	return float64(c), float64(r), float64(l)
+}
+
+// NodeAt returns the node at (l, r, c). The returned node may be open or closed.
+func (g *VoxelGrid) NodeAt(l, r, c int) graph.Node {
+	if l < 0 || l >= g.l || r < 0 || r >= g.r || c < 0 || c >= g.c {
+		return nil
+	}
+	return simple.Node((l*g.r+r)*g.c + c)
+}
+
+// From returns all the nodes reachable from u. Reachabilty requires that both
+// ends of an edge must be open.
+func (g *VoxelGrid) From(u graph.Node) []graph.Node {
+	if !g.HasOpen(u) {
+		return nil
+	}
+	nl, nr, nc := g.LayerRowCol(u.ID())
+	var to []graph.Node
+	for l := nl - 1; l <= nl+1; l++ {
+		for r := nr - 1; r <= nr+1; r++ {
+			for c := nc - 1; c <= nc+1; c++ {
+				if v := g.NodeAt(l, r, c); v != nil && g.HasEdgeBetween(u, v) {
+					to = append(to, v)
+				}
+			}
+		}
+	}
+	return to
+}
+
+// HasEdgeBetween returns whether there is an edge between u and v.
+func (g *VoxelGrid) HasEdgeBetween(u, v graph.Node) bool {
+	if !g.HasOpen(u) || !g.HasOpen(v) || u.ID() == v.ID() {
+		return false
+	}
+	d := g.delta(u.ID(), v.ID())
+	if d[0] > 1 || d[1] > 1 || d[2] > 1 {
+		return false
+	}
+	return g.AllowDiagonal || d[0]+d[1]+d[2] == 1
+}
+
+// Edge returns the edge between u and v.
+func (g *VoxelGrid) Edge(u, v graph.Node) graph.Edge {
+	return g.EdgeBetween(u, v)
+}
+
+// EdgeBetween returns the edge between u and v.
+func (g *VoxelGrid) EdgeBetween(u, v graph.Node) graph.Edge {
+	if g.HasEdgeBetween(u, v) {
+		return simple.Edge{F: u, T: v, W: g.weight(u.ID(), v.ID())}
+	}
+	return nil
+}
+
+// Weight returns the weight of the given edge.
+func (g *VoxelGrid) Weight(x, y graph.Node) (w float64, ok bool) {
+	if x.ID() == y.ID() {
+		return 0, true
+	}
+	if !g.HasEdgeBetween(x, y) {
+		return math.Inf(1), false
+	}
+	return g.weight(x.ID(), y.ID()), true
+}
+
+// weight returns the weight of an edge between the
+// adjacent cells with IDs uid and vid.
+func (g *VoxelGrid) weight(uid, vid int) float64 {
+	w := 1.0
+	if g.AllowDiagonal && !g.UnitEdgeWeight {
+		d := g.delta(uid, vid)
+		w = math.Sqrt(float64(d[0] + d[1] + d[2]))
+	}
+	if g.cost != nil {
+		w *= (g.cost[uid] + g.cost[vid]) / 2
+	}
+	return w
+}
+
+// delta returns the absolute layer, row and column differences
+// between the cells with IDs uid and vid.
+func (g *VoxelGrid) delta(uid, vid int) [3]int {
+	ul, ur, uc := g.LayerRowCol(uid)
+	vl, vr, vc := g.LayerRowCol(vid)
+	return [3]int{abs(ul - vl), abs(ur - vr), abs(uc - vc)}
+}
+
+// HeuristicCost returns an admissible and consistent estimate of the cost
+// of the path between x and y. The heuristic is the ManhattanDistance
+// if AllowDiagonal is false, the ChebyshevDistance if AllowDiagonal and
+// UnitEdgeWeight are true and the OctileDistance otherwise, scaled by the
+// minimum traversal cost of the cells in the grid. HeuristicCost satisfies
+// the path.HeuristicCoster interface.
+func (g *VoxelGrid) HeuristicCost(x, y graph.Node) float64 {
+	var d float64
+	switch {
+	case !g.AllowDiagonal:
+		d = g.ManhattanDistance(x, y)
+	case g.UnitEdgeWeight:
+		d = g.ChebyshevDistance(x, y)
+	default:
+		d = g.OctileDistance(x, y)
+	}
+	return d * minCost(g.cost)
+}
+
+// ManhattanDistance returns the Manhattan distance between x and y. This
+// is the length of the shortest path between x and y on an open 6-connected
+// grid. If either of x or y is not in the grid, NaN is returned.
+func (g *VoxelGrid) ManhattanDistance(x, y graph.Node) float64 {
+	d, ok := g.sortedDelta(x, y)
+	if !ok {
+		return math.NaN()
+	}
+	return float64(d[0] + d[1] + d[2])
+}
+
+// ChebyshevDistance returns the Chebyshev distance between x and y. This
+// is the length of the shortest path between x and y on an open 26-connected
+// grid with unit edge weights. If either of x or y is not in the grid, NaN
+// is returned.
+func (g *VoxelGrid) ChebyshevDistance(x, y graph.Node) float64 {
+	d, ok := g.sortedDelta(x, y)
+	if !ok {
+		return math.NaN()
+	}
+	return float64(d[2])
+}
+
+// OctileDistance returns the 3D octile distance between x and y. This is
+// the length of the shortest path between x and y on an open 26-connected
+// grid with Euclidean edge weights. If either of x or y is not in the grid,
+// NaN is returned.
+func (g *VoxelGrid) OctileDistance(x, y graph.Node) float64 {
+	d, ok := g.sortedDelta(x, y)
+	if !ok {
+		return math.NaN()
+	}
+	// Move diagonally across all three axes as far as
+	// possible, then diagonally across the remaining two
+	// and finally along the remaining axis.
+	return math.Sqrt(3)*float64(d[0]) + math.Sqrt2*float64(d[1]-d[0]) + float64(d[2]-d[1])
+}
+
+// EuclideanDistance returns the straight line distance between x and y.
+// If either of x or y is not in the grid, NaN is returned.
+func (g *VoxelGrid) EuclideanDistance(x, y graph.Node) float64 {
+	d, ok := g.sortedDelta(x, y)
+	if !ok {
+		return math.NaN()
+	}
+	return math.Sqrt(float64(d[0]*d[0] + d[1]*d[1] + d[2]*d[2]))
+}
+
+// sortedDelta returns the absolute coordinate differences between
+// x and y in ascending order.
+func (g *VoxelGrid) sortedDelta(x, y graph.Node) (d [3]int, ok bool) {
+	xid := x.ID()
+	yid := y.ID()
+	if xid < 0 || xid >= len(g.open) || yid < 0 || yid >= len(g.open) {
+		return d, false
+	}
+	d = g.delta(xid, yid)
+	sort.Ints(d[:])
+	return d, true
+}
+
+// String returns a string representation of the grid.
+func (g *VoxelGrid) String() string {
+	b, _ := g.Render(nil)
+	return string(b)
+}
+
+// Render returns a text representation of the graph with the given path
+// included. Layers are separated by blank lines. If the path is not a path
+// in the grid Render returns a non-nil error and the path up to that point.
+func (g *VoxelGrid) Render(path []graph.Node) ([]byte, error) {
+	// Each layer is rendered as for a Grid
+	// with an additional newline separating
+	// it from the next layer.
+	size := g.r*(g.c+1) + 1
+	n := g.l*size - 2
+	if n < 0 {
+		n = 0
+	}
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = '\n'
+	}
+	pos := func(l, r, c int) int { return l*size + r*(g.c+1) + c }
+	for l := 0; l < g.l; l++ {
+		for r := 0; r < g.r; r++ {
+			for c := 0; c < g.c; c++ {
+				if g.open[(l*g.r+r)*g.c+c] {
+					b[pos(l, r, c)] = Open
+				} else {
+					b[pos(l, r, c)] = Closed
+				}
+			}
+		}
+	}
+
+	// We don't use topo.IsPathIn at the outset because we
+	// want to draw as much as possible before failing.
+	for i, n := range path {
+		if !g.Has(n) || (i != 0 && !g.HasEdgeBetween(path[i-1], n)) {
+			id := n.ID()
+			if id >= 0 && id < len(g.open) {
+				b[pos(g.LayerRowCol(id))] = '!'
+			}
+			return b, errors.New("grid: not a path in graph")
+		}
+		switch i {
+		case len(path) - 1:
+			b[pos(g.LayerRowCol(n.ID()))] = 'G'
+		case 0:
+			b[pos(g.LayerRowCol(n.ID()))] = 'S'
+		default:
+			b[pos(g.LayerRowCol(n.ID()))] = 'o'
+		}
+	}
+	return b, nil
+}
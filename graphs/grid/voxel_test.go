@@ -0,0 +1,145 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package grid
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/path"
+)
+
+var _ graph.Graph = (*VoxelGrid)(nil)
+
+func TestVoxelGrid(t *testing.T) {
+	g := NewVoxelGridFrom(
+		[]string{
+			"..*",
+			"...",
+		},
+		[]string{
+			"***",
+			"*.*",
+		},
+		[]string{
+			"...",
+			"...",
+		},
+	)
+	if l, r, c := g.Dims(); l != 3 || r != 2 || c != 3 {
+		t.Fatalf("unexpected dimensions: got:(%d, %d, %d) want:(3, 2, 3)", l, r, c)
+	}
+	want := join(
+		"..*",
+		"...",
+		"",
+		"***",
+		"*.*",
+		"",
+		"...",
+		"...",
+	)
+	if got := g.String(); got != want {
+		t.Fatalf("unexpected voxel grid rendering:\ngot: %q\nwant:%q", got, want)
+	}
+	parsed, err := ParseVoxelGrid([]byte(want + "\n"))
+	if err != nil {
+		t.Fatalf("unexpected error parsing voxel grid: %v", err)
+	}
+	if parsed.String() != want {
+		t.Fatalf("unexpected voxel grid rendering after parsing:\ngot: %q\nwant:%q", parsed.String(), want)
+	}
+
+	s := g.NodeAt(0, 0, 0)
+	d := g.NodeAt(2, 0, 0)
+	pt := path.DijkstraFrom(s, g)
+	if w := pt.WeightTo(d); w != 6 {
+		t.Errorf("unexpected face-connected path weight: got:%v want:6", w)
+	}
+	p := []graph.Node{
+		g.NodeAt(0, 0, 0),
+		g.NodeAt(0, 1, 0),
+		g.NodeAt(0, 1, 1),
+		g.NodeAt(1, 1, 1),
+		g.NodeAt(2, 1, 1),
+		g.NodeAt(2, 1, 0),
+		g.NodeAt(2, 0, 0),
+	}
+	b, err := g.Render(p)
+	if err != nil {
+		t.Fatalf("unexpected error rendering path: %v", err)
+	}
+	want = join(
+		"S.*",
+		"oo.",
+		"",
+		"***",
+		"*o*",
+		"",
+		"G..",
+		"oo.",
+	)
+	if string(b) != want {
+		t.Errorf("unexpected voxel grid path rendering:\ngot: %q\nwant:%q", b, want)
+	}
+	if _, err = g.Render([]graph.Node{g.NodeAt(0, 0, 0), g.NodeAt(1, 0, 0)}); err == nil {
+		t.Error("expected error rendering invalid path")
+	}
+
+	g.AllowDiagonal = true
+	pt = path.DijkstraFrom(s, g)
+	if w := pt.WeightTo(d); math.Abs(w-(math.Sqrt(3)+math.Sqrt(3))) > 1e-12 {
+		t.Errorf("unexpected diagonal path weight: got:%v want:%v", w, 2*math.Sqrt(3))
+	}
+
+	for _, layers := range []string{"..\n..\n\n...\n...", "..\n..\n\n\n..\n.."} {
+		if _, err := ParseVoxelGrid([]byte(layers)); err == nil {
+			t.Errorf("expected error for invalid layers %q", layers)
+		}
+	}
+}
+
+func TestVoxelGridHeuristic(t *testing.T) {
+	for _, test := range []struct {
+		diagonal, unit bool
+	}{
+		{diagonal: false},
+		{diagonal: true, unit: true},
+		{diagonal: true, unit: false},
+	} {
+		g := NewVoxelGrid(3, 3, 4, true)
+		g.AllowDiagonal = test.diagonal
+		g.UnitEdgeWeight = test.unit
+		for _, u := range g.Nodes() {
+			pt := path.DijkstraFrom(u, g)
+			for _, v := range g.Nodes() {
+				h := g.HeuristicCost(u, v)
+				w := pt.WeightTo(v)
+				if math.Abs(h-w) > 1e-12 {
+					t.Errorf("unexpected heuristic on open grid between %d and %d with diagonal=%t unit=%t: h=%v w=%v",
+						u.ID(), v.ID(), test.diagonal, test.unit, h, w)
+				}
+			}
+		}
+
+		g.SetCost(1, 1, 1, 0.25)
+		g.Set(1, 1, 2, false)
+		for _, u := range g.Nodes() {
+			pt := path.DijkstraFrom(u, g)
+			for _, v := range g.Nodes() {
+				h := g.HeuristicCost(u, v)
+				w := pt.WeightTo(v)
+				if h > w+1e-12 {
+					t.Errorf("inadmissible heuristic between %d and %d with diagonal=%t unit=%t: h=%v w=%v",
+						u.ID(), v.ID(), test.diagonal, test.unit, h, w)
+				}
+			}
+		}
+		if h, e := g.HeuristicCost(g.NodeAt(0, 0, 0), g.NodeAt(2, 2, 3)), g.EuclideanDistance(g.NodeAt(0, 0, 0), g.NodeAt(2, 2, 3)); test.diagonal && !test.unit && h > e {
+			t.Errorf("heuristic greater than scaled euclidean distance: h=%v e=%v", h, e)
+		}
+	}
+}
@@ -0,0 +1,379 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package iso
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"sort"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+)
+
+// Canonical is the canonical labelling of a graph.
+type Canonical struct {
+	// Nodes holds the nodes of the graph in
+	// canonical order.
+	Nodes []graph.Node
+
+	// Hash is a hash of the canonically labelled
+	// graph. Isomorphic graphs have equal hashes.
+	Hash [sha256.Size]byte
+
+	// Automorphisms holds generators of the
+	// automorphism group of the graph, each
+	// expressed as a mapping of node IDs.
+	// The identity is not included.
+	Automorphisms []map[int]int
+
+	cert []byte
+}
+
+// Equal returns whether the graphs labelled by c and d are isomorphic,
+// including their node colours. Unlike comparison of hashes, Equal is
+// not subject to collisions.
+func (c Canonical) Equal(d Canonical) bool {
+	return bytes.Equal(c.cert, d.cert)
+}
+
+// CanonicalLabelling returns the canonical labelling of g using a
+// partition refinement search in the style of nauty and bliss. If colour
+// is not nil, it is used to assign an initial colour to each node and
+// only colour-preserving relabellings are considered. Both directed and
+// undirected graphs are accepted; g is treated as directed if it is a
+// graph.Directed. Edge weights are not considered.
+//
+//	McKay and Piperno "Practical graph isomorphism, II"
+//	doi:10.1016/j.jsc.2013.09.003
+func CanonicalLabelling(g graph.Graph, colour func(graph.Node) int) Canonical {
+	_, directed := g.(graph.Directed)
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+	indexOf := make(map[int]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+
+	c := canonizer{
+		directed: directed,
+		colour:   make([]int, len(nodes)),
+		out:      make([][]int, len(nodes)),
+		in:       make([][]int, len(nodes)),
+	}
+	for i, u := range nodes {
+		if colour != nil {
+			c.colour[i] = colour(u)
+		}
+		for _, v := range g.From(u) {
+			j := indexOf[v.ID()]
+			c.out[i] = append(c.out[i], j)
+			if directed {
+				c.in[j] = append(c.in[j], i)
+			}
+		}
+	}
+
+	// The initial partition orders nodes by
+	// the rank of their colour.
+	col := make([]int, len(nodes))
+	copy(col, c.colour)
+	c.search(c.refine(col), nil)
+
+	canon := Canonical{
+		Nodes: make([]graph.Node, len(nodes)),
+		Hash:  sha256.Sum256(c.bestCert),
+		cert:  c.bestCert,
+	}
+	for i, l := range c.bestLabel {
+		canon.Nodes[l] = nodes[i]
+	}
+	for _, gen := range c.generators {
+		m := make(map[int]int, len(gen))
+		for i, j := range gen {
+			m[nodes[i].ID()] = nodes[j].ID()
+		}
+		canon.Automorphisms = append(canon.Automorphisms, m)
+	}
+	return canon
+}
+
+// canonizer performs the canonical labelling search over an
+// index-based representation of a graph.
+type canonizer struct {
+	directed bool
+	colour   []int
+	out, in  [][]int
+
+	// firstPath and firstLabel are the individualised
+	// nodes and labelling of the first leaf of the search
+	// tree, and bestPath, bestLabel and bestCert are the
+	// corresponding values for the leaf with the least
+	// certificate.
+	firstPath  []int
+	firstLabel []int
+	firstCert  []byte
+	bestPath   []int
+	bestLabel  []int
+	bestCert   []byte
+
+	// generators holds the automorphisms found during
+	// the search as permutations of node indices.
+	generators [][]int
+}
+
+// search explores the search tree below the node with the equitable colouring
+// col reached by individualising the nodes in path. It returns the depth of the
+// search tree node at which the search should resume.
+func (c *canonizer) search(col []int, path []int) int {
+	depth := len(path)
+	cell := targetCell(col)
+	if cell == nil {
+		return c.leaf(col, path)
+	}
+
+	var explored []int
+	for _, v := range cell {
+		if c.equivalent(v, explored, path) {
+			continue
+		}
+		explored = append(explored, v)
+		child := make([]int, len(col))
+		for i, k := range col {
+			child[i] = 2*k + 1
+		}
+		child[v] = 2 * col[v]
+		r := c.search(c.refine(child), append(path[:depth:depth], v))
+		if r < depth {
+			return r
+		}
+	}
+	return depth
+}
+
+// leaf handles the discrete colouring col reached by individualising the nodes
+// in path and returns the depth of the search tree node at which the search
+// should resume.
+func (c *canonizer) leaf(col []int, path []int) int {
+	cert := c.certificate(col)
+	path = append([]int(nil), path...)
+	label := append([]int(nil), col...)
+	if c.firstLabel == nil {
+		c.firstPath, c.firstLabel, c.firstCert = path, label, cert
+		c.bestPath, c.bestLabel, c.bestCert = path, label, cert
+		return len(path)
+	}
+
+	// A leaf with the same certificate as an earlier leaf gives
+	// an automorphism mapping the current subtree of the search
+	// onto the subtree containing the earlier leaf. The search
+	// can then resume at the node where their paths diverge.
+	if bytes.Equal(cert, c.firstCert) {
+		c.addGenerator(c.firstLabel, label)
+		return divergence(path, c.firstPath)
+	}
+	switch cmp := bytes.Compare(cert, c.bestCert); {
+	case cmp < 0:
+		c.bestPath, c.bestLabel, c.bestCert = path, label, cert
+	case cmp == 0:
+		c.addGenerator(c.bestLabel, label)
+		return divergence(path, c.bestPath)
+	}
+	return len(path)
+}
+
+// addGenerator adds the automorphism mapping each node to the node of
+// the labelling to with the same label in the labelling from.
+func (c *canonizer) addGenerator(to, from []int) {
+	inv := make([]int, len(to))
+	for i, l := range to {
+		inv[l] = i
+	}
+	gen := make([]int, len(from))
+	for i, l := range from {
+		gen[i] = inv[l]
+	}
+	c.generators = append(c.generators, gen)
+}
+
+// equivalent returns whether v is in the same orbit as any of the nodes in
+// explored under the group generated by the automorphisms found so far that
+// fix each node in path.
+func (c *canonizer) equivalent(v int, explored, path []int) bool {
+	if len(explored) == 0 || len(c.generators) == 0 {
+		return false
+	}
+	n := len(c.colour)
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+outer:
+	for _, gen := range c.generators {
+		for _, p := range path {
+			if gen[p] != p {
+				continue outer
+			}
+		}
+		for i, j := range gen {
+			parent[find(i)] = find(j)
+		}
+	}
+	root := find(v)
+	for _, u := range explored {
+		if find(u) == root {
+			return true
+		}
+	}
+	return false
+}
+
+// refine returns the coarsest equitable colouring finer than col, with
+// colours numbered from zero in an order that depends only on the
+// structure of the graph and the order of the colours in col.
+func (c *canonizer) refine(col []int) []int {
+	n := len(col)
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	cells := -1
+	sigs := make([][]int, n)
+	for {
+		for i := range sigs {
+			sig := append(sigs[i][:0], col[i])
+			sig = appendSorted(sig, col, c.out[i])
+			if c.directed {
+				sig = append(sig, -1)
+				sig = appendSorted(sig, col, c.in[i])
+			}
+			sigs[i] = sig
+		}
+		sort.Sort(bySignature{idx: idx, sigs: sigs})
+		next := make([]int, n)
+		k := 0
+		for i, v := range idx {
+			if i != 0 && lessInts(sigs[idx[i-1]], sigs[v]) {
+				k++
+			}
+			next[v] = k
+		}
+		col = next
+		if k+1 == cells {
+			return col
+		}
+		cells = k + 1
+	}
+}
+
+// certificate returns an encoding of the graph labelled by the discrete
+// colouring col.
+func (c *canonizer) certificate(col []int) []byte {
+	n := len(col)
+	inv := make([]int, n)
+	for i, l := range col {
+		inv[l] = i
+	}
+	var buf [binary.MaxVarintLen64]byte
+	cert := make([]byte, 0, n*4)
+	put := func(v int) {
+		k := binary.PutVarint(buf[:], int64(v))
+		cert = append(cert, buf[:k]...)
+	}
+	if c.directed {
+		put(1)
+	} else {
+		put(0)
+	}
+	put(n)
+	for _, i := range inv {
+		put(c.colour[i])
+	}
+	var labels []int
+	for _, i := range inv {
+		labels = labels[:0]
+		for _, j := range c.out[i] {
+			labels = append(labels, col[j])
+		}
+		sort.Ints(labels)
+		put(len(labels))
+		for _, l := range labels {
+			put(l)
+		}
+	}
+	return cert
+}
+
+// targetCell returns the members of the first non-singleton cell of col,
+// or nil if col is discrete.
+func targetCell(col []int) []int {
+	size := make([]int, len(col))
+	for _, k := range col {
+		size[k]++
+	}
+	for k, s := range size {
+		if s > 1 {
+			var cell []int
+			for i, ki := range col {
+				if ki == k {
+					cell = append(cell, i)
+				}
+			}
+			return cell
+		}
+	}
+	return nil
+}
+
+// divergence returns the length of the common prefix of a and b.
+func divergence(a, b []int) int {
+	for i := range a {
+		if i == len(b) || a[i] != b[i] {
+			return i
+		}
+	}
+	return len(a)
+}
+
+// appendSorted appends the sorted colours of the nodes in nbrs to dst.
+func appendSorted(dst, col, nbrs []int) []int {
+	n := len(dst)
+	for _, j := range nbrs {
+		dst = append(dst, col[j])
+	}
+	sort.Ints(dst[n:])
+	return dst
+}
+
+// bySignature sorts node indices by their refinement signatures.
+type bySignature struct {
+	idx  []int
+	sigs [][]int
+}
+
+func (s bySignature) Len() int           { return len(s.idx) }
+func (s bySignature) Less(i, j int) bool { return lessInts(s.sigs[s.idx[i]], s.sigs[s.idx[j]]) }
+func (s bySignature) Swap(i, j int)      { s.idx[i], s.idx[j] = s.idx[j], s.idx[i] }
+
+// lessInts returns whether a is lexicographically less than b.
+func lessInts(a, b []int) bool {
+	for i, v := range a {
+		if i == len(b) {
+			return false
+		}
+		if v != b[i] {
+			return v < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
@@ -0,0 +1,270 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package iso
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+var (
+	// k33 is the complete bipartite graph K₃,₃.
+	k33 = []intset{
+		0: linksTo(3, 4, 5),
+		1: linksTo(3, 4, 5),
+		2: linksTo(3, 4, 5),
+		3: nil,
+		4: nil,
+		5: nil,
+	}
+
+	// prism is the triangular prism graph.
+	prism = []intset{
+		0: linksTo(1, 2, 3),
+		1: linksTo(2, 4),
+		2: linksTo(5),
+		3: linksTo(4, 5),
+		4: linksTo(5),
+		5: nil,
+	}
+)
+
+var canonicalTests = []struct {
+	name     string
+	g        []intset
+	directed bool
+}{
+	{name: "empty", g: nil},
+	{name: "P3", g: path3},
+	{name: "P5", g: path5},
+	{name: "C5", g: cycle5},
+	{name: "K4", g: k4},
+	{name: "star", g: star5},
+	{name: "Petersen", g: petersen},
+	{name: "K3,3", g: k33},
+	{name: "prism", g: prism},
+	{name: "directed C5", g: cycle5, directed: true},
+	{name: "directed P5", g: path5, directed: true},
+	{name: "transitive tournament", g: transitiveTriangle, directed: true},
+	{name: "directed Petersen", g: petersen, directed: true},
+}
+
+func TestCanonicalLabelling(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range canonicalTests {
+		g := newGraph(test.g, test.directed)
+		want := CanonicalLabelling(g, nil)
+		if len(want.Nodes) != len(test.g) {
+			t.Errorf("unexpected number of canonical nodes for %q: got:%d want:%d", test.name, len(want.Nodes), len(test.g))
+		}
+		checkAutomorphisms(t, test.name, g, want.Automorphisms)
+
+		// The generators must generate the
+		// complete automorphism group.
+		var order int
+		m := NewMatcher(g, g, Isomorphism, nil, nil)
+		for m.Next() {
+			order++
+		}
+		if got := groupOrder(len(test.g), want.Automorphisms); got != order {
+			t.Errorf("unexpected automorphism group order for %q: got:%d want:%d", test.name, got, order)
+		}
+
+		for i := 0; i < 5; i++ {
+			h := newGraph(permute(test.g, rnd.Perm(len(test.g))), test.directed)
+			got := CanonicalLabelling(h, nil)
+			if got.Hash != want.Hash {
+				t.Errorf("unexpected hash for relabelled %q", test.name)
+			}
+			if !got.Equal(want) {
+				t.Errorf("relabelled %q not equal", test.name)
+			}
+			if !sameCanonicalEdges(g, want.Nodes, h, got.Nodes) {
+				t.Errorf("canonical orders for relabelled %q do not give the same graph", test.name)
+			}
+		}
+	}
+}
+
+func TestCanonicalLabellingDistinct(t *testing.T) {
+	seen := make(map[[32]byte]string)
+	for _, test := range canonicalTests {
+		if test.name == "empty" {
+			continue
+		}
+		c := CanonicalLabelling(newGraph(test.g, test.directed), nil)
+		if other, ok := seen[c.Hash]; ok {
+			t.Errorf("hash collision between non-isomorphic %q and %q", test.name, other)
+		}
+		seen[c.Hash] = test.name
+	}
+}
+
+func TestCanonicalLabellingRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		const n = 10
+		adj := make([]intset, n)
+		for u := 0; u < n; u++ {
+			for v := u + 1; v < n; v++ {
+				if rnd.Float64() < 0.4 {
+					if adj[u] == nil {
+						adj[u] = make(intset)
+					}
+					adj[u][v] = struct{}{}
+				}
+			}
+		}
+		for _, dir := range []bool{false, true} {
+			name := fmt.Sprintf("random %d directed=%t", i, dir)
+			g := newGraph(adj, dir)
+			want := CanonicalLabelling(g, nil)
+			checkAutomorphisms(t, name, g, want.Automorphisms)
+
+			h := newGraph(permute(adj, rnd.Perm(n)), dir)
+			got := CanonicalLabelling(h, nil)
+			if !got.Equal(want) || got.Hash != want.Hash {
+				t.Errorf("relabelled %s not equal", name)
+			}
+			if !sameCanonicalEdges(g, want.Nodes, h, got.Nodes) {
+				t.Errorf("canonical orders for relabelled %s do not give the same graph", name)
+			}
+		}
+	}
+}
+
+func TestCanonicalLabellingColour(t *testing.T) {
+	g := newGraph(path3, false)
+	c := CanonicalLabelling(g, nil)
+	if len(c.Automorphisms) != 1 {
+		t.Errorf("unexpected number of automorphism generators for uncoloured P3: got:%d want:1", len(c.Automorphisms))
+	}
+
+	// Distinguishing the ends of the path
+	// removes the reflection.
+	end := func(n graph.Node) int {
+		if n.ID() == 0 {
+			return 1
+		}
+		return 0
+	}
+	cEnd := CanonicalLabelling(g, end)
+	if len(cEnd.Automorphisms) != 0 {
+		t.Errorf("unexpected automorphisms for coloured P3: %v", cEnd.Automorphisms)
+	}
+	if cEnd.Equal(c) {
+		t.Error("coloured and uncoloured P3 unexpectedly equal")
+	}
+
+	// Colouring the other end gives an isomorphic
+	// coloured graph, but colouring the middle does not.
+	other := CanonicalLabelling(g, func(n graph.Node) int {
+		if n.ID() == 2 {
+			return 1
+		}
+		return 0
+	})
+	if !other.Equal(cEnd) {
+		t.Error("P3 coloured at opposite ends not equal")
+	}
+	middle := CanonicalLabelling(g, func(n graph.Node) int {
+		if n.ID() == 1 {
+			return 1
+		}
+		return 0
+	})
+	if middle.Equal(cEnd) {
+		t.Error("P3 coloured at middle and end unexpectedly equal")
+	}
+}
+
+func newGraph(adj []intset, isDirected bool) builder {
+	if isDirected {
+		return directed(adj)
+	}
+	return undirected(adj)
+}
+
+// permute returns adj with node u relabelled as perm[u].
+func permute(adj []intset, perm []int) []intset {
+	p := make([]intset, len(adj))
+	for u := range adj {
+		p[perm[u]] = make(intset)
+	}
+	for u, e := range adj {
+		for v := range e {
+			p[perm[u]][perm[v]] = struct{}{}
+		}
+	}
+	return p
+}
+
+// sameCanonicalEdges returns whether g1 and g2 have the same edges when
+// their nodes are labelled by their positions in order1 and order2.
+func sameCanonicalEdges(g1 graph.Graph, order1 []graph.Node, g2 graph.Graph, order2 []graph.Node) bool {
+	if len(order1) != len(order2) {
+		return false
+	}
+	for i := range order1 {
+		for j := range order1 {
+			if hasEdge(g1, order1[i], order1[j]) != hasEdge(g2, order2[i], order2[j]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func hasEdge(g graph.Graph, u, v graph.Node) bool {
+	if d, ok := g.(graph.Directed); ok {
+		return d.HasEdgeFromTo(u, v)
+	}
+	return g.HasEdgeBetween(u, v)
+}
+
+// checkAutomorphisms checks that each mapping in gens is an automorphism of g.
+func checkAutomorphisms(t *testing.T, name string, g graph.Graph, gens []map[int]int) {
+	nodes := g.Nodes()
+	for _, gen := range gens {
+		for _, u := range nodes {
+			for _, v := range nodes {
+				if hasEdge(g, u, v) != hasEdge(g, simple.Node(gen[u.ID()]), simple.Node(gen[v.ID()])) {
+					t.Errorf("generator for %q is not an automorphism: %v", name, gen)
+					return
+				}
+			}
+		}
+	}
+}
+
+// groupOrder returns the order of the permutation group on the nodes
+// 0 to n-1 generated by gens.
+func groupOrder(n int, gens []map[int]int) int {
+	id := make([]byte, n)
+	for i := range id {
+		id[i] = byte(i)
+	}
+	seen := map[string]bool{string(id): true}
+	queue := [][]byte{id}
+	for len(queue) != 0 {
+		p := queue[0]
+		queue = queue[1:]
+		for _, gen := range gens {
+			q := make([]byte, n)
+			for i, v := range p {
+				q[i] = byte(gen[int(v)])
+			}
+			if !seen[string(q)] {
+				seen[string(q)] = true
+				queue = append(queue, q)
+			}
+		}
+	}
+	return len(seen)
+}
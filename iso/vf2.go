@@ -0,0 +1,503 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package iso
+
+import (
+	"sort"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+)
+
+// Kind specifies the kind of node mapping found by a Matcher.
+type Kind int
+
+const (
+	// Isomorphism mappings are bijections between the nodes
+	// of two graphs that preserve adjacency and non-adjacency.
+	Isomorphism Kind = iota
+
+	// InducedSubgraph mappings are injections from the nodes
+	// of a pattern graph into the nodes of a target graph that
+	// preserve adjacency and non-adjacency, so the pattern is
+	// isomorphic to the subgraph of the target induced by the
+	// mapped nodes.
+	InducedSubgraph
+
+	// Monomorphism mappings are injections from the nodes of
+	// a pattern graph into the nodes of a target graph that
+	// preserve adjacency, so the pattern is isomorphic to a
+	// not necessarily induced subgraph of the target.
+	Monomorphism
+)
+
+// NodeMatcher returns whether the node u of the first graph may be mapped
+// to the node v of the second graph.
+type NodeMatcher func(u, v graph.Node) bool
+
+// EdgeMatcher returns whether the edge e of the first graph may be mapped
+// to the edge f of the second graph.
+type EdgeMatcher func(e, f graph.Edge) bool
+
+// Isomorphic returns whether g1 and g2 are isomorphic. Both graphs must be
+// directed or both must be undirected.
+func Isomorphic(g1, g2 graph.Graph) bool {
+	return NewMatcher(g1, g2, Isomorphism, nil, nil).Next()
+}
+
+// Matcher is an iterator over the node mappings between two graphs found
+// by the VF2 algorithm with the node ordering and pruning rules of VF2++.
+//
+//	Cordella, Foggia, Sansone and Vento "A (sub)graph isomorphism algorithm for
+//	matching large graphs" doi:10.1109/TPAMI.2004.75
+//
+//	Jüttner and Madarasi "VF2++ — An improved subgraph isomorphism algorithm"
+//	doi:10.1016/j.dam.2018.02.018
+type Matcher struct {
+	kind      Kind
+	nodeMatch NodeMatcher
+	edgeMatch EdgeMatcher
+
+	g1, g2 *matchGraph
+
+	// order holds the indices of the nodes of
+	// g1 in the order they are matched. parent
+	// holds for each position in order the index
+	// of a previously matched neighbour or -1,
+	// and fromParent holds whether the edge
+	// joining them is from the parent.
+	order      []int
+	parent     []int
+	fromParent []bool
+
+	// core1 and core2 hold the current partial
+	// mapping between nodes of g1 and g2 and the
+	// inverse mapping, with -1 for unmapped nodes.
+	core1, core2 []int
+
+	// cands and pos hold the candidate nodes of
+	// g2 for each depth of the search and the
+	// position of the next candidate to try.
+	cands [][]int
+	pos   []int
+	depth int
+
+	started, done bool
+}
+
+// NewMatcher returns a Matcher that iterates over the mappings of the given kind
+// from the nodes of g1 to the nodes of g2. For subgraph kinds, g1 is the pattern
+// and g2 is the target. Both graphs must be directed or both must be undirected,
+// NewMatcher will panic otherwise.
+//
+// If nodeMatch is not nil, only mappings where nodeMatch returns true for each
+// mapped pair of nodes are found. If edgeMatch is not nil, only mappings where
+// edgeMatch returns true for each mapped pair of edges are found.
+func NewMatcher(g1, g2 graph.Graph, kind Kind, nodeMatch NodeMatcher, edgeMatch EdgeMatcher) *Matcher {
+	_, d1 := g1.(graph.Directed)
+	_, d2 := g2.(graph.Directed)
+	if d1 != d2 {
+		panic("iso: mixed directed and undirected graphs")
+	}
+	m := &Matcher{
+		kind:      kind,
+		nodeMatch: nodeMatch,
+		edgeMatch: edgeMatch,
+
+		g1: newMatchGraph(g1, d1),
+		g2: newMatchGraph(g2, d2),
+	}
+	n1 := len(m.g1.nodes)
+	n2 := len(m.g2.nodes)
+	switch {
+	case kind == Isomorphism && (n1 != n2 || len(m.g1.edges) != len(m.g2.edges)):
+		m.done = true
+	case n1 > n2:
+		m.done = true
+	}
+
+	m.core1 = make([]int, n1)
+	for i := range m.core1 {
+		m.core1[i] = -1
+	}
+	m.core2 = make([]int, n2)
+	for i := range m.core2 {
+		m.core2[i] = -1
+	}
+	m.cands = make([][]int, n1)
+	m.pos = make([]int, n1)
+	if !m.done {
+		m.orderNodes()
+	}
+
+	return m
+}
+
+// Next advances the Matcher to the next mapping and returns whether
+// a mapping was found.
+func (m *Matcher) Next() bool {
+	if m.done {
+		return false
+	}
+	n1 := len(m.order)
+	if !m.started {
+		m.started = true
+		if n1 == 0 {
+			// The empty graph has exactly
+			// one mapping into any graph.
+			return true
+		}
+		m.depth = 0
+		m.cands[0] = m.candidates(0)
+		m.pos[0] = 0
+	} else {
+		if n1 == 0 {
+			m.done = true
+			return false
+		}
+		// Undo the last match of the
+		// previously returned mapping.
+		m.depth = n1 - 1
+		m.unmatch(m.depth)
+	}
+
+	for m.depth >= 0 {
+		d := m.depth
+		if m.pos[d] == len(m.cands[d]) {
+			m.depth--
+			if m.depth >= 0 {
+				m.unmatch(m.depth)
+			}
+			continue
+		}
+		u := m.order[d]
+		v := m.cands[d][m.pos[d]]
+		m.pos[d]++
+		if !m.feasible(u, v) {
+			continue
+		}
+		m.core1[u] = v
+		m.core2[v] = u
+		if d+1 == n1 {
+			return true
+		}
+		m.depth++
+		m.cands[m.depth] = m.candidates(m.depth)
+		m.pos[m.depth] = 0
+	}
+	m.done = true
+	return false
+}
+
+// Mapping returns the current mapping from node IDs of the first graph to
+// node IDs of the second graph. Mapping returns nil if Next has not been
+// called or the last call to Next returned false.
+func (m *Matcher) Mapping() map[int]int {
+	if !m.started || m.done {
+		return nil
+	}
+	mapping := make(map[int]int, len(m.core1))
+	for u, v := range m.core1 {
+		mapping[m.g1.nodes[u].ID()] = m.g2.nodes[v].ID()
+	}
+	return mapping
+}
+
+// unmatch removes the match of the node at depth d of the search.
+func (m *Matcher) unmatch(d int) {
+	u := m.order[d]
+	m.core2[m.core1[u]] = -1
+	m.core1[u] = -1
+}
+
+// candidates returns the candidate nodes of g2 for
+// matching with the node at depth d of the search.
+func (m *Matcher) candidates(d int) []int {
+	var cands []int
+	p := m.parent[d]
+	if p < 0 {
+		for v, u := range m.core2 {
+			if u < 0 {
+				cands = append(cands, v)
+			}
+		}
+		return cands
+	}
+
+	// Only neighbours of the match of a matched
+	// neighbour can be matched with the node.
+	from := m.g2.in[m.core1[p]]
+	if m.fromParent[d] {
+		from = m.g2.out[m.core1[p]]
+	}
+	for _, v := range from {
+		if m.core2[v] < 0 {
+			cands = append(cands, v)
+		}
+	}
+	return cands
+}
+
+// feasible returns whether the node u of g1 can be matched with
+// the node v of g2 given the current partial mapping.
+func (m *Matcher) feasible(u, v int) bool {
+	g1, g2 := m.g1, m.g2
+	induced := m.kind != Monomorphism
+
+	if !m.countOK(len(g1.out[u]), len(g2.out[v])) {
+		return false
+	}
+	if g1.directed && !m.countOK(len(g1.in[u]), len(g2.in[v])) {
+		return false
+	}
+	if m.nodeMatch != nil && !m.nodeMatch(g1.nodes[u], g2.nodes[v]) {
+		return false
+	}
+	if g1.loop[u] != g2.loop[v] && (g1.loop[u] || induced) {
+		return false
+	}
+	if g1.loop[u] && !m.edgesMatch(u, u, v, v) {
+		return false
+	}
+
+	// Check that matched neighbours of u are matched
+	// with neighbours of v and count the unmatched
+	// neighbours for the look-ahead rule.
+	var free1Out, free1In, free2Out, free2In int
+	for _, n := range g1.out[u] {
+		w := m.core1[n]
+		if w < 0 {
+			free1Out++
+			continue
+		}
+		if !g2.edges[[2]int{v, w}] || !m.edgesMatch(u, n, v, w) {
+			return false
+		}
+	}
+	for _, n := range g1.in[u] {
+		w := m.core1[n]
+		if w < 0 {
+			free1In++
+			continue
+		}
+		if !g2.edges[[2]int{w, v}] || !m.edgesMatch(n, u, w, v) {
+			return false
+		}
+	}
+
+	// Check that matched neighbours of v are matched
+	// with neighbours of u if non-adjacency must be
+	// preserved.
+	for _, n := range g2.out[v] {
+		w := m.core2[n]
+		if w < 0 {
+			free2Out++
+			continue
+		}
+		if induced && !g1.edges[[2]int{u, w}] {
+			return false
+		}
+	}
+	for _, n := range g2.in[v] {
+		w := m.core2[n]
+		if w < 0 {
+			free2In++
+			continue
+		}
+		if induced && !g1.edges[[2]int{w, u}] {
+			return false
+		}
+	}
+
+	return m.countOK(free1Out, free2Out) && m.countOK(free1In, free2In)
+}
+
+// countOK returns whether a node of g1 with n1 neighbours of some class
+// can be matched with a node of g2 with n2 neighbours of the same class.
+func (m *Matcher) countOK(n1, n2 int) bool {
+	if m.kind == Isomorphism {
+		return n1 == n2
+	}
+	return n1 <= n2
+}
+
+// edgesMatch returns whether the edge (u1, v1) in g1 can be matched
+// with the edge (u2, v2) in g2.
+func (m *Matcher) edgesMatch(u1, v1, u2, v2 int) bool {
+	if m.edgeMatch == nil {
+		return true
+	}
+	return m.edgeMatch(
+		m.g1.g.Edge(m.g1.nodes[u1], m.g1.nodes[v1]),
+		m.g2.g.Edge(m.g2.nodes[u2], m.g2.nodes[v2]),
+	)
+}
+
+// orderNodes determines the matching order of the nodes of g1. Following
+// VF2++, nodes are ordered by breadth first search from the rarest node with
+// the highest degree, and within each level nodes with the most connections
+// to already ordered nodes are placed first, then the rarest and then those
+// with the highest degree. Node rarity is the number of nodes of g2 that the
+// node may be matched with.
+func (m *Matcher) orderNodes() {
+	g1 := m.g1
+	n1 := len(g1.nodes)
+	rarity := make([]int, n1)
+	if m.nodeMatch != nil {
+		for u, un := range g1.nodes {
+			for _, vn := range m.g2.nodes {
+				if m.nodeMatch(un, vn) {
+					rarity[u]++
+				}
+			}
+			if rarity[u] == 0 {
+				m.done = true
+				return
+			}
+		}
+	}
+	degree := func(u int) int { return len(g1.out[u]) + len(g1.in[u]) }
+
+	m.order = make([]int, 0, n1)
+	m.parent = make([]int, 0, n1)
+	m.fromParent = make([]bool, 0, n1)
+	conn := make([]int, n1)
+	seen := make([]bool, n1)
+	ordered := make([]bool, n1)
+	for len(m.order) < n1 {
+		root := -1
+		for u := range g1.nodes {
+			if seen[u] {
+				continue
+			}
+			if root < 0 || rarity[u] < rarity[root] || (rarity[u] == rarity[root] && degree(u) > degree(root)) {
+				root = u
+			}
+		}
+		seen[root] = true
+		level := []int{root}
+		for len(level) != 0 {
+			var next []int
+			for len(level) != 0 {
+				best := 0
+				for i, u := range level {
+					b := level[best]
+					switch {
+					case conn[u] != conn[b]:
+						if conn[u] > conn[b] {
+							best = i
+						}
+					case rarity[u] != rarity[b]:
+						if rarity[u] < rarity[b] {
+							best = i
+						}
+					case degree(u) > degree(b):
+						best = i
+					}
+				}
+				u := level[best]
+				level[best] = level[len(level)-1]
+				level = level[:len(level)-1]
+
+				parent, fromParent := -1, false
+				for _, n := range g1.in[u] {
+					if ordered[n] {
+						parent, fromParent = n, true
+						break
+					}
+				}
+				if parent < 0 {
+					for _, n := range g1.out[u] {
+						if ordered[n] {
+							// For undirected graphs out and in
+							// are the same so the parent edge
+							// is considered to be from the parent.
+							parent, fromParent = n, !g1.directed
+							break
+						}
+					}
+				}
+				m.order = append(m.order, u)
+				m.parent = append(m.parent, parent)
+				m.fromParent = append(m.fromParent, fromParent)
+				ordered[u] = true
+
+				for _, nbrs := range [2][]int{g1.out[u], g1.in[u]} {
+					for _, n := range nbrs {
+						conn[n]++
+						if !seen[n] {
+							seen[n] = true
+							next = append(next, n)
+						}
+					}
+				}
+			}
+			level = next
+		}
+	}
+}
+
+// matchGraph is an index-based representation of a graph used for matching.
+type matchGraph struct {
+	g        graph.Graph
+	directed bool
+
+	nodes   []graph.Node
+	indexOf map[int]int
+
+	// out and in hold the neighbours of
+	// each node excluding the node itself.
+	// For undirected graphs in is empty.
+	out, in [][]int
+	// edges holds the set of edges between
+	// distinct nodes. Undirected edges are
+	// held in both directions.
+	edges map[[2]int]bool
+	// loop holds whether each node has
+	// an edge to itself.
+	loop []bool
+}
+
+func newMatchGraph(g graph.Graph, directed bool) *matchGraph {
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+	indexOf := make(map[int]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+	mg := &matchGraph{
+		g:        g,
+		directed: directed,
+
+		nodes:   nodes,
+		indexOf: indexOf,
+
+		out:   make([][]int, len(nodes)),
+		edges: make(map[[2]int]bool),
+		loop:  make([]bool, len(nodes)),
+	}
+	// For undirected graphs in holds only empty
+	// neighbour lists so ranging over it is a no-op.
+	mg.in = make([][]int, len(nodes))
+	for i, u := range nodes {
+		for _, v := range g.From(u) {
+			j := indexOf[v.ID()]
+			if i == j {
+				mg.loop[i] = true
+				continue
+			}
+			mg.out[i] = append(mg.out[i], j)
+			mg.edges[[2]int{i, j}] = true
+			if directed {
+				mg.in[j] = append(mg.in[j], i)
+			}
+		}
+	}
+	for i := range nodes {
+		sort.Ints(mg.out[i])
+		sort.Ints(mg.in[i])
+	}
+	return mg
+}
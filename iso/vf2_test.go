@@ -0,0 +1,408 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package iso
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+type intset map[int]struct{}
+
+func linksTo(i ...int) intset {
+	if len(i) == 0 {
+		return nil
+	}
+	s := make(intset)
+	for _, v := range i {
+		s[v] = struct{}{}
+	}
+	return s
+}
+
+type builder interface {
+	graph.Graph
+	graph.Builder
+	graph.EdgeRemover
+}
+
+func buildGraph(g builder, adj []intset) builder {
+	for u, e := range adj {
+		if !g.Has(simple.Node(u)) {
+			g.AddNode(simple.Node(u))
+		}
+		for v := range e {
+			if !g.Has(simple.Node(v)) {
+				g.AddNode(simple.Node(v))
+			}
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v), W: 1})
+		}
+	}
+	return g
+}
+
+func undirected(adj []intset) builder {
+	return buildGraph(simple.NewUndirectedGraph(0, math.Inf(1)), adj)
+}
+
+func directed(adj []intset) builder {
+	return buildGraph(simple.NewDirectedGraph(0, math.Inf(1)), adj)
+}
+
+var (
+	// triangle is the complete graph K₃.
+	triangle = []intset{
+		0: linksTo(1, 2),
+		1: linksTo(2),
+		2: nil,
+	}
+
+	// path3 is the path graph P₃.
+	path3 = []intset{
+		0: linksTo(1),
+		1: linksTo(2),
+		2: nil,
+	}
+
+	// k4 is the complete graph K₄.
+	k4 = []intset{
+		0: linksTo(1, 2, 3),
+		1: linksTo(2, 3),
+		2: linksTo(3),
+		3: nil,
+	}
+
+	// cycle5 is the cycle graph C₅.
+	cycle5 = []intset{
+		0: linksTo(1),
+		1: linksTo(2),
+		2: linksTo(3),
+		3: linksTo(4),
+		4: linksTo(0),
+	}
+
+	// cycle5Relabelled is C₅ with relabelled nodes.
+	cycle5Relabelled = []intset{
+		0: linksTo(2),
+		1: linksTo(3, 4),
+		2: linksTo(4),
+		3: linksTo(0),
+		4: nil,
+	}
+
+	// path5 is the path graph P₅.
+	path5 = []intset{
+		0: linksTo(1),
+		1: linksTo(2),
+		2: linksTo(3),
+		3: linksTo(4),
+		4: nil,
+	}
+
+	// star5 is the star graph with four leaves.
+	star5 = []intset{
+		0: linksTo(1, 2, 3, 4),
+		1: nil,
+		2: nil,
+		3: nil,
+		4: nil,
+	}
+
+	// petersen is the Petersen graph.
+	petersen = []intset{
+		0: linksTo(1, 4, 5),
+		1: linksTo(2, 6),
+		2: linksTo(3, 7),
+		3: linksTo(4, 8),
+		4: linksTo(9),
+		5: linksTo(7, 8),
+		6: linksTo(8, 9),
+		7: linksTo(9),
+		8: nil,
+		9: nil,
+	}
+
+	// transitiveTriangle is the transitive tournament on three nodes.
+	transitiveTriangle = []intset{
+		0: linksTo(1, 2),
+		1: linksTo(2),
+		2: nil,
+	}
+)
+
+var isomorphicTests = []struct {
+	name     string
+	g1, g2   []intset
+	directed bool
+	want     bool
+}{
+	{name: "C5 relabelled", g1: cycle5, g2: cycle5Relabelled, want: true},
+	{name: "C5 P5", g1: cycle5, g2: path5, want: false},
+	{name: "P5 star", g1: path5, g2: star5, want: false},
+	{name: "K3 P3", g1: triangle, g2: path3, want: false},
+	{name: "directed C5 relabelled", g1: cycle5, g2: cycle5Relabelled, directed: true, want: false},
+	{name: "directed C5", g1: cycle5, g2: cycle5, directed: true, want: true},
+	{
+		name: "directed reversed path",
+		g1:   path3,
+		g2: []intset{
+			0: nil,
+			1: linksTo(0),
+			2: linksTo(1),
+		},
+		directed: true,
+		want:     true,
+	},
+	{
+		name:     "directed cycle transitive",
+		g1:       []intset{0: linksTo(1), 1: linksTo(2), 2: linksTo(0)},
+		g2:       transitiveTriangle,
+		directed: true,
+		want:     false,
+	},
+	{name: "empty", g1: nil, g2: nil, want: true},
+}
+
+func TestIsomorphic(t *testing.T) {
+	for _, test := range isomorphicTests {
+		var g1, g2 graph.Graph
+		if test.directed {
+			g1, g2 = directed(test.g1), directed(test.g2)
+		} else {
+			g1, g2 = undirected(test.g1), undirected(test.g2)
+		}
+		got := Isomorphic(g1, g2)
+		if got != test.want {
+			t.Errorf("unexpected result for %q: got:%t want:%t", test.name, got, test.want)
+		}
+	}
+}
+
+var matcherCountTests = []struct {
+	name     string
+	g1, g2   []intset
+	directed bool
+	kind     Kind
+	want     int
+}{
+	// Automorphism group orders.
+	{name: "C5 automorphisms", g1: cycle5, g2: cycle5, kind: Isomorphism, want: 10},
+	{name: "directed C5 automorphisms", g1: cycle5, g2: cycle5, directed: true, kind: Isomorphism, want: 5},
+	{name: "K4 automorphisms", g1: k4, g2: k4, kind: Isomorphism, want: 24},
+	{name: "star automorphisms", g1: star5, g2: star5, kind: Isomorphism, want: 24},
+	{name: "Petersen automorphisms", g1: petersen, g2: petersen, kind: Isomorphism, want: 120},
+	{name: "transitive tournament automorphisms", g1: transitiveTriangle, g2: transitiveTriangle, directed: true, kind: Isomorphism, want: 1},
+
+	// Subgraph matches.
+	{name: "K3 in K4 induced", g1: triangle, g2: k4, kind: InducedSubgraph, want: 24},
+	{name: "K3 in K4 mono", g1: triangle, g2: k4, kind: Monomorphism, want: 24},
+	{name: "P3 in K4 induced", g1: path3, g2: k4, kind: InducedSubgraph, want: 0},
+	{name: "P3 in K4 mono", g1: path3, g2: k4, kind: Monomorphism, want: 24},
+	{name: "P3 in C5 induced", g1: path3, g2: cycle5, kind: InducedSubgraph, want: 10},
+	{name: "P5 in C5 induced", g1: path5, g2: cycle5, kind: InducedSubgraph, want: 0},
+	{name: "P5 in C5 mono", g1: path5, g2: cycle5, kind: Monomorphism, want: 10},
+	{name: "K3 in Petersen mono", g1: triangle, g2: petersen, kind: Monomorphism, want: 0},
+	{name: "C5 in Petersen induced", g1: cycle5, g2: petersen, kind: InducedSubgraph, want: 120},
+	{name: "directed P3 in directed C5 mono", g1: path3, g2: cycle5, directed: true, kind: Monomorphism, want: 5},
+	{name: "directed P3 in transitive tournament induced", g1: path3, g2: transitiveTriangle, directed: true, kind: InducedSubgraph, want: 0},
+	{name: "directed P3 in transitive tournament mono", g1: path3, g2: transitiveTriangle, directed: true, kind: Monomorphism, want: 1},
+	{name: "K4 in K3 mono", g1: k4, g2: triangle, kind: Monomorphism, want: 0},
+	{name: "empty in K3", g1: nil, g2: triangle, kind: InducedSubgraph, want: 1},
+}
+
+func TestMatcherCount(t *testing.T) {
+	for _, test := range matcherCountTests {
+		var g1, g2 builder
+		if test.directed {
+			g1, g2 = directed(test.g1), directed(test.g2)
+		} else {
+			g1, g2 = undirected(test.g1), undirected(test.g2)
+		}
+		m := NewMatcher(g1, g2, test.kind, nil, nil)
+		seen := make(map[string]bool)
+		var got int
+		for m.Next() {
+			mapping := m.Mapping()
+			checkMapping(t, test.name, g1, g2, test.kind, mapping)
+			key := mappingKey(mapping)
+			if seen[key] {
+				t.Errorf("duplicate mapping for %q: %v", test.name, mapping)
+			}
+			seen[key] = true
+			got++
+		}
+		if got != test.want {
+			t.Errorf("unexpected number of mappings for %q: got:%d want:%d", test.name, got, test.want)
+		}
+		if m.Next() {
+			t.Errorf("unexpected mapping after exhaustion for %q", test.name)
+		}
+		if m.Mapping() != nil {
+			t.Errorf("unexpected non-nil mapping after exhaustion for %q", test.name)
+		}
+	}
+}
+
+func TestIsomorphicRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		const n = 12
+		adj := make([]intset, n)
+		perm := rnd.Perm(n)
+		permuted := make([]intset, n)
+		for u := 0; u < n; u++ {
+			for v := u + 1; v < n; v++ {
+				if rnd.Float64() < 0.3 {
+					if adj[u] == nil {
+						adj[u] = make(intset)
+					}
+					adj[u][v] = struct{}{}
+					if permuted[perm[u]] == nil {
+						permuted[perm[u]] = make(intset)
+					}
+					permuted[perm[u]][perm[v]] = struct{}{}
+				}
+			}
+		}
+		for _, dir := range []bool{false, true} {
+			var g1, g2 builder
+			if dir {
+				g1, g2 = directed(adj), directed(permuted)
+			} else {
+				g1, g2 = undirected(adj), undirected(permuted)
+			}
+			m := NewMatcher(g1, g2, Isomorphism, nil, nil)
+			if !m.Next() {
+				t.Errorf("failed to find isomorphism for random graph %d directed=%t", i, dir)
+				continue
+			}
+			checkMapping(t, "random", g1, g2, Isomorphism, m.Mapping())
+
+			// Removing an edge breaks isomorphism.
+			u := simple.Node(perm[0])
+			nbrs := g2.From(u)
+			if len(nbrs) == 0 {
+				continue
+			}
+			g2.RemoveEdge(g2.Edge(u, nbrs[0]))
+			if Isomorphic(g1, g2) {
+				t.Errorf("unexpected isomorphism after edge removal for random graph %d directed=%t", i, dir)
+			}
+		}
+	}
+}
+
+func TestMatcherPredicates(t *testing.T) {
+	// Colour nodes by parity of ID on the pattern
+	// and by ID < 2 on the target.
+	g1 := undirected(path3)
+	g2 := undirected(k4)
+	node := func(u, v graph.Node) bool {
+		return (u.ID()%2 == 0) == (v.ID() < 2)
+	}
+	m := NewMatcher(g1, g2, Monomorphism, node, nil)
+	var got int
+	for m.Next() {
+		mapping := m.Mapping()
+		for u, v := range mapping {
+			if (u%2 == 0) != (v < 2) {
+				t.Errorf("node predicate violated: %d -> %d", u, v)
+			}
+		}
+		got++
+	}
+	// The end nodes of the path must map to
+	// nodes 0 and 1 in either order, and the
+	// middle node to 2 or 3.
+	if got != 4 {
+		t.Errorf("unexpected number of node-matched mappings: got:%d want:4", got)
+	}
+
+	// Weight the edges of the target and require
+	// pattern edges to map to weight 2 edges.
+	w1 := simple.NewUndirectedGraph(0, math.Inf(1))
+	w1.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1), W: 2})
+	w1.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2), W: 2})
+	w2 := simple.NewUndirectedGraph(0, math.Inf(1))
+	for u := 0; u < 4; u++ {
+		v := (u + 1) % 4
+		w2.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v), W: float64(1 + u%2)})
+	}
+	edge := func(e, f graph.Edge) bool {
+		return e.Weight() == f.Weight()
+	}
+	m = NewMatcher(w1, w2, Monomorphism, nil, edge)
+	got = 0
+	for m.Next() {
+		got++
+	}
+	// No two weight 2 edges in the target share a node.
+	if got != 0 {
+		t.Errorf("unexpected number of edge-matched mappings: got:%d want:0", got)
+	}
+	w2.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1), W: 2})
+	m = NewMatcher(w1, w2, Monomorphism, nil, edge)
+	got = 0
+	for m.Next() {
+		got++
+	}
+	// The weight 2 edges now form the path 3-0-1-2.
+	if got != 4 {
+		t.Errorf("unexpected number of edge-matched mappings after reweighting: got:%d want:4", got)
+	}
+}
+
+func TestMatcherMixedPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for mixed directed and undirected graphs")
+		}
+	}()
+	NewMatcher(undirected(path3), directed(path3), Isomorphism, nil, nil)
+}
+
+// checkMapping checks that mapping is a valid mapping of the given kind from g1 to g2.
+func checkMapping(t *testing.T, name string, g1, g2 graph.Graph, kind Kind, mapping map[int]int) {
+	if len(mapping) != len(g1.Nodes()) {
+		t.Errorf("mapping for %q does not cover pattern: %v", name, mapping)
+		return
+	}
+	used := make(map[int]bool)
+	for _, v := range mapping {
+		if used[v] {
+			t.Errorf("mapping for %q is not injective: %v", name, mapping)
+			return
+		}
+		used[v] = true
+	}
+	for _, u := range g1.Nodes() {
+		for _, v := range g1.Nodes() {
+			has1 := g1.HasEdgeBetween(u, v)
+			has2 := g2.HasEdgeBetween(simple.Node(mapping[u.ID()]), simple.Node(mapping[v.ID()]))
+			if d1, ok := g1.(graph.Directed); ok {
+				has1 = d1.HasEdgeFromTo(u, v)
+				has2 = g2.(graph.Directed).HasEdgeFromTo(simple.Node(mapping[u.ID()]), simple.Node(mapping[v.ID()]))
+			}
+			if has1 && !has2 {
+				t.Errorf("mapping for %q does not preserve edge %d-%d: %v", name, u.ID(), v.ID(), mapping)
+			}
+			if kind != Monomorphism && has2 && !has1 {
+				t.Errorf("mapping for %q does not preserve non-edge %d-%d: %v", name, u.ID(), v.ID(), mapping)
+			}
+		}
+	}
+}
+
+func mappingKey(mapping map[int]int) string {
+	b := make([]byte, 0, 2*len(mapping))
+	for u := 0; u < len(mapping); u++ {
+		b = append(b, byte(u), byte(mapping[u]))
+	}
+	return string(b)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/gonum/graph"
 	"github.com/gonum/graph/path"
+	"github.com/gonum/graph/traverse"
 )
 
 // Closeness returns the closeness centrality for nodes in the graph g used to
@@ -65,6 +66,91 @@ func Farness(g graph.Graph, p path.AllShortest) map[int]float64 {
 	return f
 }
 
+// UnweightedCloseness returns the closeness centrality for nodes in the graph g,
+// treating each edge as having unit length.
+//
+//  C(v) = 1 / \sum_u d(u,v)
+//
+// Distances are found by a parallel breadth-first search from each node using
+// the given number of workers. If workers is not positive, runtime.GOMAXPROCS(0)
+// is used. For directed graphs the incoming paths are used. Infinite distances
+// are not considered. The graph must be safe for concurrent reads.
+func UnweightedCloseness(g graph.Graph, workers int) map[int]float64 {
+	c := UnweightedFarness(g, workers)
+	for id, f := range c {
+		c[id] = 1 / f
+	}
+	return c
+}
+
+// UnweightedFarness returns the farness for nodes in the graph g, treating
+// each edge as having unit length.
+//
+//  F(v) = \sum_u d(u,v)
+//
+// Distances are found by a parallel breadth-first search from each node using
+// the given number of workers. If workers is not positive, runtime.GOMAXPROCS(0)
+// is used. For directed graphs the incoming paths are used. Infinite distances
+// are not considered. The graph must be safe for concurrent reads.
+func UnweightedFarness(g graph.Graph, workers int) map[int]float64 {
+	if d, ok := g.(graph.Directed); ok {
+		// Search against the direction of edges
+		// to find the incoming path lengths.
+		g = reversed{d}
+	}
+	w := traverse.ParallelBreadthFirst{Workers: workers}
+	nodes := g.Nodes()
+	f := make(map[int]float64, len(nodes))
+	for _, u := range nodes {
+		var sum float64
+		for _, d := range w.Walk(g, u).Dist {
+			if d > 0 {
+				sum += float64(d)
+			}
+		}
+		f[u.ID()] = sum
+	}
+	return f
+}
+
+// reversed is a directed graph with the edges of a directed graph reversed.
+type reversed struct {
+	graph.Directed
+}
+
+func (g reversed) From(n graph.Node) []graph.Node {
+	return g.Directed.To(n)
+}
+
+func (g reversed) To(n graph.Node) []graph.Node {
+	return g.Directed.From(n)
+}
+
+func (g reversed) HasEdgeFromTo(u, v graph.Node) bool {
+	return g.Directed.HasEdgeFromTo(v, u)
+}
+
+func (g reversed) Edge(u, v graph.Node) graph.Edge {
+	e := g.Directed.Edge(v, u)
+	if e == nil {
+		return nil
+	}
+	return reversedEdge{e}
+}
+
+// reversedEdge is an edge with its direction reversed.
+type reversedEdge struct {
+	graph.Edge
+}
+
+func (e reversedEdge) From() graph.Node {
+	return e.Edge.To()
+}
+
+func (e reversedEdge) To() graph.Node {
+	return e.Edge.From()
+}
+
 // Harmonic returns the harmonic centrality for nodes in the graph g used to
 // construct the given shortest paths.
 //
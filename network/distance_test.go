@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/gonum/floats"
+	"github.com/gonum/graph"
 	"github.com/gonum/graph/path"
 	"github.com/gonum/graph/simple"
 )
@@ -392,3 +393,59 @@ func TestDistanceCentralityDirected(t *testing.T) {
 		}
 	}
 }
+
+func TestUnweightedDistanceCentrality(t *testing.T) {
+	const tol = 1e-12
+	prec := 1 - int(math.Log10(tol))
+
+	var graphs []graph.Graph
+	for _, test := range undirectedCentralityTests {
+		g := simple.NewUndirectedGraph(0, math.Inf(1))
+		for u, e := range test.g {
+			if !g.Has(simple.Node(u)) {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v), W: 1})
+			}
+		}
+		graphs = append(graphs, g)
+	}
+	for _, test := range directedCentralityTests {
+		g := simple.NewDirectedGraph(0, math.Inf(1))
+		for u, e := range test.g {
+			if !g.Has(simple.Node(u)) {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v), W: 1})
+			}
+		}
+		graphs = append(graphs, g)
+	}
+
+	for i, g := range graphs {
+		p, ok := path.FloydWarshall(g)
+		if !ok {
+			t.Errorf("unexpected negative cycle in test %d", i)
+			continue
+		}
+		for _, workers := range []int{1, 4} {
+			for _, test := range []struct {
+				name      string
+				got, want map[int]float64
+			}{
+				{name: "closeness", got: UnweightedCloseness(g, workers), want: Closeness(g, p)},
+				{name: "farness", got: UnweightedFarness(g, workers), want: Farness(g, p)},
+			} {
+				for _, n := range g.Nodes() {
+					if !floats.EqualWithinAbsOrRel(test.got[n.ID()], test.want[n.ID()], tol, tol) {
+						t.Errorf("unexpected unweighted %s for test %d %T with %d workers:\ngot: %v\nwant:%v",
+							test.name, i, g, workers, orderedFloats(test.got, prec), orderedFloats(test.want, prec))
+						break
+					}
+				}
+			}
+		}
+	}
+}
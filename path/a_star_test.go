@@ -10,7 +10,7 @@ import (
 	"testing"
 
 	"github.com/gonum/graph"
-	"github.com/gonum/graph/path/internal"
+	"github.com/gonum/graph/graphs/grid"
 	"github.com/gonum/graph/path/internal/testgraphs"
 	"github.com/gonum/graph/simple"
 	"github.com/gonum/graph/topo"
@@ -27,7 +27,7 @@ var aStarTests = []struct {
 	{
 		name: "simple path",
 		g: func() graph.Graph {
-			return internal.NewGridFrom(
+			return grid.NewGridFrom(
 				"*..*",
 				"**.*",
 				"**.*",
@@ -40,20 +40,20 @@ var aStarTests = []struct {
 	},
 	{
 		name: "small open graph",
-		g:    internal.NewGrid(3, 3, true),
+		g:    grid.NewGrid(3, 3, true),
 
 		s: 0, t: 8,
 	},
 	{
 		name: "large open graph",
-		g:    internal.NewGrid(1000, 1000, true),
+		g:    grid.NewGrid(1000, 1000, true),
 
 		s: 0, t: 999*1000 + 999,
 	},
 	{
 		name: "no path",
 		g: func() graph.Graph {
-			tg := internal.NewGrid(5, 5, true)
+			tg := grid.NewGrid(5, 5, true)
 
 			// Create a complete "wall" across the middle row.
 			tg.Set(2, 0, false)
@@ -70,7 +70,7 @@ var aStarTests = []struct {
 	{
 		name: "partially obstructed",
 		g: func() graph.Graph {
-			tg := internal.NewGrid(10, 10, true)
+			tg := grid.NewGrid(10, 10, true)
 
 			// Create a partial "wall" accross the middle
 			// row with a gap at the left-hand end.
@@ -88,11 +88,14 @@ var aStarTests = []struct {
 		}(),
 
 		s: 5, t: 9*10 + 9,
+		// Grids implement HeuristicCoster, so the
+		// null heuristic must be given explicitly.
+		heuristic: NullHeuristic,
 	},
 	{
 		name: "partially obstructed with heuristic",
 		g: func() graph.Graph {
-			tg := internal.NewGrid(10, 10, true)
+			tg := grid.NewGrid(10, 10, true)
 
 			// Create a partial "wall" accross the middle
 			// row with a gap at the left-hand end.
@@ -0,0 +1,25 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.7
+// +build go1.7
+
+package path
+
+import (
+	"context"
+
+	"github.com/gonum/graph"
+)
+
+// DijkstraAllPathsContext returns a shortest-path tree for shortest paths in the
+// graph g in the same way as DijkstraAllPaths, but checks ctx before finding the
+// paths from each node. If ctx is cancelled or its deadline expires, the search
+// stops and ctx.Err() is returned. Paths from nodes that had been searched are
+// complete, while all other nodes appear to have no paths to any node.
+func DijkstraAllPathsContext(ctx context.Context, g graph.Graph) (paths AllShortest, err error) {
+	paths = newAllShortest(g.Nodes(), false)
+	err = dijkstraAllPaths(g, paths, ctx.Err)
+	return paths, err
+}
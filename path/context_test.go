@@ -0,0 +1,41 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.7
+// +build go1.7
+
+package path
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/gonum/graph/simple"
+)
+
+func TestDijkstraAllPathsContext(t *testing.T) {
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	for i := 0; i < 4; i++ {
+		g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(i + 1), W: 1})
+	}
+
+	pt, err := DijkstraAllPathsContext(context.Background(), g)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if w := pt.Weight(simple.Node(0), simple.Node(4)); w != 4 {
+		t.Errorf("unexpected weight: got:%v want:4", w)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	pt, err = DijkstraAllPathsContext(ctx, g)
+	if err != context.Canceled {
+		t.Errorf("unexpected error for cancelled context: got:%v want:%v", err, context.Canceled)
+	}
+	if w := pt.Weight(simple.Node(0), simple.Node(4)); !math.IsInf(w, 1) {
+		t.Errorf("unexpected weight for cancelled context: got:%v want:+Inf", w)
+	}
+}
@@ -73,15 +73,17 @@ func DijkstraFrom(u graph.Node, g graph.Graph) Shortest {
 // The time complexity of DijkstrAllPaths is O(|V|.|E|+|V|^2.log|V|).
 func DijkstraAllPaths(g graph.Graph) (paths AllShortest) {
 	paths = newAllShortest(g.Nodes(), false)
-	dijkstraAllPaths(g, paths)
+	dijkstraAllPaths(g, paths, nil)
 	return paths
 }
 
 // dijkstraAllPaths is the all-paths implementation of Dijkstra. It is shared
 // between DijkstraAllPaths and JohnsonAllPaths to avoid repeated allocation
-// of the nodes slice and the indexOf map. It returns nothing, but stores the
-// result of the work in the paths parameter which is a reference type.
-func dijkstraAllPaths(g graph.Graph, paths AllShortest) {
+// of the nodes slice and the indexOf map. It stores the result of the work in
+// the paths parameter which is a reference type. If cancelled is not nil it is
+// called before finding the paths from each node, and the work is abandoned and
+// the error returned if it is not nil.
+func dijkstraAllPaths(g graph.Graph, paths AllShortest, cancelled func() error) error {
 	var weight Weighting
 	if wg, ok := g.(graph.Weighter); ok {
 		weight = wg.Weight
@@ -91,6 +93,11 @@ func dijkstraAllPaths(g graph.Graph, paths AllShortest) {
 
 	var Q priorityQueue
 	for i, u := range paths.nodes {
+		if cancelled != nil {
+			if err := cancelled(); err != nil {
+				return err
+			}
+		}
 		// Dijkstra's algorithm here is implemented essentially as
 		// described in Function B.2 in figure 6 of UTCS Technical
 		// Report TR-07-54 with the addition of handling multiple
@@ -125,6 +132,7 @@ func dijkstraAllPaths(g graph.Graph, paths AllShortest) {
 			}
 		}
 	}
+	return nil
 }
 
 type distanceNode struct {
@@ -5,6 +5,7 @@
 package path
 
 import (
+	"errors"
 	"math"
 	"reflect"
 	"sort"
@@ -13,6 +14,7 @@ import (
 	"github.com/gonum/graph"
 	"github.com/gonum/graph/internal/ordered"
 	"github.com/gonum/graph/path/internal/testgraphs"
+	"github.com/gonum/graph/simple"
 )
 
 func TestDijkstraFrom(t *testing.T) {
@@ -176,3 +178,44 @@ func TestDijkstraAllPaths(t *testing.T) {
 		}
 	}
 }
+
+func TestDijkstraAllPathsCancelled(t *testing.T) {
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	for i := 0; i < 4; i++ {
+		g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(i + 1), W: 1})
+	}
+	n := len(g.Nodes())
+
+	// Allow two sources to be searched.
+	errStop := errors.New("stop")
+	var calls int
+	pt := newAllShortest(g.Nodes(), false)
+	err := dijkstraAllPaths(g, pt, func() error {
+		if calls++; calls > 2 {
+			return errStop
+		}
+		return nil
+	})
+	if err != errStop {
+		t.Errorf("unexpected error for partial search: got:%v want:%v", err, errStop)
+	}
+	var searched int
+	for _, u := range pt.nodes {
+		var reached int
+		for _, v := range pt.nodes {
+			if !math.IsInf(pt.Weight(u, v), 1) {
+				reached++
+			}
+		}
+		switch reached {
+		case n:
+			searched++
+		case 0:
+		default:
+			t.Errorf("unexpected partial paths from node %d: reached %d nodes", u.ID(), reached)
+		}
+	}
+	if searched != 2 {
+		t.Errorf("unexpected number of searched sources: got:%d want:2", searched)
+	}
+}
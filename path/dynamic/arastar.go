@@ -0,0 +1,369 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dynamic
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/path"
+	"github.com/gonum/graph/simple"
+)
+
+// ARAStar implements the Anytime Repairing A* path search algorithm. ARAStar
+// quickly finds a path with an inflated heuristic and then improves the path
+// by repeatedly decreasing the inflation factor, reusing previous search
+// effort. Each path found is within a known factor of the optimal path weight.
+//
+//	Likhachev, Gordon and Thrun "ARA*: Anytime A* with Provable Bounds on
+//	Sub-Optimality" NIPS 2003.
+type ARAStar struct {
+	s, t *araStarNode
+
+	model WorldModel
+	open  araStarQueue
+	// incons holds nodes that became
+	// inconsistent while closed during
+	// the current search iteration.
+	incons []*araStarNode
+
+	// epsilon is the current heuristic
+	// inflation factor and initial and
+	// decrement hold the parameters
+	// for the anytime search.
+	epsilon   float64
+	initial   float64
+	decrement float64
+
+	weight    path.Weighting
+	heuristic path.Heuristic
+}
+
+// NewARAStar returns a new ARAStar planner for the path from s to t in g using the
+// heuristic h, and performs the initial search with the heuristic inflated by the
+// factor epsilon. Subsequent calls to Improve reduce the inflation factor by decrement
+// until it reaches 1. The world model, m, is used to store path information during
+// path planning. The world model must be an empty graph when NewARAStar is called.
+//
+// If h is nil, the ARAStar will use the g.HeuristicCost method if g implements
+// path.HeuristicCoster, falling back to path.NullHeuristic otherwise. If the graph does
+// not implement graph.Weighter, path.UniformCost is used. NewARAStar will panic if g
+// has a negative edge weight, if epsilon is less than 1 or if decrement is not positive.
+// The suboptimality bounds reported by ARAStar are only valid for admissible heuristics.
+func NewARAStar(s, t graph.Node, g graph.Graph, h path.Heuristic, m WorldModel, epsilon, decrement float64) *ARAStar {
+	if !(epsilon >= 1) {
+		panic("ARA*: inflation factor less than 1")
+	}
+	if !(decrement > 0) {
+		panic("ARA*: non-positive inflation decrement")
+	}
+
+	a := &ARAStar{
+		s: newARAStarNode(s),
+		t: newARAStarNode(t),
+
+		model: m,
+
+		initial:   epsilon,
+		decrement: decrement,
+
+		heuristic: h,
+	}
+	if s.ID() == t.ID() {
+		a.t = a.s
+	}
+
+	if wg, ok := g.(graph.Weighter); ok {
+		a.weight = wg.Weight
+	} else {
+		a.weight = path.UniformCost(g)
+	}
+	if a.heuristic == nil {
+		if g, ok := g.(path.HeuristicCoster); ok {
+			a.heuristic = g.HeuristicCost
+		} else {
+			a.heuristic = path.NullHeuristic
+		}
+	}
+
+	for _, n := range g.Nodes() {
+		switch n.ID() {
+		case a.s.ID():
+			a.model.AddNode(a.s)
+		case a.t.ID():
+			a.model.AddNode(a.t)
+		default:
+			a.model.AddNode(newARAStarNode(n))
+		}
+	}
+	for _, u := range a.model.Nodes() {
+		for _, v := range g.From(u) {
+			w := edgeWeight(a.weight, u, v)
+			if w < 0 {
+				panic("ARA*: negative edge weight")
+			}
+			a.model.SetEdge(simple.Edge{F: u, T: a.model.Node(v.ID()), W: w})
+		}
+	}
+
+	a.restart()
+
+	return a
+}
+
+// restart discards all search state and performs
+// a search with the initial inflation factor.
+func (a *ARAStar) restart() {
+	/*
+	   procedure Main()
+	   {01} g(s_goal) = ∞; g(s_start) = 0;
+	   {02} OPEN = CLOSED = INCONS = ∅;
+	   {03} insert s_start into OPEN with fvalue(s_start);
+	   {04} ImprovePath();
+	*/
+	for _, n := range a.model.Nodes() {
+		u := a.worldNodeFor(n)
+		u.g = math.Inf(1)
+		u.parent = nil
+		u.closed = false
+		u.incons = false
+		u.idx = -1
+	}
+	a.open = a.open[:0]
+	a.incons = a.incons[:0]
+	a.epsilon = a.initial
+
+	a.s.g = 0
+	a.open.insert(a.s, a.fvalue(a.s))
+	a.improvePath()
+}
+
+// fvalue returns the inflated priority of u.
+func (a *ARAStar) fvalue(u *araStarNode) float64 {
+	return u.g + a.epsilon*a.heuristic(u.Node, a.t.Node)
+}
+
+// improvePath is the ImprovePath procedure in the ARA* paper.
+func (a *ARAStar) improvePath() {
+	/*
+	   procedure ImprovePath()
+	   {01} while(fvalue(s_goal) > min_{s∈OPEN}(fvalue(s)))
+	   {02}   remove s with the smallest fvalue(s) from OPEN;
+	   {03}   CLOSED = CLOSED ∪ {s};
+	   {04}   for each successor s' of s
+	   {05}     if s' was never visited by ARA* before then
+	   {06}       g(s') = ∞;
+	   {07}     if g(s') > g(s) + c(s, s')
+	   {08}       g(s') = g(s) + c(s, s');
+	   {09}       if s' ∉ CLOSED
+	   {10}         insert s' into OPEN with fvalue(s');
+	   {11}       else
+	   {12}         insert s' into INCONS;
+	*/
+	for a.open.Len() != 0 && a.fvalue(a.t) > a.open[0].f {
+		u := heap.Pop(&a.open).(*araStarNode)
+		u.closed = true
+		for _, _v := range a.model.From(u) {
+			v := _v.(*araStarNode)
+			g := u.g + edgeWeight(a.model.Weight, u, v)
+			if g >= v.g {
+				continue
+			}
+			v.g = g
+			v.parent = u
+			switch {
+			case !v.closed && v.inQueue():
+				a.open.update(v, a.fvalue(v))
+			case !v.closed:
+				a.open.insert(v, a.fvalue(v))
+			case !v.incons:
+				v.incons = true
+				a.incons = append(a.incons, v)
+			}
+		}
+	}
+}
+
+// Improve decreases the heuristic inflation factor and improves the current
+// path, reusing the previous search effort. Improve returns false without
+// further searching if the inflation factor has already reached 1 or the
+// current path is known to be optimal.
+func (a *ARAStar) Improve() bool {
+	/*
+	   procedure Main()
+	   {07} while ε' > 1
+	   {08}   decrease ε;
+	   {09}   Move states from INCONS into OPEN;
+	   {10}   Update the priorities for all s ∈ OPEN according to fvalue(s);
+	   {11}   CLOSED = ∅;
+	   {12}   ImprovePath();
+	*/
+	if a.epsilon <= 1 || a.Bound() <= 1 {
+		return false
+	}
+	a.epsilon = math.Max(1, a.epsilon-a.decrement)
+	for _, u := range a.incons {
+		u.incons = false
+		if !u.inQueue() {
+			a.open.insert(u, 0)
+		}
+	}
+	a.incons = a.incons[:0]
+	for _, u := range a.open {
+		u.f = a.fvalue(u)
+	}
+	heap.Init(&a.open)
+	for _, n := range a.model.Nodes() {
+		a.worldNodeFor(n).closed = false
+	}
+	a.improvePath()
+	return true
+}
+
+// Epsilon returns the current heuristic inflation factor.
+func (a *ARAStar) Epsilon() float64 {
+	return a.epsilon
+}
+
+// Bound returns the current suboptimality bound of the path returned by Path.
+// The weight of the path is no more than Bound times the weight of the optimal
+// path. If no path has been found, Bound returns +Inf.
+func (a *ARAStar) Bound() float64 {
+	/*
+	   procedure Main()
+	   {05} ε' = min(ε, g(s_goal)/min_{s∈OPEN∪INCONS}(g(s)+h(s)));
+	*/
+	if math.IsInf(a.t.g, 1) {
+		return math.Inf(1)
+	}
+	min := math.Inf(1)
+	for _, u := range a.open {
+		min = math.Min(min, u.g+a.heuristic(u.Node, a.t.Node))
+	}
+	for _, u := range a.incons {
+		min = math.Min(min, u.g+a.heuristic(u.Node, a.t.Node))
+	}
+	return math.Max(1, math.Min(a.epsilon, a.t.g/min))
+}
+
+// UpdateWorld updates or adds edges in the world graph. The new weights of the
+// changed edges are obtained from the graph passed to NewARAStar. Since ARA* does
+// not repair searches after changes to the world, UpdateWorld discards the search
+// state and searches again with the initial inflation factor. UpdateWorld will
+// panic if changes include a negative edge weight.
+func (a *ARAStar) UpdateWorld(changes []graph.Edge) {
+	if len(changes) == 0 {
+		return
+	}
+	for _, e := range changes {
+		from := e.From()
+		to := e.To()
+		c, _ := a.weight(from, to)
+		if c < 0 {
+			panic("ARA*: negative edge weight")
+		}
+		a.model.SetEdge(simple.Edge{F: a.worldNodeFor(from), T: a.worldNodeFor(to), W: c})
+	}
+	a.restart()
+}
+
+func (a *ARAStar) worldNodeFor(n graph.Node) *araStarNode {
+	switch w := a.model.Node(n.ID()).(type) {
+	case *araStarNode:
+		return w
+	case graph.Node:
+		panic(fmt.Sprintf("ARA*: illegal world model node type: %T", w))
+	default:
+		return newARAStarNode(n)
+	}
+}
+
+// Path returns the current path from the start to the goal and the weight of
+// the path. The weight of the path is within a factor of Bound of the optimal
+// path weight.
+func (a *ARAStar) Path() (p []graph.Node, weight float64) {
+	if math.IsInf(a.t.g, 1) {
+		return nil, math.Inf(1)
+	}
+	for u := a.t; u != nil; u = u.parent {
+		p = append(p, u.Node)
+	}
+	for i, j := 0, len(p)-1; i < j; i, j = i+1, j-1 {
+		p[i], p[j] = p[j], p[i]
+	}
+	return p, a.t.g
+}
+
+// araStarNode adds ARA* accounting to a graph.Node.
+type araStarNode struct {
+	graph.Node
+	g float64
+	f float64
+
+	parent *araStarNode
+
+	idx    int
+	closed bool
+	incons bool
+}
+
+// newARAStarNode returns an ARA* node that is in a legal state
+// for existence outside the ARAStar priority queue.
+func newARAStarNode(n graph.Node) *araStarNode {
+	return &araStarNode{
+		Node: n,
+		g:    math.Inf(1),
+		idx:  -1,
+	}
+}
+
+// inQueue returns whether the node is in the queue.
+func (q *araStarNode) inQueue() bool {
+	return q.idx >= 0
+}
+
+// araStarQueue is an ARA* priority queue ordered by fvalue.
+type araStarQueue []*araStarNode
+
+func (q araStarQueue) Less(i, j int) bool {
+	return q[i].f < q[j].f
+}
+
+func (q araStarQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].idx = i
+	q[j].idx = j
+}
+
+func (q araStarQueue) Len() int {
+	return len(q)
+}
+
+func (q *araStarQueue) Push(x interface{}) {
+	n := x.(*araStarNode)
+	n.idx = len(*q)
+	*q = append(*q, n)
+}
+
+func (q *araStarQueue) Pop() interface{} {
+	n := (*q)[len(*q)-1]
+	n.idx = -1
+	*q = (*q)[:len(*q)-1]
+	return n
+}
+
+// insert puts the node u into the queue with the fvalue f.
+func (q *araStarQueue) insert(u *araStarNode, f float64) {
+	u.f = f
+	heap.Push(q, u)
+}
+
+// update updates the node u in the queue with the fvalue f.
+func (q *araStarQueue) update(u *araStarNode, f float64) {
+	u.f = f
+	heap.Fix(q, u.idx)
+}
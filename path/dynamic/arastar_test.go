@@ -0,0 +1,112 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dynamic
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/graphs/grid"
+	"github.com/gonum/graph/path"
+	"github.com/gonum/graph/simple"
+)
+
+func TestARAStar(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		const rows, cols = 20, 20
+		g := grid.NewGrid(rows, cols, true)
+		g.AllowDiagonal = true
+		for r := 0; r < rows; r++ {
+			for c := 0; c < cols; c++ {
+				if rnd.Float64() < 0.25 {
+					g.Set(r, c, false)
+				}
+			}
+		}
+		s := g.NodeAt(0, 0)
+		tn := g.NodeAt(rows-1, cols-1)
+		g.Set(0, 0, true)
+		g.Set(rows-1, cols-1, true)
+
+		want := path.DijkstraFrom(s, g).WeightTo(tn)
+
+		a := NewARAStar(s, tn, g, nil, simple.NewDirectedGraph(0, math.Inf(1)), 3, 0.5)
+		if a.Epsilon() != 3 {
+			t.Errorf("unexpected initial inflation for test %d: got:%v want:3", i, a.Epsilon())
+		}
+		lastBound := math.Inf(1)
+		for {
+			p, weight := a.Path()
+			bound := a.Bound()
+			if math.IsInf(want, 1) {
+				if p != nil || !math.IsInf(weight, 1) || !math.IsInf(bound, 1) {
+					t.Errorf("unexpected path for test %d: got:%v weight:%v bound:%v", i, p, weight, bound)
+				}
+			} else {
+				if weight > bound*want+1e-10 {
+					t.Errorf("path weight exceeds bound for test %d: weight:%v bound:%v optimal:%v", i, weight, bound, want)
+				}
+				if bound > a.Epsilon() {
+					t.Errorf("bound exceeds inflation for test %d: bound:%v epsilon:%v", i, bound, a.Epsilon())
+				}
+				if bound > lastBound {
+					t.Errorf("bound increased for test %d: got:%v last:%v", i, bound, lastBound)
+				}
+				if w := weightOf(p, g); math.Abs(w-weight) > 1e-10 {
+					t.Errorf("unexpected weight of returned path for test %d: got:%v want:%v", i, w, weight)
+				}
+			}
+			lastBound = bound
+			if !a.Improve() {
+				break
+			}
+		}
+		if _, weight := a.Path(); math.Abs(weight-want) > 1e-10 && !(math.IsInf(weight, 1) && math.IsInf(want, 1)) {
+			t.Errorf("unexpected final path weight for test %d: got:%v want:%v", i, weight, want)
+		}
+	}
+}
+
+func TestARAStarUpdateWorld(t *testing.T) {
+	g := grid.NewGridFrom(
+		".....",
+		".....",
+		".....",
+	)
+	s := g.NodeAt(1, 0)
+	tn := g.NodeAt(1, 4)
+	a := NewARAStar(s, tn, g, nil, simple.NewDirectedGraph(0, math.Inf(1)), 2, 1)
+	for a.Improve() {
+	}
+	if _, weight := a.Path(); weight != 4 {
+		t.Fatalf("unexpected initial path weight: got:%v want:4", weight)
+	}
+
+	// Block the direct route.
+	var changes []graph.Edge
+	for _, r := range []int{0, 1} {
+		u := g.NodeAt(r, 2)
+		for _, v := range g.From(u) {
+			changes = append(changes, simple.Edge{F: u, T: v}, simple.Edge{F: v, T: u})
+		}
+		g.Set(r, 2, false)
+	}
+	a.UpdateWorld(changes)
+	if a.Epsilon() != 2 {
+		t.Errorf("unexpected inflation after world update: got:%v want:2", a.Epsilon())
+	}
+	for a.Improve() {
+	}
+	p, weight := a.Path()
+	if weight != 6 {
+		t.Errorf("unexpected path weight after world update: got:%v want:6 path:%v", weight, p)
+	}
+	if w := weightOf(p, g); w != weight {
+		t.Errorf("unexpected weight of returned path: got:%v want:%v", w, weight)
+	}
+}
@@ -15,13 +15,21 @@ import (
 )
 
 // DStarLite implements the D* Lite dynamic re-planning path search algorithm.
+// DStarLite may search for a path to any one of a set of acceptable goals and
+// the goals may be changed during planning, as described for Moving Target
+// D* Lite.
 //
 //  doi:10.1109/tro.2004.838026 and ISBN:0-262-51129-0 pp476-483
+//  Sun, Yeoh and Koenig "Moving Target D* Lite" AAMAS 2010.
 //
 type DStarLite struct {
-	s, t *dStarLiteNode
+	s    *dStarLiteNode
 	last *dStarLiteNode
 
+	// goals holds the acceptable
+	// goal nodes keyed by ID.
+	goals map[int]*dStarLiteNode
+
 	model       WorldModel
 	queue       dStarLiteQueue
 	keyModifier float64
@@ -47,6 +55,13 @@ type WorldModel interface {
 // implement graph.Weighter, path.UniformCost is used. NewDStarLite will panic if g has
 // a negative edge weight.
 func NewDStarLite(s, t graph.Node, g graph.Graph, h path.Heuristic, m WorldModel) *DStarLite {
+	return NewDStarLiteGoals(s, []graph.Node{t}, g, h, m)
+}
+
+// NewDStarLiteGoals returns a new DStarLite planner for the path from s to the closest
+// of the goals in g using the heuristic h. Goals that are not nodes in g are ignored.
+// Other parameters are interpreted as described for NewDStarLite.
+func NewDStarLiteGoals(s graph.Node, goals []graph.Node, g graph.Graph, h path.Heuristic, m WorldModel) *DStarLite {
 	/*
 	   procedure Initialize()
 	   {02”} U = ∅;
@@ -57,14 +72,13 @@ func NewDStarLite(s, t graph.Node, g graph.Graph, h path.Heuristic, m WorldModel
 	*/
 
 	d := &DStarLite{
-		s: newDStarLiteNode(s),
-		t: newDStarLiteNode(t), // badKey is overwritten below.
+		s:     newDStarLiteNode(s),
+		goals: make(map[int]*dStarLiteNode, len(goals)),
 
 		model: m,
 
 		heuristic: h,
 	}
-	d.t.rhs = 0
 
 	/*
 		procedure Main()
@@ -86,17 +100,23 @@ func NewDStarLite(s, t graph.Node, g graph.Graph, h path.Heuristic, m WorldModel
 		}
 	}
 
-	d.queue.insert(d.t, key{d.heuristic(s, t), 0})
-
+	isGoal := make(map[int]bool, len(goals))
+	for _, t := range goals {
+		isGoal[t.ID()] = true
+	}
 	for _, n := range g.Nodes() {
-		switch n.ID() {
-		case d.s.ID():
-			d.model.AddNode(d.s)
-		case d.t.ID():
-			d.model.AddNode(d.t)
-		default:
-			d.model.AddNode(newDStarLiteNode(n))
+		u := d.s
+		if n.ID() != d.s.ID() {
+			u = newDStarLiteNode(n)
 		}
+		d.model.AddNode(u)
+		if isGoal[u.ID()] {
+			d.goals[u.ID()] = u
+		}
+	}
+	for _, t := range d.goals {
+		t.rhs = 0
+		d.queue.insert(t, d.keyFor(t))
 	}
 	for _, u := range d.model.Nodes() {
 		for _, v := range g.From(u) {
@@ -195,7 +215,7 @@ func (d *DStarLite) findShortestPath() {
 			d.queue.remove(u)
 			for _, _s := range d.model.To(u) {
 				s := _s.(*dStarLiteNode)
-				if s.ID() != d.t.ID() {
+				if !d.isGoal(s) {
 					s.rhs = math.Min(s.rhs, edgeWeight(d.model.Weight, s, u)+u.g)
 				}
 				d.update(s)
@@ -206,11 +226,8 @@ func (d *DStarLite) findShortestPath() {
 			for _, _s := range append(d.model.To(u), u) {
 				s := _s.(*dStarLiteNode)
 				if s.rhs == edgeWeight(d.model.Weight, s, u)+gOld {
-					if s.ID() != d.t.ID() {
-						s.rhs = math.Inf(1)
-						for _, t := range d.model.From(s) {
-							s.rhs = math.Min(s.rhs, edgeWeight(d.model.Weight, s, t)+t.(*dStarLiteNode).g)
-						}
+					if !d.isGoal(s) {
+						d.recalculate(s)
 					}
 				}
 				d.update(s)
@@ -230,7 +247,7 @@ func (d *DStarLite) Step() bool {
 	   {33”} // if (rhs(s_start) = ∞) then there is no known path
 	   {34”}   s_start = argmin s'∈Succ(s_start)(c(s_start, s') + g(s'));
 	*/
-	if d.s.ID() == d.t.ID() {
+	if d.isGoal(d.s) {
 		return false
 	}
 	if math.IsInf(d.s.rhs, 1) {
@@ -300,19 +317,16 @@ func (d *DStarLite) UpdateWorld(changes []graph.Edge) {
 			panic("D* Lite: negative edge weight")
 		}
 		cOld, _ := d.model.Weight(from, to)
-		u := d.worldNodeFor(from)
-		v := d.worldNodeFor(to)
+		u := worldNodeFor(d.model, from)
+		v := worldNodeFor(d.model, to)
 		d.model.SetEdge(simple.Edge{F: u, T: v, W: c})
 		if cOld > c {
-			if u.ID() != d.t.ID() {
+			if !d.isGoal(u) {
 				u.rhs = math.Min(u.rhs, c+v.g)
 			}
 		} else if u.rhs == cOld+v.g {
-			if u.ID() != d.t.ID() {
-				u.rhs = math.Inf(1)
-				for _, t := range d.model.From(u) {
-					u.rhs = math.Min(u.rhs, edgeWeight(d.model.Weight, u, t)+t.(*dStarLiteNode).g)
-				}
+			if !d.isGoal(u) {
+				d.recalculate(u)
 			}
 		}
 		d.update(u)
@@ -320,8 +334,69 @@ func (d *DStarLite) UpdateWorld(changes []graph.Edge) {
 	d.findShortestPath()
 }
 
-func (d *DStarLite) worldNodeFor(n graph.Node) *dStarLiteNode {
-	switch w := d.model.Node(n.ID()).(type) {
+// SetGoals changes the set of acceptable goals of the search and re-plans
+// the path from the current location, reusing the search effort from the
+// previous goals. SetGoals may be used to follow a moving target. Goals that
+// are not nodes in the world model are ignored.
+func (d *DStarLite) SetGoals(goals ...graph.Node) {
+	next := make(map[int]*dStarLiteNode, len(goals))
+	for _, t := range goals {
+		if u, ok := d.model.Node(t.ID()).(*dStarLiteNode); ok {
+			next[u.ID()] = u
+		}
+	}
+
+	// A node is a goal exactly when its rhs is fixed at
+	// zero, so changing the goals only requires the rhs
+	// of nodes entering or leaving the goal set to be
+	// updated before the search is repaired.
+	d.keyModifier += d.heuristic(d.last, d.s)
+	d.last = d.s
+	old := d.goals
+	d.goals = next
+	for id, u := range old {
+		if _, ok := next[id]; !ok {
+			d.recalculate(u)
+			d.update(u)
+		}
+	}
+	for id, u := range next {
+		if _, ok := old[id]; !ok {
+			u.rhs = 0
+			d.update(u)
+		}
+	}
+	d.findShortestPath()
+}
+
+// Goals returns the current set of acceptable goals.
+func (d *DStarLite) Goals() []graph.Node {
+	goals := make([]graph.Node, 0, len(d.goals))
+	for _, t := range d.goals {
+		goals = append(goals, t.Node)
+	}
+	return goals
+}
+
+// isGoal returns whether u is an acceptable goal.
+func (d *DStarLite) isGoal(u graph.Node) bool {
+	_, ok := d.goals[u.ID()]
+	return ok
+}
+
+// recalculate sets the rhs of u to the minimum cost
+// of reaching a goal through each of its successors.
+func (d *DStarLite) recalculate(u *dStarLiteNode) {
+	u.rhs = math.Inf(1)
+	for _, t := range d.model.From(u) {
+		u.rhs = math.Min(u.rhs, edgeWeight(d.model.Weight, u, t)+t.(*dStarLiteNode).g)
+	}
+}
+
+// worldNodeFor returns the node in the world model m corresponding to n,
+// or a new node if m does not hold a node with the ID of n.
+func worldNodeFor(m WorldModel, n graph.Node) *dStarLiteNode {
+	switch w := m.Node(n.ID()).(type) {
 	case *dStarLiteNode:
 		return w
 	case graph.Node:
@@ -336,12 +411,12 @@ func (d *DStarLite) Here() graph.Node {
 	return d.s.Node
 }
 
-// Path returns the path from the current location to the goal and the
-// weight of the path.
+// Path returns the path from the current location to the closest goal and
+// the weight of the path.
 func (d *DStarLite) Path() (p []graph.Node, weight float64) {
 	u := d.s
 	p = []graph.Node{u.Node}
-	for u.ID() != d.t.ID() {
+	for !d.isGoal(u) {
 		if math.IsInf(u.rhs, 1) {
 			return nil, math.Inf(1)
 		}
@@ -9,13 +9,14 @@ import (
 	"flag"
 	"fmt"
 	"math"
+	"math/rand"
 	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/gonum/graph"
+	"github.com/gonum/graph/graphs/grid"
 	"github.com/gonum/graph/path"
-	"github.com/gonum/graph/path/internal"
 	"github.com/gonum/graph/path/internal/testgraphs"
 	"github.com/gonum/graph/simple"
 )
@@ -88,12 +89,12 @@ func TestDStarLiteNullHeuristic(t *testing.T) {
 }
 
 var dynamicDStarLiteTests = []struct {
-	g          *internal.Grid
+	g          *grid.Grid
 	radius     float64
 	all        bool
 	diag, unit bool
 	remember   []bool
-	modify     func(*internal.LimitedVisionGrid)
+	modify     func(*grid.LimitedVisionGrid)
 
 	heuristic func(dx, dy float64) float64
 
@@ -105,7 +106,7 @@ var dynamicDStarLiteTests = []struct {
 }{
 	{
 		// This is the example shown in figures 6 and 7 of doi:10.1109/tro.2004.838026.
-		g: internal.NewGridFrom(
+		g: grid.NewGridFrom(
 			"...",
 			".*.",
 			".*.",
@@ -139,7 +140,7 @@ var dynamicDStarLiteTests = []struct {
 		// may be taken incorrectly at 90° or correctly at 45° because the
 		// calculated rhs values of 12 and 17 are tied when moving from node
 		// 16, and the grid is small enough to examine by a dump.
-		g: internal.NewGridFrom(
+		g: grid.NewGridFrom(
 			".....",
 			"...*.",
 			"**.*.",
@@ -176,7 +177,7 @@ var dynamicDStarLiteTests = []struct {
 		// with the exception that diagonal edge weights are calculated with the hypot
 		// function instead of a step count and only allowing information to be known
 		// from exploration.
-		g: internal.NewGridFrom(
+		g: grid.NewGridFrom(
 			"..................",
 			"..................",
 			"..................",
@@ -239,7 +240,7 @@ var dynamicDStarLiteTests = []struct {
 		// with the exception that diagonal edge weights are calculated with the hypot
 		// function instead of a step count, not closing the exit and only allowing
 		// information to be known from exploration.
-		g: internal.NewGridFrom(
+		g: grid.NewGridFrom(
 			"..................",
 			"..................",
 			"..................",
@@ -299,7 +300,7 @@ var dynamicDStarLiteTests = []struct {
 		// with the exception that diagonal edge weights are calculated with the hypot
 		// function instead of a step count, the exit is closed at a distance and
 		// information is allowed to be known from exploration.
-		g: internal.NewGridFrom(
+		g: grid.NewGridFrom(
 			"..................",
 			"..................",
 			"..................",
@@ -363,7 +364,7 @@ var dynamicDStarLiteTests = []struct {
 		// This is the example shown in figure 2 of doi:10.1109/tro.2004.838026
 		// with the exception that diagonal edge weights are calculated with the hypot
 		// function instead of a step count.
-		g: internal.NewGridFrom(
+		g: grid.NewGridFrom(
 			"..................",
 			"..................",
 			"..................",
@@ -385,7 +386,7 @@ var dynamicDStarLiteTests = []struct {
 		diag:     true,
 		remember: []bool{true},
 
-		modify: func(l *internal.LimitedVisionGrid) {
+		modify: func(l *grid.LimitedVisionGrid) {
 			all := l.Grid.AllVisible
 			l.Grid.AllVisible = false
 			for _, n := range l.Nodes() {
@@ -456,7 +457,7 @@ var dynamicDStarLiteTests = []struct {
 		weight: 21.242640687119287,
 	},
 	{
-		g: internal.NewGridFrom(
+		g: grid.NewGridFrom(
 			"*..*",
 			"**.*",
 			"**.*",
@@ -484,7 +485,7 @@ var dynamicDStarLiteTests = []struct {
 		weight: 4,
 	},
 	{
-		g: internal.NewGridFrom(
+		g: grid.NewGridFrom(
 			"*..*",
 			"**.*",
 			"**.*",
@@ -511,7 +512,7 @@ var dynamicDStarLiteTests = []struct {
 		weight: math.Sqrt2 + 2,
 	},
 	{
-		g: internal.NewGridFrom(
+		g: grid.NewGridFrom(
 			"...",
 			".*.",
 			".*.",
@@ -552,7 +553,7 @@ var dynamicDStarLiteTests = []struct {
 func TestDStarLiteDynamic(t *testing.T) {
 	for i, test := range dynamicDStarLiteTests {
 		for _, remember := range test.remember {
-			l := &internal.LimitedVisionGrid{
+			l := &grid.LimitedVisionGrid{
 				Grid:         test.g,
 				VisionRadius: test.radius,
 				Location:     test.s,
@@ -683,3 +684,75 @@ func simpleEdgesOf(g weightedGraph, edges []graph.Edge) []simple.Edge {
 	}
 	return w
 }
+
+func TestDStarLiteGoals(t *testing.T) {
+	g := grid.NewGridFrom(
+		"........",
+		".******.",
+		"........",
+		"........",
+	)
+	s := g.NodeAt(3, 0)
+	goals := []graph.Node{g.NodeAt(0, 0), g.NodeAt(0, 7), g.NodeAt(3, 7)}
+
+	d := NewDStarLiteGoals(s, goals, g, nil, simple.NewDirectedGraph(0, math.Inf(1)))
+	if got := len(d.Goals()); got != len(goals) {
+		t.Errorf("unexpected number of goals: got:%d want:%d", got, len(goals))
+	}
+	p, weight := d.Path()
+	if weight != 3 || p[len(p)-1].ID() != goals[0].ID() {
+		t.Errorf("unexpected path to closest goal: got:%v weight:%v want end:%d weight:3", p, weight, goals[0].ID())
+	}
+
+	// Remove the closest goal.
+	d.SetGoals(goals[1:]...)
+	p, weight = d.Path()
+	if weight != 7 || p[len(p)-1].ID() != goals[2].ID() {
+		t.Errorf("unexpected path after retargeting: got:%v weight:%v want end:%d weight:7", p, weight, goals[2].ID())
+	}
+	for d.Step() {
+	}
+	if d.Here().ID() != goals[2].ID() {
+		t.Errorf("unexpected final location: got:%d want:%d", d.Here().ID(), goals[2].ID())
+	}
+}
+
+func TestDStarLiteMovingTarget(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		const rows, cols = 15, 15
+		g := grid.NewGrid(rows, cols, true)
+		for r := 0; r < rows; r++ {
+			for c := 0; c < cols; c++ {
+				if rnd.Float64() < 0.2 {
+					g.Set(r, c, false)
+				}
+			}
+		}
+		g.Set(0, 0, true)
+		g.Set(rows-1, cols-1, true)
+		s := g.NodeAt(0, 0)
+		target := g.NodeAt(rows-1, cols-1)
+
+		d := NewDStarLite(s, target, g, nil, simple.NewDirectedGraph(0, math.Inf(1)))
+		for step := 0; step < 2*rows*cols; step++ {
+			want := path.DijkstraFrom(d.Here(), g).WeightTo(target)
+			p, weight := d.Path()
+			if weight != want {
+				t.Fatalf("unexpected path weight for test %d step %d: got:%v want:%v", i, step, weight, want)
+			}
+			if p != nil && (p[len(p)-1].ID() != target.ID() || weightOf(p, g) != want) {
+				t.Fatalf("unexpected path for test %d step %d: got:%v", i, step, p)
+			}
+			if !d.Step() {
+				break
+			}
+
+			// Move the target to a random open neighbour.
+			if to := g.From(target); len(to) != 0 {
+				target = to[rnd.Intn(len(to))]
+				d.SetGoals(target)
+			}
+		}
+	}
+}
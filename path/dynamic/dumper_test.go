@@ -11,7 +11,7 @@ import (
 	"sort"
 	"text/tabwriter"
 
-	"github.com/gonum/graph/path/internal"
+	"github.com/gonum/graph/graphs/grid"
 	"github.com/gonum/graph/simple"
 )
 
@@ -20,7 +20,7 @@ type dumper struct {
 	step int
 
 	dStarLite *DStarLite
-	grid      *internal.LimitedVisionGrid
+	grid      *grid.LimitedVisionGrid
 
 	w io.Writer
 }
@@ -67,7 +67,7 @@ func (d *dumper) dump(withpath bool) {
 							// Mark location as illegal.
 							fmt.Fprintf(w, "id:%2d  >!<", n.ID())
 						}
-					} else if n.ID() == d.dStarLite.t.ID() {
+					} else if d.dStarLite.isGoal(n) {
 						fmt.Fprintf(w, "id:%2d   G", n.ID())
 						// Mark goal cell as illegal.
 						if !d.grid.Grid.HasOpen(n) {
@@ -0,0 +1,231 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dynamic
+
+import (
+	"math"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/path"
+	"github.com/gonum/graph/simple"
+)
+
+// LPAStar implements the Lifelong Planning A* incremental path search
+// algorithm. Unlike DStarLite, the start of the path is fixed and the path
+// is re-planned from the start as edge weights change.
+//
+//	doi:10.1016/j.artint.2002.11.001
+type LPAStar struct {
+	s, t *dStarLiteNode
+
+	model WorldModel
+	queue dStarLiteQueue
+
+	weight    path.Weighting
+	heuristic path.Heuristic
+}
+
+// NewLPAStar returns a new LPAStar planner for the path from s to t in g using the
+// heuristic h. The world model, m, is used to store shortest path information during path
+// planning. The world model must be an empty graph when NewLPAStar is called.
+//
+// If h is nil, the LPAStar will use the g.HeuristicCost method if g implements
+// path.HeuristicCoster, falling back to path.NullHeuristic otherwise. If the graph does not
+// implement graph.Weighter, path.UniformCost is used. NewLPAStar will panic if g has
+// a negative edge weight.
+func NewLPAStar(s, t graph.Node, g graph.Graph, h path.Heuristic, m WorldModel) *LPAStar {
+	/*
+	   procedure Initialize()
+	   {02'} U = ∅;
+	   {03'} for all s ∈ S rhs(s) = g(s) = ∞;
+	   {04'} rhs(s_start) = 0;
+	   {05'} U.Insert(s_start, [h(s_start); 0]);
+	*/
+
+	l := &LPAStar{
+		s: newDStarLiteNode(s),
+		t: newDStarLiteNode(t),
+
+		model: m,
+
+		heuristic: h,
+	}
+	if s.ID() == t.ID() {
+		l.t = l.s
+	}
+	l.s.rhs = 0
+
+	if wg, ok := g.(graph.Weighter); ok {
+		l.weight = wg.Weight
+	} else {
+		l.weight = path.UniformCost(g)
+	}
+	if l.heuristic == nil {
+		if g, ok := g.(path.HeuristicCoster); ok {
+			l.heuristic = g.HeuristicCost
+		} else {
+			l.heuristic = path.NullHeuristic
+		}
+	}
+
+	l.queue.insert(l.s, key{l.heuristic(s, t), 0})
+
+	for _, n := range g.Nodes() {
+		switch n.ID() {
+		case l.s.ID():
+			l.model.AddNode(l.s)
+		case l.t.ID():
+			l.model.AddNode(l.t)
+		default:
+			l.model.AddNode(newDStarLiteNode(n))
+		}
+	}
+	for _, u := range l.model.Nodes() {
+		for _, v := range g.From(u) {
+			w := edgeWeight(l.weight, u, v)
+			if w < 0 {
+				panic("LPA*: negative edge weight")
+			}
+			l.model.SetEdge(simple.Edge{F: u, T: l.model.Node(v.ID()), W: w})
+		}
+	}
+
+	l.findShortestPath()
+
+	return l
+}
+
+// keyFor is the CalculateKey procedure in the LPA* paper.
+func (l *LPAStar) keyFor(s *dStarLiteNode) key {
+	/*
+	   procedure CalculateKey(s)
+	   {01'} return [min(g(s), rhs(s)) + h(s); min(g(s), rhs(s))];
+	*/
+	k := key{1: math.Min(s.g, s.rhs)}
+	k[0] = k[1] + l.heuristic(s.Node, l.t.Node)
+	return k
+}
+
+// update is the UpdateVertex procedure in the LPA* paper.
+func (l *LPAStar) update(u *dStarLiteNode) {
+	/*
+	   procedure UpdateVertex(u)
+	   {07'} if (u != s_start) rhs(u) = min s'∈Pred(u)(g(s') + c(s', u));
+	   {08'} if (u ∈ U) U.Remove(u);
+	   {09'} if (g(u) != rhs(u)) U.Insert(u, CalculateKey(u));
+	*/
+	if u.ID() != l.s.ID() {
+		u.rhs = math.Inf(1)
+		for _, _p := range l.model.To(u) {
+			p := _p.(*dStarLiteNode)
+			u.rhs = math.Min(u.rhs, p.g+edgeWeight(l.model.Weight, p, u))
+		}
+	}
+	inQueue := u.inQueue()
+	switch {
+	case inQueue && u.g != u.rhs:
+		l.queue.update(u, l.keyFor(u))
+	case !inQueue && u.g != u.rhs:
+		l.queue.insert(u, l.keyFor(u))
+	case inQueue && u.g == u.rhs:
+		l.queue.remove(u)
+	}
+}
+
+// findShortestPath is the ComputeShortestPath procedure in the LPA* paper.
+func (l *LPAStar) findShortestPath() {
+	/*
+	   procedure ComputeShortestPath()
+	   {10'} while (U.TopKey() < CalculateKey(s_goal) OR rhs(s_goal) != g(s_goal))
+	   {11'}   u = U.Pop();
+	   {12'}   if (g(u) > rhs(u))
+	   {13'}     g(u) = rhs(u);
+	   {14'}     for all s ∈ Succ(u) UpdateVertex(s);
+	   {15'}   else
+	   {16'}     g(u) = ∞;
+	   {17'}     for all s ∈ Succ(u) ∪ {u} UpdateVertex(s);
+	*/
+	for l.queue.Len() != 0 { // We use l.queue.Len since l.queue does not return an infinite key when empty.
+		u := l.queue.top()
+		if !u.key.less(l.keyFor(l.t)) && l.t.rhs == l.t.g {
+			break
+		}
+		l.queue.remove(u)
+		if u.g > u.rhs {
+			u.g = u.rhs
+			for _, s := range l.model.From(u) {
+				l.update(s.(*dStarLiteNode))
+			}
+		} else {
+			u.g = math.Inf(1)
+			for _, s := range append(l.model.From(u), u) {
+				l.update(s.(*dStarLiteNode))
+			}
+		}
+	}
+}
+
+// UpdateWorld updates or adds edges in the world graph and re-plans the path.
+// The new weights of the changed edges are obtained from the graph passed to
+// NewLPAStar. UpdateWorld will panic if changes include a negative edge weight.
+func (l *LPAStar) UpdateWorld(changes []graph.Edge) {
+	/*
+	   procedure Main()
+	   {21'}   Wait for changes in edge costs;
+	   {22'}   for all directed edges (u, v) with changed edge costs
+	   {23'}     Update the edge cost c(u, v);
+	   {24'}     UpdateVertex(v);
+	*/
+	if len(changes) == 0 {
+		return
+	}
+	for _, e := range changes {
+		from := e.From()
+		to := e.To()
+		c, _ := l.weight(from, to)
+		if c < 0 {
+			panic("LPA*: negative edge weight")
+		}
+		u := worldNodeFor(l.model, from)
+		v := worldNodeFor(l.model, to)
+		l.model.SetEdge(simple.Edge{F: u, T: v, W: c})
+		l.update(v)
+	}
+	l.findShortestPath()
+}
+
+// Path returns the path from the start to the goal and the weight of the path.
+func (l *LPAStar) Path() (p []graph.Node, weight float64) {
+	if math.IsInf(l.t.g, 1) {
+		return nil, math.Inf(1)
+	}
+
+	// The path is constructed backwards from the goal
+	// by following the predecessors minimising g(s')+c(s', u).
+	u := l.t
+	p = []graph.Node{u.Node}
+	seen := map[int]bool{u.ID(): true}
+	for u.ID() != l.s.ID() {
+		min := math.Inf(1)
+		var next *dStarLiteNode
+		for _, _v := range l.model.To(u) {
+			v := _v.(*dStarLiteNode)
+			if g := v.g + edgeWeight(l.model.Weight, v, u); g < min {
+				next = v
+				min = g
+			}
+		}
+		if next == nil || seen[next.ID()] {
+			return nil, math.NaN()
+		}
+		seen[next.ID()] = true
+		u = next
+		p = append(p, u.Node)
+	}
+	for i, j := 0, len(p)-1; i < j; i, j = i+1, j-1 {
+		p[i], p[j] = p[j], p[i]
+	}
+	return p, l.t.g
+}
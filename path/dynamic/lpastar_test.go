@@ -0,0 +1,147 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dynamic
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/path"
+	"github.com/gonum/graph/path/internal/testgraphs"
+	"github.com/gonum/graph/simple"
+)
+
+func TestLPAStarNullHeuristic(t *testing.T) {
+	for _, test := range testgraphs.ShortestPathTests {
+		// Skip zero-weight cycles.
+		if strings.HasPrefix(test.Name, "zero-weight") {
+			continue
+		}
+
+		g := test.Graph()
+		for _, e := range test.Edges {
+			g.SetEdge(e)
+		}
+
+		var (
+			l *LPAStar
+
+			panicked bool
+		)
+		func() {
+			defer func() {
+				panicked = recover() != nil
+			}()
+			l = NewLPAStar(test.Query.From(), test.Query.To(), g.(graph.Graph), path.NullHeuristic, simple.NewDirectedGraph(0, math.Inf(1)))
+		}()
+		if panicked || test.HasNegativeWeight {
+			if !test.HasNegativeWeight {
+				t.Errorf("%q: unexpected panic", test.Name)
+			}
+			if !panicked {
+				t.Errorf("%q: expected panic for negative edge weight", test.Name)
+			}
+			continue
+		}
+
+		p, weight := l.Path()
+
+		if !math.IsInf(weight, 1) && p[0].ID() != test.Query.From().ID() {
+			t.Fatalf("%q: unexpected from node ID: got:%d want:%d", test.Name, p[0].ID(), test.Query.From().ID())
+		}
+		if weight != test.Weight {
+			t.Errorf("%q: unexpected weight from Path: got:%f want:%f",
+				test.Name, weight, test.Weight)
+		}
+
+		var got []int
+		for _, n := range p {
+			got = append(got, n.ID())
+		}
+		ok := len(got) == 0 && len(test.WantPaths) == 0
+		for _, sp := range test.WantPaths {
+			if reflect.DeepEqual(got, sp) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			t.Errorf("%q: unexpected shortest path:\ngot: %v\nwant from:%v",
+				test.Name, p, test.WantPaths)
+		}
+	}
+}
+
+func TestLPAStarDynamic(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		const n = 30
+		g := simple.NewDirectedGraph(0, math.Inf(1))
+		for u := 0; u < n; u++ {
+			g.AddNode(simple.Node(u))
+		}
+		for j := 0; j < 4*n; j++ {
+			u, v := rnd.Intn(n), rnd.Intn(n)
+			if u == v {
+				continue
+			}
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v), W: float64(1 + rnd.Intn(10))})
+		}
+
+		s, tn := simple.Node(0), simple.Node(n-1)
+		l := NewLPAStar(s, tn, g, nil, simple.NewDirectedGraph(0, math.Inf(1)))
+		checkDynamicPath(t, i, 0, g, s, tn, l.Path)
+
+		for step := 1; step <= 10; step++ {
+			var changes []graph.Edge
+			edges := g.Edges()
+			for j := 0; j < 5; j++ {
+				if rnd.Intn(3) == 0 && len(edges) != 0 {
+					// Remove an existing edge.
+					e := edges[rnd.Intn(len(edges))]
+					g.RemoveEdge(e)
+					changes = append(changes, e)
+					continue
+				}
+				u, v := rnd.Intn(n), rnd.Intn(n)
+				if u == v {
+					continue
+				}
+				e := simple.Edge{F: simple.Node(u), T: simple.Node(v), W: float64(1 + rnd.Intn(10))}
+				g.SetEdge(e)
+				changes = append(changes, e)
+			}
+			l.UpdateWorld(changes)
+			checkDynamicPath(t, i, step, g, s, tn, l.Path)
+		}
+	}
+}
+
+// checkDynamicPath checks that the path returned by pathFn is a shortest
+// path from s to t in g.
+func checkDynamicPath(t *testing.T, test, step int, g weightedGraph, s, tn graph.Node, pathFn func() ([]graph.Node, float64)) {
+	want := path.DijkstraFrom(s, g).WeightTo(tn)
+	p, weight := pathFn()
+	if weight != want {
+		t.Errorf("unexpected path weight for test %d step %d: got:%v want:%v", test, step, weight, want)
+		return
+	}
+	if math.IsInf(want, 1) {
+		if p != nil {
+			t.Errorf("unexpected path for test %d step %d: got:%v", test, step, p)
+		}
+		return
+	}
+	if p[0].ID() != s.ID() || p[len(p)-1].ID() != tn.ID() {
+		t.Errorf("unexpected path end points for test %d step %d: %v", test, step, p)
+	}
+	if w := weightOf(p, g); w != want {
+		t.Errorf("unexpected weight of returned path for test %d step %d: got:%v want:%v", test, step, w, want)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"github.com/gonum/graph"
+)
+
+// Grid is a graph of nodes laid out on a 2D square grid, such as a grid.Grid.
+// Nodes are located at the centres of unit square cells.
+type Grid interface {
+	graph.Graph
+
+	// NodeAt returns the node at (r, c) or nil
+	// if (r, c) is outside the grid.
+	NodeAt(r, c int) graph.Node
+
+	// RowCol returns the row and column
+	// of the node with the given ID.
+	RowCol(id int) (r, c int)
+
+	// HasOpen returns whether n is an
+	// open node in the grid.
+	HasOpen(n graph.Node) bool
+}
+
+// isOpen returns whether the grid node at (r, c) exists and is open.
+func isOpen(g Grid, r, c int) bool {
+	n := g.NodeAt(r, c)
+	return n != nil && g.HasOpen(n)
+}
+
+// octile returns the octile distance between the grid nodes with IDs
+// uid and vid. This is the length of the shortest path between the nodes
+// on an open 8-connected grid with unit orthogonal and √2 diagonal steps.
+func octile(g Grid, uid, vid int) float64 {
+	ur, uc := g.RowCol(uid)
+	vr, vc := g.RowCol(vid)
+	dr := math.Abs(float64(ur - vr))
+	dc := math.Abs(float64(uc - vc))
+	return math.Max(dr, dc) + (math.Sqrt2-1)*math.Min(dr, dc)
+}
+
+// euclidean returns the straight line distance between the grid nodes
+// with IDs uid and vid.
+func euclidean(g Grid, uid, vid int) float64 {
+	ur, uc := g.RowCol(uid)
+	vr, vc := g.RowCol(vid)
+	return math.Hypot(float64(ur-vr), float64(uc-vc))
+}
+
+// lineOfSight returns whether the straight line between the centres of the
+// grid nodes with IDs uid and vid passes only through open cells. Where the
+// line passes exactly through the corner shared by four cells, the line is
+// only considered to be unobstructed there if the grid holds an edge
+// between the two diagonally adjacent cells on the line.
+func lineOfSight(g Grid, uid, vid int) bool {
+	r, c := g.RowCol(uid)
+	vr, vc := g.RowCol(vid)
+	dr, sr := absSign(vr - r)
+	dc, sc := absSign(vc - c)
+
+	// The line is traversed cell by cell. Boundary i between
+	// columns is crossed at parameter (2i+1)/2dc along the line
+	// and boundary j between rows is crossed at (2j+1)/2dr, so
+	// comparing (2i+1)dr and (2j+1)dc determines which boundary
+	// is crossed next without loss of precision.
+	if !isOpen(g, r, c) {
+		return false
+	}
+	for i, j := 0, 0; i < dc || j < dr; {
+		var cmp int
+		switch {
+		case j == dr:
+			cmp = -1
+		case i == dc:
+			cmp = 1
+		default:
+			cmp = (2*i+1)*dr - (2*j+1)*dc
+		}
+		switch {
+		case cmp < 0:
+			c += sc
+			i++
+		case cmp > 0:
+			r += sr
+			j++
+		default:
+			if g.Edge(g.NodeAt(r, c), g.NodeAt(r+sr, c+sc)) == nil {
+				return false
+			}
+			r += sr
+			c += sc
+			i++
+			j++
+		}
+		if !isOpen(g, r, c) {
+			return false
+		}
+	}
+	return true
+}
+
+// absSign returns the absolute value and sign of i.
+func absSign(i int) (abs, sign int) {
+	switch {
+	case i < 0:
+		return -i, -1
+	case i > 0:
+		return i, 1
+	default:
+		return 0, 0
+	}
+}
@@ -48,7 +48,7 @@ func JohnsonAllPaths(g graph.Graph) (paths AllShortest, ok bool) {
 	}
 
 	jg.bellmanFord = false
-	dijkstraAllPaths(jg, paths)
+	dijkstraAllPaths(jg, paths, nil)
 
 	for i, u := range paths.nodes {
 		hu := jg.adjustBy.WeightTo(u)
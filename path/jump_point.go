@@ -0,0 +1,203 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/set"
+)
+
+// JumpPointSearch finds the shortest path from s to t in the uniform-cost
+// 8-connected grid g using Jump Point Search. The grid must allow diagonal
+// moves between any pair of open cells, including past closed corners, and
+// must have orthogonal steps of unit cost and diagonal steps of cost √2, as
+// is the case for a grid.Grid with AllowDiagonal set and UnitEdgeWeight unset.
+// Edge weights reported by g are not consulted.
+//
+// The path and its cost are returned in a Shortest with every grid node along
+// the path included, so the path may be compared directly with paths returned
+// by AStar. Unlike AStar, the returned Shortest only holds the path to t. The
+// number of expanded jump points is also returned.
+//
+// Jump Point Search is described in Harabor and Grastien "Online Graph Pruning
+// for Pathfinding on Grid Maps" AAAI 2011.
+func JumpPointSearch(s, t graph.Node, g Grid) (path Shortest, expanded int) {
+	if !g.HasOpen(s) || !g.HasOpen(t) {
+		return Shortest{from: s}, 0
+	}
+	j := jumper{g: g, tid: t.ID()}
+	parent := map[int]int{s.ID(): s.ID()}
+
+	visited := make(set.Ints)
+	open := &aStarQueue{indexOf: make(map[int]int)}
+	heap.Push(open, aStarNode{node: s, gscore: 0, fscore: octile(g, s.ID(), j.tid)})
+
+	found := false
+	for open.Len() != 0 {
+		u := heap.Pop(open).(aStarNode)
+		uid := u.node.ID()
+		expanded++
+
+		if uid == j.tid {
+			found = true
+			break
+		}
+
+		visited.Add(uid)
+		r, c := g.RowCol(uid)
+		for _, d := range j.successorDirections(r, c, parent[uid]) {
+			v := j.jump(r+d[0], c+d[1], d[0], d[1])
+			if v == nil {
+				continue
+			}
+			vid := v.ID()
+			if visited.Has(vid) {
+				continue
+			}
+
+			gv := u.gscore + octile(g, uid, vid)
+			if n, ok := open.node(vid); !ok {
+				parent[vid] = uid
+				heap.Push(open, aStarNode{node: v, gscore: gv, fscore: gv + octile(g, vid, j.tid)})
+			} else if gv < n.gscore {
+				parent[vid] = uid
+				open.update(vid, gv, gv+octile(g, vid, j.tid))
+			}
+		}
+	}
+
+	path = newShortestFrom(s, g.Nodes())
+	if !found {
+		return path, expanded
+	}
+
+	// Collect the jump points on the path and then
+	// fill in the grid nodes between each pair.
+	jumps := []int{j.tid}
+	for id := j.tid; id != s.ID(); {
+		id = parent[id]
+		jumps = append(jumps, id)
+	}
+	var dist float64
+	prev := path.indexOf[s.ID()]
+	for i := len(jumps) - 1; i > 0; i-- {
+		r, c := g.RowCol(jumps[i])
+		tr, tc := g.RowCol(jumps[i-1])
+		_, dr := absSign(tr - r)
+		_, dc := absSign(tc - c)
+		step := 1.0
+		if dr != 0 && dc != 0 {
+			step = math.Sqrt2
+		}
+		for r != tr || c != tc {
+			r += dr
+			c += dc
+			dist += step
+			k := path.indexOf[g.NodeAt(r, c).ID()]
+			path.set(k, dist, prev)
+			prev = k
+		}
+	}
+
+	return path, expanded
+}
+
+// jumper implements the jump point identification
+// and neighbour pruning rules of Jump Point Search.
+type jumper struct {
+	g   Grid
+	tid int
+}
+
+func (j jumper) open(r, c int) bool { return isOpen(j.g, r, c) }
+
+// successorDirections returns the directions that must be searched from
+// the node at (r, c) when it was reached from the node with ID from.
+func (j jumper) successorDirections(r, c int, from int) [][2]int {
+	pr, pc := j.g.RowCol(from)
+	_, dr := absSign(r - pr)
+	_, dc := absSign(c - pc)
+
+	var dirs [][2]int
+	switch {
+	case dr == 0 && dc == 0:
+		// The start node has all its
+		// neighbours as successors.
+		for dr := -1; dr <= 1; dr++ {
+			for dc := -1; dc <= 1; dc++ {
+				if dr != 0 || dc != 0 {
+					dirs = append(dirs, [2]int{dr, dc})
+				}
+			}
+		}
+	case dr != 0 && dc != 0:
+		dirs = append(dirs, [2]int{dr, 0}, [2]int{0, dc}, [2]int{dr, dc})
+		if !j.open(r, c-dc) {
+			dirs = append(dirs, [2]int{dr, -dc})
+		}
+		if !j.open(r-dr, c) {
+			dirs = append(dirs, [2]int{-dr, dc})
+		}
+	case dr != 0:
+		dirs = append(dirs, [2]int{dr, 0})
+		if !j.open(r, c+1) {
+			dirs = append(dirs, [2]int{dr, 1})
+		}
+		if !j.open(r, c-1) {
+			dirs = append(dirs, [2]int{dr, -1})
+		}
+	default:
+		dirs = append(dirs, [2]int{0, dc})
+		if !j.open(r+1, c) {
+			dirs = append(dirs, [2]int{1, dc})
+		}
+		if !j.open(r-1, c) {
+			dirs = append(dirs, [2]int{-1, dc})
+		}
+	}
+	return dirs
+}
+
+// jump scans from (r, c) in the direction (dr, dc) and returns the first
+// jump point found, or nil if the scan is blocked before a jump point is
+// found.
+func (j jumper) jump(r, c, dr, dc int) graph.Node {
+	for {
+		if !j.open(r, c) {
+			return nil
+		}
+		n := j.g.NodeAt(r, c)
+		if n.ID() == j.tid {
+			return n
+		}
+
+		// Check for forced neighbours.
+		switch {
+		case dr != 0 && dc != 0:
+			if (j.open(r-dr, c+dc) && !j.open(r-dr, c)) || (j.open(r+dr, c-dc) && !j.open(r, c-dc)) {
+				return n
+			}
+			// A diagonal scan stops at nodes from which
+			// an orthogonal scan finds a jump point.
+			if j.jump(r+dr, c, dr, 0) != nil || j.jump(r, c+dc, 0, dc) != nil {
+				return n
+			}
+		case dr != 0:
+			if (j.open(r+dr, c+1) && !j.open(r, c+1)) || (j.open(r+dr, c-1) && !j.open(r, c-1)) {
+				return n
+			}
+		default:
+			if (j.open(r+1, c+dc) && !j.open(r+1, c)) || (j.open(r-1, c+dc) && !j.open(r-1, c)) {
+				return n
+			}
+		}
+
+		r += dr
+		c += dc
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/floats"
+	"github.com/gonum/graph/graphs/grid"
+	"github.com/gonum/graph/simple"
+	"github.com/gonum/graph/topo"
+)
+
+var jumpPointSearchTests = []struct {
+	name string
+	g    *grid.Grid
+
+	s, t     int
+	wantCost float64
+}{
+	{
+		name: "open",
+		g:    grid.NewGrid(5, 8, true),
+		s:    0, t: 4*8 + 7,
+		wantCost: 4*math.Sqrt2 + 3,
+	},
+	{
+		name: "wall with gap",
+		g: grid.NewGridFrom(
+			"........",
+			"........",
+			"******.*",
+			"........",
+			"........",
+		),
+		s: 0, t: 4 * 8,
+		wantCost: 4*math.Sqrt2 + 8,
+	},
+	{
+		name: "corridor",
+		g: grid.NewGridFrom(
+			"*.******",
+			"*......*",
+			"******.*",
+		),
+		s: 1, t: 2*8 + 6,
+		wantCost: 3 + 2*math.Sqrt2,
+	},
+	{
+		name: "no path",
+		g: grid.NewGridFrom(
+			"...*...",
+			"...*...",
+			"...*...",
+		),
+		s: 0, t: 6,
+		wantCost: math.Inf(1),
+	},
+	{
+		name: "same node",
+		g:    grid.NewGrid(3, 3, true),
+		s:    4, t: 4,
+		wantCost: 0,
+	},
+}
+
+func TestJumpPointSearch(t *testing.T) {
+	for _, test := range jumpPointSearchTests {
+		test.g.AllowDiagonal = true
+
+		pt, _ := JumpPointSearch(simple.Node(test.s), simple.Node(test.t), test.g)
+		p, cost := pt.To(simple.Node(test.t))
+		if !floats.EqualWithinAbsOrRel(cost, test.wantCost, 1e-12, 1e-12) && cost != test.wantCost {
+			t.Errorf("unexpected cost for %q: got:%v want:%v", test.name, cost, test.wantCost)
+		}
+		if math.IsInf(test.wantCost, 1) {
+			if p != nil {
+				t.Errorf("unexpected path for %q: got:%v", test.name, p)
+			}
+			continue
+		}
+		if !topo.IsPathIn(test.g, p) {
+			t.Errorf("got path that is not path in input graph for %q: %v", test.name, p)
+		}
+		if p[0].ID() != test.s || p[len(p)-1].ID() != test.t {
+			t.Errorf("unexpected path end points for %q: %v", test.name, p)
+		}
+	}
+}
+
+func TestJumpPointSearchRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		const rows, cols = 20, 30
+		g := grid.NewGrid(rows, cols, true)
+		g.AllowDiagonal = true
+		for r := 0; r < rows; r++ {
+			for c := 0; c < cols; c++ {
+				if rnd.Float64() < 0.3 {
+					g.Set(r, c, false)
+				}
+			}
+		}
+		s := simple.Node(rnd.Intn(rows * cols))
+		tn := simple.Node(rnd.Intn(rows * cols))
+		g.Set(s.ID()/cols, s.ID()%cols, true)
+		g.Set(tn.ID()/cols, tn.ID()%cols, true)
+
+		pt, _ := JumpPointSearch(s, tn, g)
+		p, cost := pt.To(tn)
+
+		apt, _ := AStar(s, tn, g, nil)
+		ap, aCost := apt.To(tn)
+
+		if (p == nil) != (ap == nil) {
+			t.Fatalf("unexpected path existence for test %d: got:%v want:%v", i, p, ap)
+		}
+		if p == nil {
+			continue
+		}
+		if !floats.EqualWithinAbsOrRel(cost, aCost, 1e-10, 1e-10) {
+			t.Errorf("unexpected cost for test %d: got:%v want:%v", i, cost, aCost)
+		}
+		if !topo.IsPathIn(g, p) {
+			t.Errorf("got path that is not path in input graph for test %d: %v", i, p)
+		}
+		var sum float64
+		for j := 1; j < len(p); j++ {
+			w, _ := g.Weight(p[j-1], p[j])
+			sum += w
+		}
+		if !floats.EqualWithinAbsOrRel(sum, cost, 1e-10, 1e-10) {
+			t.Errorf("path weight does not match cost for test %d: got:%v want:%v", i, sum, cost)
+		}
+	}
+}
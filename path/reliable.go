@@ -0,0 +1,68 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"container/heap"
+
+	"github.com/gonum/graph"
+)
+
+// MostReliableFrom returns a most-reliable-path tree for a most reliable path from
+// u to all nodes in the graph g. Edge weights are interpreted as the probability
+// that the edge may be traversed successfully and the reliability of a path is the
+// product of its edge probabilities, so the path found from u to a node maximises
+// that product. If the graph does not implement graph.Weighter, UniformCost is used.
+//
+// The weight of the path from u to itself is 1 and the weight of paths to nodes
+// that are not reachable from u is zero. Edges with zero probability are not
+// traversed. MostReliableFrom will panic if g has a u-reachable edge weight
+// outside the interval [0, 1].
+//
+// The time complexity of MostReliableFrom is O(|E|.log|V|).
+func MostReliableFrom(u graph.Node, g graph.Graph) Shortest {
+	if !g.Has(u) {
+		return Shortest{from: u, maximise: true}
+	}
+	var weight Weighting
+	if wg, ok := g.(graph.Weighter); ok {
+		weight = wg.Weight
+	} else {
+		weight = UniformCost(g)
+	}
+
+	nodes := g.Nodes()
+	path := newMaximalShortestFrom(u, nodes, 1)
+
+	// The most reliable path algorithm is Dijkstra's algorithm
+	// with the sum of path weights replaced by their product.
+	// This is correct since edge probabilities are no greater
+	// than 1, so extending a path never increases its weight.
+	Q := maxPriorityQueue{priorityQueue{{node: u, dist: 1}}}
+	for Q.Len() != 0 {
+		mid := heap.Pop(&Q).(distanceNode)
+		k := path.indexOf[mid.node.ID()]
+		if mid.dist < path.dist[k] {
+			continue
+		}
+		for _, v := range g.From(mid.node) {
+			j := path.indexOf[v.ID()]
+			w, ok := weight(mid.node, v)
+			if !ok {
+				panic("reliable: unexpected invalid weight")
+			}
+			if w < 0 || w > 1 {
+				panic("reliable: edge weight is not a probability")
+			}
+			joint := path.dist[k] * w
+			if joint > path.dist[j] {
+				heap.Push(&Q, distanceNode{node: v, dist: joint})
+				path.set(j, joint, k)
+			}
+		}
+	}
+
+	return path
+}
@@ -0,0 +1,134 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/floats"
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+var mostReliablePathTests = []struct {
+	name  string
+	g     func() graph.EdgeSetter
+	edges []simple.Edge
+
+	from, to        graph.Node
+	wantReliability float64
+	wantPath        []int
+}{
+	{
+		name:            "empty",
+		g:               func() graph.EdgeSetter { return simple.NewDirectedGraph(0, math.Inf(1)) },
+		from:            simple.Node(0),
+		to:              simple.Node(1),
+		wantReliability: 0,
+	},
+	{
+		name: "self",
+		g:    func() graph.EdgeSetter { return simple.NewDirectedGraph(0, math.Inf(1)) },
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1), W: 0.5},
+		},
+		from:            simple.Node(0),
+		to:              simple.Node(0),
+		wantReliability: 1,
+		wantPath:        []int{0},
+	},
+	{
+		name: "longer more reliable directed",
+		g:    func() graph.EdgeSetter { return simple.NewDirectedGraph(0, math.Inf(1)) },
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(3), W: 0.5},
+
+			{F: simple.Node(0), T: simple.Node(1), W: 0.9},
+			{F: simple.Node(1), T: simple.Node(2), W: 0.9},
+			{F: simple.Node(2), T: simple.Node(3), W: 0.9},
+		},
+		from:            simple.Node(0),
+		to:              simple.Node(3),
+		wantReliability: 0.9 * 0.9 * 0.9,
+		wantPath:        []int{0, 1, 2, 3},
+	},
+	{
+		name: "shorter more reliable undirected",
+		g:    func() graph.EdgeSetter { return simple.NewUndirectedGraph(0, math.Inf(1)) },
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(3), W: 0.8},
+
+			{F: simple.Node(0), T: simple.Node(1), W: 0.9},
+			{F: simple.Node(1), T: simple.Node(2), W: 0.9},
+			{F: simple.Node(2), T: simple.Node(3), W: 0.9},
+		},
+		from:            simple.Node(3),
+		to:              simple.Node(0),
+		wantReliability: 0.8,
+		wantPath:        []int{3, 0},
+	},
+	{
+		name: "zero probability",
+		g:    func() graph.EdgeSetter { return simple.NewDirectedGraph(0, math.Inf(1)) },
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1), W: 0.5},
+			{F: simple.Node(1), T: simple.Node(2), W: 0},
+		},
+		from:            simple.Node(0),
+		to:              simple.Node(2),
+		wantReliability: 0,
+	},
+}
+
+func TestMostReliableFrom(t *testing.T) {
+	const tol = 1e-12
+	for _, test := range mostReliablePathTests {
+		g := test.g()
+		for _, e := range test.edges {
+			g.SetEdge(e)
+		}
+
+		pt := MostReliableFrom(test.from, g.(graph.Graph))
+		if pt.From().ID() != test.from.ID() {
+			t.Fatalf("%q: unexpected from node ID: got:%d want:%d", test.name, pt.From().ID(), test.from.ID())
+		}
+
+		p, r := pt.To(test.to)
+		if !floats.EqualWithinAbsOrRel(r, test.wantReliability, tol, tol) {
+			t.Errorf("%q: unexpected reliability from To: got:%v want:%v", test.name, r, test.wantReliability)
+		}
+		if r := pt.WeightTo(test.to); !floats.EqualWithinAbsOrRel(r, test.wantReliability, tol, tol) {
+			t.Errorf("%q: unexpected reliability from WeightTo: got:%v want:%v", test.name, r, test.wantReliability)
+		}
+
+		var got []int
+		for _, n := range p {
+			got = append(got, n.ID())
+		}
+		if !reflect.DeepEqual(got, test.wantPath) {
+			t.Errorf("%q: unexpected most reliable path: got:%v want:%v", test.name, got, test.wantPath)
+		}
+	}
+}
+
+func TestMostReliableFromBadProbability(t *testing.T) {
+	for _, w := range []float64{-0.5, 1.5} {
+		g := simple.NewDirectedGraph(0, math.Inf(1))
+		g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1), W: w})
+
+		var panicked bool
+		func() {
+			defer func() {
+				panicked = recover() != nil
+			}()
+			MostReliableFrom(simple.Node(0), g)
+		}()
+		if !panicked {
+			t.Errorf("expected panic for edge weight %v", w)
+		}
+	}
+}
@@ -12,8 +12,8 @@ import (
 	"github.com/gonum/matrix/mat64"
 )
 
-// Shortest is a shortest-path tree created by the BellmanFordFrom or DijkstraFrom
-// single-source shortest path functions.
+// Shortest is a shortest-path tree created by the BellmanFordFrom, DijkstraFrom,
+// WidestFrom or MostReliableFrom single-source path functions.
 type Shortest struct {
 	// from holds the source node given to
 	// DijkstraFrom.
@@ -42,6 +42,14 @@ type Shortest struct {
 	// tree of the graph. The index is a
 	// linear mapping of to-dense-id.
 	next []int
+
+	// maximise indicates that the
+	// path weights held in dist are
+	// maximised rather than minimised,
+	// so nodes that cannot be reached
+	// have a weight of zero rather
+	// than +Inf.
+	maximise bool
 }
 
 func newShortestFrom(u graph.Node, nodes []graph.Node) Shortest {
@@ -72,28 +80,52 @@ func newShortestFrom(u graph.Node, nodes []graph.Node) Shortest {
 	return p
 }
 
+// newMaximalShortestFrom returns a Shortest for a path search that maximises
+// path weights. The weight of the path from u to itself is set to self and
+// all other nodes are initially unreachable with a weight of zero.
+func newMaximalShortestFrom(u graph.Node, nodes []graph.Node, self float64) Shortest {
+	p := newShortestFrom(u, nodes)
+	p.maximise = true
+	for i := range p.dist {
+		p.dist[i] = 0
+	}
+	p.dist[p.indexOf[u.ID()]] = self
+	return p
+}
+
 func (p Shortest) set(to int, weight float64, mid int) {
 	p.dist[to] = weight
 	p.next[to] = mid
 }
 
+// noPath returns the weight of a path to an unreachable node.
+func (p Shortest) noPath() float64 {
+	if p.maximise {
+		return 0
+	}
+	return math.Inf(1)
+}
+
 // From returns the starting node of the paths held by the Shortest.
 func (p Shortest) From() graph.Node { return p.from }
 
-// WeightTo returns the weight of the minimum path to v.
+// WeightTo returns the weight of the optimal path to v. For paths found by
+// WidestFrom and MostReliableFrom the weight of a path to an unreachable node
+// is zero, otherwise it is +Inf.
 func (p Shortest) WeightTo(v graph.Node) float64 {
 	to, toOK := p.indexOf[v.ID()]
 	if !toOK {
-		return math.Inf(1)
+		return p.noPath()
 	}
 	return p.dist[to]
 }
 
-// To returns a shortest path to v and the weight of the path.
+// To returns an optimal path to v and the weight of the path. If v is not
+// reachable, To returns a nil path and the weight described by WeightTo.
 func (p Shortest) To(v graph.Node) (path []graph.Node, weight float64) {
 	to, toOK := p.indexOf[v.ID()]
-	if !toOK || math.IsInf(p.dist[to], 1) {
-		return nil, math.Inf(1)
+	if !toOK || p.dist[to] == p.noPath() {
+		return nil, p.noPath()
 	}
 	from := p.indexOf[p.from.ID()]
 	path = []graph.Node{p.nodes[to]}
@@ -0,0 +1,124 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/set"
+)
+
+// ThetaStar finds an any-angle path from s to t in the grid g using Theta*.
+// Paths are not restricted to edges of g; consecutive nodes in a returned
+// path are in line of sight of each other, but need not be adjacent. The cost
+// of a path is its Euclidean length between node centres. Successors of a node
+// are the nodes returned by g.From, and edge weights reported by g are not
+// consulted.
+//
+// The path and its cost are returned in a Shortest along with paths and costs
+// to all nodes explored during the search, as is the case for AStar. The number
+// of expanded nodes is also returned.
+//
+// Theta* is described in Nash, Daniel, Koenig and Felner "Theta*: Any-Angle
+// Path Planning on Grids" AAAI 2007.
+func ThetaStar(s, t graph.Node, g Grid) (path Shortest, expanded int) {
+	return thetaStar(s, t, g, false)
+}
+
+// LazyThetaStar finds an any-angle path from s to t in the grid g using Lazy
+// Theta*. LazyThetaStar returns paths with the same semantics as ThetaStar,
+// but defers line of sight checks until a node is expanded, performing fewer
+// checks at the expense of potentially longer paths.
+//
+// Lazy Theta* is described in Nash, Koenig and Tovey "Lazy Theta*: Any-Angle
+// Path Planning and Path Length Analysis in 3D" AAAI 2010.
+func LazyThetaStar(s, t graph.Node, g Grid) (path Shortest, expanded int) {
+	return thetaStar(s, t, g, true)
+}
+
+func thetaStar(s, t graph.Node, g Grid, lazy bool) (path Shortest, expanded int) {
+	if !g.HasOpen(s) || !g.HasOpen(t) {
+		return Shortest{from: s}, 0
+	}
+
+	path = newShortestFrom(s, g.Nodes())
+	tid := t.ID()
+
+	// parent returns the path.indexOf index of
+	// the parent of the node with index i.
+	parent := func(i int) int {
+		if path.next[i] < 0 {
+			// Only the start node has no parent.
+			return i
+		}
+		return path.next[i]
+	}
+
+	visited := make(set.Ints)
+	open := &aStarQueue{indexOf: make(map[int]int)}
+	heap.Push(open, aStarNode{node: s, gscore: 0, fscore: euclidean(g, s.ID(), tid)})
+
+	for open.Len() != 0 {
+		u := heap.Pop(open).(aStarNode)
+		uid := u.node.ID()
+		i := path.indexOf[uid]
+		expanded++
+
+		if lazy {
+			// Lazy Theta* assumes line of sight between a node
+			// and its parent's parent when the node is reached,
+			// so check now and repair the parent if needed.
+			pid := path.nodes[parent(i)].ID()
+			if !lineOfSight(g, pid, uid) {
+				path.dist[i] = math.Inf(1)
+				for _, v := range g.From(u.node) {
+					vid := v.ID()
+					if !visited.Has(vid) {
+						continue
+					}
+					k := path.indexOf[vid]
+					if d := path.dist[k] + euclidean(g, vid, uid); d < path.dist[i] {
+						path.set(i, d, k)
+					}
+				}
+			}
+		}
+
+		if uid == tid {
+			break
+		}
+
+		visited.Add(uid)
+		for _, v := range g.From(u.node) {
+			vid := v.ID()
+			if visited.Has(vid) {
+				continue
+			}
+			j := path.indexOf[vid]
+
+			// Attempt to connect v directly to the parent of u,
+			// falling back to the path through u.
+			k := parent(i)
+			pid := path.nodes[k].ID()
+			if !lazy && !lineOfSight(g, pid, vid) {
+				k = i
+				pid = uid
+			}
+			gv := path.dist[k] + euclidean(g, pid, vid)
+			if gv < path.dist[j] {
+				path.set(j, gv, k)
+				if _, ok := open.node(vid); !ok {
+					heap.Push(open, aStarNode{node: v, gscore: gv, fscore: gv + euclidean(g, vid, tid)})
+				} else {
+					open.update(vid, gv, gv+euclidean(g, vid, tid))
+				}
+			}
+		}
+	}
+
+	return path, expanded
+}
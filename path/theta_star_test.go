@@ -0,0 +1,155 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/floats"
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/graphs/grid"
+	"github.com/gonum/graph/simple"
+)
+
+var thetaStarTests = []struct {
+	name string
+	g    *grid.Grid
+
+	s, t     int
+	wantCost float64
+}{
+	{
+		name: "open",
+		g:    grid.NewGrid(5, 8, true),
+		s:    0, t: 4*8 + 7,
+		wantCost: math.Hypot(4, 7),
+	},
+	{
+		name: "no path",
+		g: grid.NewGridFrom(
+			"...*...",
+			"...*...",
+			"...*...",
+		),
+		s: 0, t: 6,
+		wantCost: math.Inf(1),
+	},
+}
+
+func TestThetaStar(t *testing.T) {
+	for _, lazy := range []bool{false, true} {
+		search := ThetaStar
+		if lazy {
+			search = LazyThetaStar
+		}
+		for _, test := range thetaStarTests {
+			test.g.AllowDiagonal = true
+
+			pt, _ := search(simple.Node(test.s), simple.Node(test.t), test.g)
+			p, cost := pt.To(simple.Node(test.t))
+			if !floats.EqualWithinAbsOrRel(cost, test.wantCost, 1e-12, 1e-12) && cost != test.wantCost {
+				t.Errorf("unexpected cost for %q lazy=%t: got:%v want:%v", test.name, lazy, cost, test.wantCost)
+			}
+			if math.IsInf(test.wantCost, 1) {
+				if p != nil {
+					t.Errorf("unexpected path for %q lazy=%t: got:%v", test.name, lazy, p)
+				}
+				continue
+			}
+			checkAnyAnglePath(t, test.name, test.g, p, cost)
+		}
+	}
+}
+
+func TestThetaStarRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		const rows, cols = 20, 30
+		g := grid.NewGrid(rows, cols, true)
+		g.AllowDiagonal = true
+		for r := 0; r < rows; r++ {
+			for c := 0; c < cols; c++ {
+				if rnd.Float64() < 0.2 {
+					g.Set(r, c, false)
+				}
+			}
+		}
+		s := simple.Node(rnd.Intn(rows * cols))
+		tn := simple.Node(rnd.Intn(rows * cols))
+		g.Set(s.ID()/cols, s.ID()%cols, true)
+		g.Set(tn.ID()/cols, tn.ID()%cols, true)
+
+		apt, _ := AStar(s, tn, g, nil)
+		ap, aCost := apt.To(tn)
+
+		for _, search := range []func(s, t graph.Node, g Grid) (Shortest, int){ThetaStar, LazyThetaStar} {
+			pt, _ := search(s, tn, g)
+			p, cost := pt.To(tn)
+			if (p == nil) != (ap == nil) {
+				t.Fatalf("unexpected path existence for test %d: got:%v want:%v", i, p, ap)
+			}
+			if p == nil {
+				continue
+			}
+			if cost > aCost+1e-10 {
+				t.Errorf("any-angle path longer than grid path for test %d: got:%v grid:%v", i, cost, aCost)
+			}
+			if straight := euclidean(g, s.ID(), tn.ID()); cost < straight-1e-10 {
+				t.Errorf("any-angle path shorter than straight line for test %d: got:%v straight:%v", i, cost, straight)
+			}
+			checkAnyAnglePath(t, "random", g, p, cost)
+		}
+	}
+}
+
+func checkAnyAnglePath(t *testing.T, name string, g Grid, p []graph.Node, cost float64) {
+	var sum float64
+	for j := 1; j < len(p); j++ {
+		if !lineOfSight(g, p[j-1].ID(), p[j].ID()) {
+			t.Errorf("no line of sight between %d and %d in path for %q: %v", p[j-1].ID(), p[j].ID(), name, p)
+		}
+		sum += euclidean(g, p[j-1].ID(), p[j].ID())
+	}
+	if !floats.EqualWithinAbsOrRel(sum, cost, 1e-10, 1e-10) {
+		t.Errorf("path length does not match cost for %q: got:%v want:%v", name, sum, cost)
+	}
+}
+
+func TestLineOfSight(t *testing.T) {
+	g := grid.NewGridFrom(
+		"......",
+		"..*...",
+		"......",
+		"......",
+	)
+	for _, test := range []struct {
+		from, to [2]int
+		diagonal bool
+		want     bool
+	}{
+		{from: [2]int{0, 0}, to: [2]int{0, 5}, want: true},
+		{from: [2]int{0, 0}, to: [2]int{3, 5}, want: false},
+		{from: [2]int{0, 0}, to: [2]int{3, 2}, want: true},
+		{from: [2]int{1, 0}, to: [2]int{1, 5}, want: false},
+		{from: [2]int{0, 3}, to: [2]int{2, 1}, want: false},
+		{from: [2]int{3, 3}, to: [2]int{0, 0}, diagonal: false, want: false},
+		{from: [2]int{3, 3}, to: [2]int{0, 0}, diagonal: true, want: true},
+		{from: [2]int{2, 2}, to: [2]int{0, 2}, want: false},
+	} {
+		g.AllowDiagonal = test.diagonal
+		u := g.NodeAt(test.from[0], test.from[1])
+		v := g.NodeAt(test.to[0], test.to[1])
+		if got := lineOfSight(g, u.ID(), v.ID()); got != test.want {
+			t.Errorf("unexpected line of sight between %v and %v with diagonal=%t: got:%t want:%t",
+				test.from, test.to, test.diagonal, got, test.want)
+		}
+		if got := lineOfSight(g, v.ID(), u.ID()); got != test.want {
+			t.Errorf("unexpected line of sight between %v and %v with diagonal=%t: got:%t want:%t",
+				test.to, test.from, test.diagonal, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/gonum/graph"
+)
+
+// WidestFrom returns a widest-path tree for a widest path from u to all nodes in
+// the graph g. The width of a path is the minimum edge weight along the path, so
+// edge weights are interpreted as capacities and the path found from u to a node
+// maximises the bottleneck capacity of the path. If the graph does not implement
+// graph.Weighter, UniformCost is used.
+//
+// The weight of the path from u to itself is +Inf and the weight of paths to nodes
+// that are not reachable from u is zero. Edges with zero capacity are not traversed.
+// WidestFrom will panic if g has a u-reachable negative edge weight.
+//
+// The time complexity of WidestFrom is O(|E|.log|V|).
+func WidestFrom(u graph.Node, g graph.Graph) Shortest {
+	if !g.Has(u) {
+		return Shortest{from: u, maximise: true}
+	}
+	var weight Weighting
+	if wg, ok := g.(graph.Weighter); ok {
+		weight = wg.Weight
+	} else {
+		weight = UniformCost(g)
+	}
+
+	nodes := g.Nodes()
+	path := newMaximalShortestFrom(u, nodes, math.Inf(1))
+
+	// The widest path algorithm is Dijkstra's algorithm with
+	// the sum of path weights replaced by their minimum and
+	// the priority queue ordered to pop the widest path first.
+	Q := maxPriorityQueue{priorityQueue{{node: u, dist: math.Inf(1)}}}
+	for Q.Len() != 0 {
+		mid := heap.Pop(&Q).(distanceNode)
+		k := path.indexOf[mid.node.ID()]
+		if mid.dist < path.dist[k] {
+			continue
+		}
+		for _, v := range g.From(mid.node) {
+			j := path.indexOf[v.ID()]
+			w, ok := weight(mid.node, v)
+			if !ok {
+				panic("widest: unexpected invalid weight")
+			}
+			if w < 0 {
+				panic("widest: negative edge weight")
+			}
+			joint := math.Min(path.dist[k], w)
+			if joint > path.dist[j] {
+				heap.Push(&Q, distanceNode{node: v, dist: joint})
+				path.set(j, joint, k)
+			}
+		}
+	}
+
+	return path
+}
+
+// maxPriorityQueue implements a no-dec priority queue that pops
+// the distanceNode with the largest dist first.
+type maxPriorityQueue struct{ priorityQueue }
+
+func (q maxPriorityQueue) Less(i, j int) bool {
+	return q.priorityQueue[i].dist > q.priorityQueue[j].dist
+}
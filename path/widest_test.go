@@ -0,0 +1,143 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+var widestPathTests = []struct {
+	name  string
+	g     func() graph.EdgeSetter
+	edges []simple.Edge
+
+	from, to  graph.Node
+	wantWidth float64
+	wantPath  []int
+}{
+	{
+		name:      "empty",
+		g:         func() graph.EdgeSetter { return simple.NewDirectedGraph(0, math.Inf(1)) },
+		from:      simple.Node(0),
+		to:        simple.Node(1),
+		wantWidth: 0,
+	},
+	{
+		name: "self",
+		g:    func() graph.EdgeSetter { return simple.NewDirectedGraph(0, math.Inf(1)) },
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1), W: 2},
+		},
+		from:      simple.Node(0),
+		to:        simple.Node(0),
+		wantWidth: math.Inf(1),
+		wantPath:  []int{0},
+	},
+	{
+		name: "bottleneck directed",
+		g:    func() graph.EdgeSetter { return simple.NewDirectedGraph(0, math.Inf(1)) },
+		edges: []simple.Edge{
+			// Short narrow path.
+			{F: simple.Node(0), T: simple.Node(1), W: 10},
+			{F: simple.Node(1), T: simple.Node(4), W: 1},
+
+			// Long wide path.
+			{F: simple.Node(0), T: simple.Node(2), W: 5},
+			{F: simple.Node(2), T: simple.Node(3), W: 8},
+			{F: simple.Node(3), T: simple.Node(4), W: 6},
+		},
+		from:      simple.Node(0),
+		to:        simple.Node(4),
+		wantWidth: 5,
+		wantPath:  []int{0, 2, 3, 4},
+	},
+	{
+		name: "bottleneck undirected",
+		g:    func() graph.EdgeSetter { return simple.NewUndirectedGraph(0, math.Inf(1)) },
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1), W: 3},
+			{F: simple.Node(1), T: simple.Node(2), W: 7},
+			{F: simple.Node(0), T: simple.Node(3), W: 4},
+			{F: simple.Node(3), T: simple.Node(2), W: 4},
+		},
+		from:      simple.Node(2),
+		to:        simple.Node(0),
+		wantWidth: 4,
+		wantPath:  []int{2, 3, 0},
+	},
+	{
+		name: "zero capacity",
+		g:    func() graph.EdgeSetter { return simple.NewDirectedGraph(0, math.Inf(1)) },
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1), W: 3},
+			{F: simple.Node(1), T: simple.Node(2), W: 0},
+		},
+		from:      simple.Node(0),
+		to:        simple.Node(2),
+		wantWidth: 0,
+	},
+	{
+		name: "unreachable",
+		g:    func() graph.EdgeSetter { return simple.NewDirectedGraph(0, math.Inf(1)) },
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1), W: 3},
+			{F: simple.Node(2), T: simple.Node(1), W: 3},
+		},
+		from:      simple.Node(0),
+		to:        simple.Node(2),
+		wantWidth: 0,
+	},
+}
+
+func TestWidestFrom(t *testing.T) {
+	for _, test := range widestPathTests {
+		g := test.g()
+		for _, e := range test.edges {
+			g.SetEdge(e)
+		}
+
+		pt := WidestFrom(test.from, g.(graph.Graph))
+		if pt.From().ID() != test.from.ID() {
+			t.Fatalf("%q: unexpected from node ID: got:%d want:%d", test.name, pt.From().ID(), test.from.ID())
+		}
+
+		p, width := pt.To(test.to)
+		if width != test.wantWidth {
+			t.Errorf("%q: unexpected width from To: got:%v want:%v", test.name, width, test.wantWidth)
+		}
+		if width := pt.WeightTo(test.to); width != test.wantWidth {
+			t.Errorf("%q: unexpected width from WeightTo: got:%v want:%v", test.name, width, test.wantWidth)
+		}
+
+		var got []int
+		for _, n := range p {
+			got = append(got, n.ID())
+		}
+		if !reflect.DeepEqual(got, test.wantPath) {
+			t.Errorf("%q: unexpected widest path: got:%v want:%v", test.name, got, test.wantPath)
+		}
+	}
+}
+
+func TestWidestFromNegativeWeight(t *testing.T) {
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1), W: -1})
+
+	var panicked bool
+	func() {
+		defer func() {
+			panicked = recover() != nil
+		}()
+		WidestFrom(simple.Node(0), g)
+	}()
+	if !panicked {
+		t.Error("expected panic for negative edge weight")
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"sort"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+)
+
+// IsBipartite returns whether the undirected graph g is bipartite. If g is
+// bipartite, parts holds a two-colouring of the nodes of g, each part ordered
+// by node ID with the lowest ID node of each connected component in parts[0],
+// and oddCycle is nil. Otherwise oddCycle holds the nodes of a cycle of g with
+// an odd number of edges, with its first node repeated at the end. A self loop
+// is an odd cycle.
+func IsBipartite(g graph.Undirected) (bipartite bool, parts [2][]graph.Node, oddCycle []graph.Node) {
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+	colour := make(map[int]int, len(nodes))
+	parent := make(map[int]graph.Node, len(nodes))
+	depth := make(map[int]int, len(nodes))
+	for _, root := range nodes {
+		if _, ok := colour[root.ID()]; ok {
+			continue
+		}
+		colour[root.ID()] = 0
+		queue := []graph.Node{root}
+		for len(queue) != 0 {
+			u := queue[0]
+			queue = queue[1:]
+			for _, v := range g.From(u) {
+				cv, ok := colour[v.ID()]
+				if !ok {
+					colour[v.ID()] = 1 - colour[u.ID()]
+					parent[v.ID()] = u
+					depth[v.ID()] = depth[u.ID()] + 1
+					queue = append(queue, v)
+					continue
+				}
+				if cv == colour[u.ID()] {
+					return false, [2][]graph.Node{}, oddCycleFrom(u, v, parent, depth)
+				}
+			}
+		}
+	}
+
+	for _, n := range nodes {
+		c := colour[n.ID()]
+		parts[c] = append(parts[c], n)
+	}
+	return true, parts, nil
+}
+
+// oddCycleFrom returns the cycle closed by the edge between u and v,
+// which have equal colour, in the breadth-first tree given by parent
+// and depth.
+func oddCycleFrom(u, v graph.Node, parent map[int]graph.Node, depth map[int]int) []graph.Node {
+	if u.ID() == v.ID() {
+		return []graph.Node{u, u}
+	}
+	var left, right []graph.Node
+	x, y := u, v
+	for x.ID() != y.ID() {
+		if depth[x.ID()] >= depth[y.ID()] {
+			left = append(left, x)
+			x = parent[x.ID()]
+		} else {
+			right = append(right, y)
+			y = parent[y.ID()]
+		}
+	}
+	cycle := append(left, x)
+	for i := len(right) - 1; i >= 0; i-- {
+		cycle = append(cycle, right[i])
+	}
+	return append(cycle, u)
+}
@@ -0,0 +1,143 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/graph"
+)
+
+var bipartiteTests = []struct {
+	name string
+	g    []intset
+	want bool
+
+	parts [2][]int
+}{
+	{name: "empty", g: nil, want: true, parts: [2][]int{{}, {}}},
+	{name: "isolated", g: []intset{0: nil, 1: nil}, want: true, parts: [2][]int{{0, 1}, {}}},
+	{name: "C4", g: colouringTests[3].g, want: true, parts: [2][]int{{0, 2}, {1, 3}}},
+	{name: "C5", g: colouringTests[2].g, want: false},
+	{name: "wheel", g: colouringTests[4].g, want: false},
+	{name: "Petersen", g: colouringTests[5].g, want: false},
+	{
+		name: "tree",
+		g: []intset{
+			0: linksTo(1, 2),
+			1: linksTo(3, 4),
+			2: linksTo(5),
+			5: linksTo(6),
+		},
+		want:  true,
+		parts: [2][]int{{0, 3, 4, 5}, {1, 2, 6}},
+	},
+	{
+		name: "two components",
+		g: []intset{
+			0: linksTo(1),
+			2: linksTo(3),
+			3: linksTo(4),
+		},
+		want:  true,
+		parts: [2][]int{{0, 2, 4}, {1, 3}},
+	},
+	{
+		name: "square with pendant triangle",
+		g: []intset{
+			0: linksTo(1, 3),
+			1: linksTo(2),
+			2: linksTo(3, 4),
+			4: linksTo(5, 6),
+			5: linksTo(6),
+		},
+		want: false,
+	},
+}
+
+func TestIsBipartite(t *testing.T) {
+	for _, test := range bipartiteTests {
+		g := undirectedFrom(test.g)
+		got, parts, cycle := IsBipartite(g)
+		if got != test.want {
+			t.Errorf("unexpected bipartite result for %q: got:%t want:%t", test.name, got, test.want)
+			continue
+		}
+		if got {
+			if cycle != nil {
+				t.Errorf("unexpected odd cycle for bipartite %q: %v", test.name, cycle)
+			}
+			gotParts := [2][]int{ids(parts[0]), ids(parts[1])}
+			if !reflect.DeepEqual(gotParts, test.parts) {
+				t.Errorf("unexpected parts for %q: got:%v want:%v", test.name, gotParts, test.parts)
+			}
+			checkBipartition(t, test.name, g, parts)
+			continue
+		}
+		checkOddCycle(t, test.name, g, cycle)
+	}
+}
+
+func TestIsBipartiteRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		g := undirectedFrom(gnpAdjacency(rnd, 12, 0.15))
+		ok, parts, cycle := IsBipartite(g)
+		k, _ := ExactColouring(g)
+		if ok != (k <= 2) {
+			t.Errorf("unexpected bipartite result for random graph %d: got:%t chromatic number:%d", i, ok, k)
+			continue
+		}
+		if ok {
+			checkBipartition(t, "random", g, parts)
+		} else {
+			checkOddCycle(t, "random", g, cycle)
+		}
+	}
+}
+
+func checkBipartition(t *testing.T, name string, g graph.Undirected, parts [2][]graph.Node) {
+	side := make(map[int]int)
+	for s, part := range parts {
+		for _, n := range part {
+			if _, ok := side[n.ID()]; ok {
+				t.Errorf("node %d in both parts for %q", n.ID(), name)
+			}
+			side[n.ID()] = s
+		}
+	}
+	if len(side) != len(g.Nodes()) {
+		t.Errorf("unexpected number of partitioned nodes for %q: got:%d want:%d", name, len(side), len(g.Nodes()))
+	}
+	for _, u := range g.Nodes() {
+		for _, v := range g.From(u) {
+			if side[u.ID()] == side[v.ID()] {
+				t.Errorf("edge %d--%d within a part for %q", u.ID(), v.ID(), name)
+			}
+		}
+	}
+}
+
+func checkOddCycle(t *testing.T, name string, g graph.Undirected, cycle []graph.Node) {
+	if len(cycle) < 4 || len(cycle)%2 != 0 {
+		t.Errorf("odd cycle for %q has unexpected length: %v", name, ids(cycle))
+		return
+	}
+	if cycle[0].ID() != cycle[len(cycle)-1].ID() {
+		t.Errorf("odd cycle for %q is not closed: %v", name, ids(cycle))
+	}
+	seen := make(intset)
+	for i, n := range cycle[:len(cycle)-1] {
+		if _, ok := seen[n.ID()]; ok {
+			t.Errorf("odd cycle for %q repeats node %d: %v", name, n.ID(), ids(cycle))
+		}
+		seen[n.ID()] = struct{}{}
+		if !g.HasEdgeBetween(n, cycle[i+1]) {
+			t.Errorf("odd cycle for %q uses missing edge %d--%d", name, n.ID(), cycle[i+1].ID())
+		}
+	}
+}
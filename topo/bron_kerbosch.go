@@ -110,6 +110,13 @@ func VertexOrdering(g graph.Undirected) (order []graph.Node, cores [][]graph.Nod
 
 // BronKerbosch returns the set of maximal cliques of the undirected graph g.
 func BronKerbosch(g graph.Undirected) [][]graph.Node {
+	cliques, _ := bronKerboschCliques(g, nil)
+	return cliques
+}
+
+// bronKerboschCliques returns the maximal cliques of g found before cancelled,
+// if not nil, returns a non-nil error, and that error.
+func bronKerboschCliques(g graph.Undirected, cancelled func() error) ([][]graph.Node, error) {
 	nodes := g.Nodes()
 
 	// The algorithm used here is essentially BronKerbosch3 as described at
@@ -120,9 +127,12 @@ func BronKerbosch(g graph.Undirected) [][]graph.Node {
 		p.Add(n)
 	}
 	x := make(set.Nodes)
-	var bk bronKerbosch
+	bk := bronKerbosch{cancelled: cancelled}
 	order, _ := VertexOrdering(g)
 	for _, v := range order {
+		if bk.stopped() {
+			return bk.cliques, bk.err
+		}
 		neighbours := g.From(v)
 		nv := make(set.Nodes, len(neighbours))
 		for _, n := range neighbours {
@@ -132,14 +142,33 @@ func BronKerbosch(g graph.Undirected) [][]graph.Node {
 		p.Remove(v)
 		x.Add(v)
 	}
-	return bk
+	return bk.cliques, bk.err
+}
+
+type bronKerbosch struct {
+	cliques [][]graph.Node
+
+	// cancelled, if not nil, is checked by
+	// stopped, which holds its first non-nil
+	// result in err.
+	cancelled func() error
+	err       error
 }
 
-type bronKerbosch [][]graph.Node
+// stopped returns whether the search has been cancelled.
+func (bk *bronKerbosch) stopped() bool {
+	if bk.err == nil && bk.cancelled != nil {
+		bk.err = bk.cancelled()
+	}
+	return bk.err != nil
+}
 
 func (bk *bronKerbosch) maximalCliquePivot(g graph.Undirected, r []graph.Node, p, x set.Nodes) {
+	if bk.stopped() {
+		return
+	}
 	if len(p) == 0 && len(x) == 0 {
-		*bk = append(*bk, r)
+		bk.cliques = append(bk.cliques, r)
 		return
 	}
 
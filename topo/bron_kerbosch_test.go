@@ -5,11 +5,14 @@
 package topo
 
 import (
+	"errors"
+	"fmt"
 	"math"
 	"reflect"
 	"sort"
 	"testing"
 
+	"github.com/gonum/graph"
 	"github.com/gonum/graph/internal/ordered"
 	"github.com/gonum/graph/simple"
 )
@@ -162,3 +165,58 @@ func TestBronKerbosch(t *testing.T) {
 		}
 	}
 }
+
+func TestBronKerboschCancelled(t *testing.T) {
+	g := undirectedFrom(batageljZaversnikGraph)
+	all := BronKerbosch(g)
+
+	// Cancellation after the search has completed must
+	// not be reported. The search of an edgeless graph
+	// checks for cancellation twice for each node.
+	edgeless := undirectedFrom([]intset{0: nil, 1: nil, 2: nil})
+	checks := 2 * len(edgeless.Nodes())
+	errLate := errors.New("late")
+	var late int
+	got, err := bronKerboschCliques(edgeless, func() error {
+		if late++; late > checks {
+			return errLate
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("unexpected error for completed search: %v", err)
+	}
+	if len(got) != 3 {
+		t.Errorf("unexpected number of cliques for completed search: got:%d want:3", len(got))
+	}
+
+	errStop := errors.New("stop")
+	var calls int
+	got, err = bronKerboschCliques(g, func() error {
+		if calls++; calls > 10 {
+			return errStop
+		}
+		return nil
+	})
+	if err != errStop {
+		t.Errorf("unexpected error for partial search: got:%v want:%v", err, errStop)
+	}
+	if len(got) == 0 || len(got) >= len(all) {
+		t.Errorf("unexpected number of cliques for partial search: got:%d want in (0, %d)", len(got), len(all))
+	}
+	want := make(map[string]bool)
+	for _, c := range all {
+		want[fmt.Sprint(sortedIDs(c))] = true
+	}
+	for _, c := range got {
+		if !want[fmt.Sprint(sortedIDs(c))] {
+			t.Errorf("partial result holds clique not in full result: %v", sortedIDs(c))
+		}
+	}
+}
+
+func sortedIDs(nodes []graph.Node) []int {
+	ids := ids(nodes)
+	sort.Ints(ids)
+	return ids
+}
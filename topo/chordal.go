@@ -0,0 +1,337 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"sort"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+	"github.com/gonum/graph/internal/set"
+	"github.com/gonum/graph/simple"
+	"github.com/gonum/graph/traverse"
+)
+
+// IsChordal returns whether the undirected graph g is chordal, that is
+// whether every cycle of g with four or more nodes has a chord.
+func IsChordal(g graph.Undirected) bool {
+	c := newChordalGraph(g)
+	_, _, ok := c.check()
+	return ok
+}
+
+// Chordality returns whether the undirected graph g is chordal. If g is
+// chordal, peo holds a perfect elimination ordering of the nodes of g and
+// cycle is nil. Otherwise peo is nil and cycle holds the nodes of a chordless
+// cycle of g with at least four nodes, in order around the cycle. Self loops
+// are ignored.
+//
+// The perfect elimination ordering is the reverse of a maximum cardinality
+// search order of g.
+//
+//	Tarjan and Yannakakis "Simple linear-time algorithms to test chordality of
+//	graphs, test acyclicity of hypergraphs, and selectively reduce acyclic
+//	hypergraphs" doi:10.1137/0213035
+func Chordality(g graph.Undirected) (chordal bool, peo, cycle []graph.Node) {
+	c := newChordalGraph(g)
+	v, u, ok := c.check()
+	if ok {
+		return true, c.nodesOf(c.peo), nil
+	}
+	return false, nil, c.nodesOf(c.chordlessCycle(v, u))
+}
+
+// MinimalTriangulation returns a minimal triangulation of the undirected
+// graph g, a minimal set of fill edges whose addition to g makes it chordal,
+// and a perfect elimination ordering of the triangulated graph. The fill
+// edges are not present in g and are returned as simple.Edge values with
+// zero weight. The triangulation is found by the MCS-M algorithm.
+//
+//	Berry, Blair, Heggernes and Peyton "Maximum cardinality search for
+//	computing minimal triangulations of graphs" doi:10.1007/s00453-004-1084-3
+func MinimalTriangulation(g graph.Undirected) (peo []graph.Node, fill []graph.Edge) {
+	c := newIndexGraph(g)
+	n := len(c.nodes)
+
+	weight := make([]int, n)
+	numbered := make([]bool, n)
+	order := make([]int, n)
+	cost := make([]int, n)
+	const unreached = -2
+	for i := n - 1; i >= 0; i-- {
+		v := -1
+		for u, ok := range numbered {
+			if !ok && (v < 0 || weight[u] > weight[v]) {
+				v = u
+			}
+		}
+
+		// Find the unnumbered nodes reachable from v by paths
+		// through unnumbered nodes of lower weight than the
+		// path's end point. The cost of reaching a node is the
+		// greatest weight of the internal nodes of its best
+		// path, or -1 if it is a neighbour of v. Costs never
+		// decrease along a path so a bucket queue suffices.
+		for u := range cost {
+			cost[u] = unreached
+		}
+		buckets := make([][]int, n+1)
+		for _, u := range c.adj[v] {
+			if !numbered[u] {
+				cost[u] = -1
+				buckets[0] = append(buckets[0], u)
+			}
+		}
+		for b := range buckets {
+			for k := 0; k < len(buckets[b]); k++ {
+				x := buckets[b][k]
+				if cost[x] != b-1 {
+					continue
+				}
+				cx := cost[x]
+				if weight[x] > cx {
+					cx = weight[x]
+				}
+				for _, y := range c.adj[x] {
+					if numbered[y] || y == v {
+						continue
+					}
+					if cost[y] == unreached || cx < cost[y] {
+						cost[y] = cx
+						buckets[cx+1] = append(buckets[cx+1], y)
+					}
+				}
+			}
+		}
+
+		adjacent := make(set.Ints)
+		for _, u := range c.adj[v] {
+			adjacent.Add(u)
+		}
+		for u, cu := range cost {
+			if cu == unreached || numbered[u] || u == v || cu >= weight[u] {
+				continue
+			}
+			weight[u]++
+			if !adjacent.Has(u) {
+				fill = append(fill, simple.Edge{F: c.nodes[v], T: c.nodes[u]})
+			}
+		}
+		numbered[v] = true
+		order[i] = v
+	}
+
+	peo = make([]graph.Node, n)
+	for i, v := range order {
+		peo[i] = c.nodes[v]
+	}
+	return peo, fill
+}
+
+// CliqueTree is a clique tree of a chordal graph. A clique tree has the
+// maximal cliques of the graph as its nodes, and for every node of the
+// graph the cliques containing the node induce a subtree. If the graph is
+// not connected the clique tree is a forest with a tree for each connected
+// component.
+type CliqueTree struct {
+	// Cliques holds the maximal cliques of
+	// the graph, each ordered by node ID.
+	Cliques [][]graph.Node
+
+	// Edges holds the edges of the tree as
+	// pairs of indices into Cliques.
+	Edges [][2]int
+}
+
+// NewCliqueTree returns a clique tree of the undirected graph g and true
+// if g is chordal. Otherwise it returns false.
+//
+//	Blair and Peyton "An introduction to chordal graphs and clique trees"
+//	doi:10.1007/978-1-4613-8369-7_1
+func NewCliqueTree(g graph.Undirected) (t CliqueTree, ok bool) {
+	c := newChordalGraph(g)
+	if _, _, ok := c.check(); !ok {
+		return CliqueTree{}, false
+	}
+
+	// For each node v, madj(v) holds the neighbours of v later
+	// in the perfect elimination ordering, which form a clique
+	// with v, and the parent of v is the earliest of them.
+	n := len(c.nodes)
+	parent := make([]int, n)
+	size := make([]int, n)
+	for _, v := range c.peo {
+		parent[v] = -1
+		for _, u := range c.adj[v] {
+			if c.pos[u] > c.pos[v] {
+				size[v]++
+				if parent[v] < 0 || c.pos[u] < c.pos[parent[v]] {
+					parent[v] = u
+				}
+			}
+		}
+	}
+
+	// The clique {v} ∪ madj(v) is contained in {u} ∪ madj(u)
+	// for a child u of v if madj(u) is one larger than madj(v),
+	// and is otherwise maximal.
+	clique := make([]int, n)
+	for i := range clique {
+		clique[i] = -1
+	}
+	var links [][2]int
+	for _, v := range c.peo {
+		if clique[v] < 0 {
+			clique[v] = len(t.Cliques)
+			members := []graph.Node{c.nodes[v]}
+			for _, u := range c.adj[v] {
+				if c.pos[u] > c.pos[v] {
+					members = append(members, c.nodes[u])
+				}
+			}
+			sort.Sort(ordered.ByID(members))
+			t.Cliques = append(t.Cliques, members)
+		}
+		p := parent[v]
+		if p < 0 {
+			continue
+		}
+		if clique[p] < 0 && size[v] == size[p]+1 {
+			clique[p] = clique[v]
+			continue
+		}
+		links = append(links, [2]int{v, p})
+	}
+	for _, l := range links {
+		t.Edges = append(t.Edges, [2]int{clique[l[0]], clique[l[1]]})
+	}
+	return t, true
+}
+
+// chordalGraph is an index-based representation of an undirected
+// graph with a candidate perfect elimination ordering.
+type chordalGraph struct {
+	indexGraph
+	adjSet []set.Ints
+
+	// peo holds the node indices in the candidate
+	// perfect elimination ordering and pos holds
+	// the position of each node in peo.
+	peo []int
+	pos []int
+}
+
+func newChordalGraph(g graph.Undirected) chordalGraph {
+	c := chordalGraph{indexGraph: newIndexGraph(g)}
+	n := len(c.nodes)
+	c.adjSet = make([]set.Ints, n)
+	for u, nbrs := range c.adj {
+		c.adjSet[u] = make(set.Ints)
+		for _, v := range nbrs {
+			c.adjSet[u].Add(v)
+		}
+	}
+	mcs := traverse.MaximumCardinalitySearch(g)
+	c.peo = make([]int, n)
+	c.pos = make([]int, n)
+	for i, v := range mcs {
+		u := c.indexOf[v.ID()]
+		c.peo[n-1-i] = u
+		c.pos[u] = n - 1 - i
+	}
+	return c
+}
+
+// check returns whether the candidate ordering is a perfect elimination
+// ordering. If it is not, check returns a node v and its neighbour u later
+// in the ordering such that u is not adjacent to another neighbour of v
+// later in the ordering.
+func (c chordalGraph) check() (v, u int, ok bool) {
+	for _, v := range c.peo {
+		// Find the earliest later neighbour of v.
+		u := -1
+		for _, w := range c.adj[v] {
+			if c.pos[w] > c.pos[v] && (u < 0 || c.pos[w] < c.pos[u]) {
+				u = w
+			}
+		}
+		for _, w := range c.adj[v] {
+			if w != u && c.pos[w] > c.pos[v] && !c.adjSet[u].Has(w) {
+				return v, u, false
+			}
+		}
+	}
+	return -1, -1, true
+}
+
+// chordlessCycle returns a chordless cycle through v and its later neighbour
+// u, where u is not adjacent to some other later neighbour of v.
+func (c chordalGraph) chordlessCycle(v, u int) []int {
+	var w int
+	for _, w = range c.adj[v] {
+		if w != u && c.pos[w] > c.pos[v] && !c.adjSet[u].Has(w) {
+			break
+		}
+	}
+
+	// A shortest path from u to w avoiding v and its other
+	// neighbours is chordless and completes a chordless cycle
+	// through v. Paths through nodes later in the ordering
+	// than v are preferred.
+	for _, later := range []bool{true, false} {
+		path := c.shortestPath(u, w, func(x int) bool {
+			if x == u || x == w {
+				return true
+			}
+			if x == v || c.adjSet[v].Has(x) {
+				return false
+			}
+			return !later || c.pos[x] > c.pos[v]
+		})
+		if path != nil {
+			return append([]int{v}, path...)
+		}
+	}
+	panic("topo: no chordless cycle found")
+}
+
+// shortestPath returns a shortest path from u to w through nodes for which
+// allowed returns true, or nil if there is no such path.
+func (c chordalGraph) shortestPath(u, w int, allowed func(int) bool) []int {
+	prev := make(map[int]int)
+	prev[u] = -1
+	queue := []int{u}
+	for len(queue) != 0 {
+		x := queue[0]
+		queue = queue[1:]
+		if x == w {
+			var path []int
+			for ; x >= 0; x = prev[x] {
+				path = append(path, x)
+			}
+			for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+				path[i], path[j] = path[j], path[i]
+			}
+			return path
+		}
+		for _, y := range c.adj[x] {
+			if _, ok := prev[y]; ok || !allowed(y) {
+				continue
+			}
+			prev[y] = x
+			queue = append(queue, y)
+		}
+	}
+	return nil
+}
+
+// nodesOf returns the nodes with the given indices.
+func (c chordalGraph) nodesOf(idx []int) []graph.Node {
+	nodes := make([]graph.Node, len(idx))
+	for i, v := range idx {
+		nodes[i] = c.nodes[v]
+	}
+	return nodes
+}
@@ -0,0 +1,285 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+	"github.com/gonum/graph/simple"
+)
+
+var chordalityTests = []struct {
+	name string
+	g    []intset
+	want bool
+}{
+	{name: "empty", g: nil, want: true},
+	{name: "isolated", g: []intset{0: nil, 1: nil}, want: true},
+	{name: "K4", g: []intset{0: linksTo(1, 2, 3), 1: linksTo(2, 3), 2: linksTo(3)}, want: true},
+	{name: "C4", g: colouringTests[3].g, want: false},
+	{name: "C5", g: colouringTests[2].g, want: false},
+	{name: "wheel", g: colouringTests[4].g, want: false},
+	{name: "Petersen", g: colouringTests[5].g, want: false},
+	{
+		name: "tree",
+		g: []intset{
+			0: linksTo(1, 2),
+			1: linksTo(3, 4),
+			2: linksTo(5),
+			5: linksTo(6),
+		},
+		want: true,
+	},
+	{
+		name: "fan",
+		g: []intset{
+			0: linksTo(1, 2, 3, 4, 5),
+			1: linksTo(2),
+			2: linksTo(3),
+			3: linksTo(4),
+			4: linksTo(5),
+		},
+		want: true,
+	},
+	{
+		name: "two triangles and a square",
+		g: []intset{
+			0: linksTo(1, 2),
+			1: linksTo(2),
+			3: linksTo(4, 5),
+			4: linksTo(5),
+			6: linksTo(7),
+			7: linksTo(8),
+			8: linksTo(9),
+			9: linksTo(6),
+		},
+		want: false,
+	},
+}
+
+func TestChordality(t *testing.T) {
+	for _, test := range chordalityTests {
+		checkChordality(t, test.name, undirectedFrom(test.g), test.want)
+	}
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		g := undirectedFrom(gnpAdjacency(rnd, 15, 0.2))
+		checkChordality(t, fmt.Sprintf("random %d", i), g, IsChordal(g))
+	}
+}
+
+func TestMinimalTriangulation(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	tests := chordalityTests
+	for i := 0; i < 30; i++ {
+		tests = append(tests, struct {
+			name string
+			g    []intset
+			want bool
+		}{name: fmt.Sprintf("random %d", i), g: gnpAdjacency(rnd, 15, 0.2)})
+	}
+	for _, test := range tests {
+		g := undirectedFrom(test.g)
+		peo, fill := MinimalTriangulation(g)
+		if test.want && len(fill) != 0 {
+			t.Errorf("unexpected fill for chordal %q: %d edges", test.name, len(fill))
+		}
+
+		h := simple.NewUndirectedGraph(0, math.Inf(1))
+		for _, n := range g.Nodes() {
+			h.AddNode(n)
+		}
+		for _, u := range g.Nodes() {
+			for _, v := range g.From(u) {
+				h.SetEdge(simple.Edge{F: u, T: v})
+			}
+		}
+		for _, e := range fill {
+			if g.HasEdgeBetween(e.From(), e.To()) {
+				t.Errorf("fill edge %d-%d of %q already in graph", e.From().ID(), e.To().ID(), test.name)
+			}
+			h.SetEdge(e)
+		}
+		if !IsChordal(h) {
+			t.Errorf("triangulation of %q is not chordal", test.name)
+			continue
+		}
+		checkPEO(t, test.name+" triangulation", h, peo)
+
+		// A triangulation is minimal if removing
+		// any single fill edge breaks chordality.
+		for _, e := range fill {
+			h.RemoveEdge(e)
+			if IsChordal(h) {
+				t.Errorf("triangulation of %q is not minimal: fill edge %d-%d is redundant", test.name, e.From().ID(), e.To().ID())
+			}
+			h.SetEdge(e)
+		}
+	}
+}
+
+func TestCliqueTree(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	var graphs []graph.Undirected
+	var names []string
+	for _, test := range chordalityTests {
+		graphs = append(graphs, undirectedFrom(test.g))
+		names = append(names, test.name)
+	}
+	for i := 0; i < 30; i++ {
+		// Triangulated random graphs are chordal.
+		g := undirectedFrom(gnpAdjacency(rnd, 15, 0.15))
+		_, fill := MinimalTriangulation(g)
+		h := g.(*simple.UndirectedGraph)
+		for _, e := range fill {
+			h.SetEdge(e)
+		}
+		graphs = append(graphs, h)
+		names = append(names, fmt.Sprintf("triangulated random %d", i))
+	}
+
+	for i, g := range graphs {
+		name := names[i]
+		tree, ok := NewCliqueTree(g)
+		if ok != IsChordal(g) {
+			t.Errorf("unexpected ok for %q: got:%t want:%t", name, ok, !ok)
+		}
+		if !ok {
+			continue
+		}
+
+		want := make(map[string]bool)
+		for _, c := range BronKerbosch(g) {
+			sort.Sort(ordered.ByID(c))
+			want[fmt.Sprint(ids(c))] = true
+		}
+		got := make(map[string]bool)
+		for _, c := range tree.Cliques {
+			got[fmt.Sprint(ids(c))] = true
+		}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("unexpected cliques for %q:\ngot: %v\nwant:%v", name, got, want)
+		}
+
+		var components int
+		for range ConnectedComponents(g) {
+			components++
+		}
+		if len(tree.Edges) != len(tree.Cliques)-components {
+			t.Errorf("unexpected number of clique tree edges for %q: got:%d want:%d", name, len(tree.Edges), len(tree.Cliques)-components)
+		}
+
+		// The cliques containing each node must
+		// induce a connected subtree.
+		for _, n := range g.Nodes() {
+			has := make(map[int]bool)
+			for k, c := range tree.Cliques {
+				for _, u := range c {
+					if u.ID() == n.ID() {
+						has[k] = true
+					}
+				}
+			}
+			parent := make(map[int]int)
+			for k := range has {
+				parent[k] = k
+			}
+			var find func(int) int
+			find = func(k int) int {
+				if parent[k] != k {
+					parent[k] = find(parent[k])
+				}
+				return parent[k]
+			}
+			for _, e := range tree.Edges {
+				if has[e[0]] && has[e[1]] {
+					parent[find(e[0])] = find(e[1])
+				}
+			}
+			roots := make(map[int]bool)
+			for k := range has {
+				roots[find(k)] = true
+			}
+			if len(roots) != 1 {
+				t.Errorf("cliques containing node %d of %q do not form a subtree", n.ID(), name)
+			}
+		}
+	}
+}
+
+// checkChordality checks the results of IsChordal and Chordality for g.
+func checkChordality(t *testing.T, name string, g graph.Undirected, want bool) {
+	if got := IsChordal(g); got != want {
+		t.Errorf("unexpected IsChordal result for %q: got:%t want:%t", name, got, want)
+	}
+	chordal, peo, cycle := Chordality(g)
+	if chordal != want {
+		t.Errorf("unexpected Chordality result for %q: got:%t want:%t", name, chordal, want)
+	}
+	if chordal {
+		if cycle != nil {
+			t.Errorf("unexpected cycle for chordal %q", name)
+		}
+		checkPEO(t, name, g, peo)
+		return
+	}
+	if peo != nil {
+		t.Errorf("unexpected perfect elimination ordering for non-chordal %q", name)
+	}
+	if len(cycle) < 4 {
+		t.Errorf("chordless cycle for %q too short: %v", name, ids(cycle))
+		return
+	}
+	seen := make(map[int]bool)
+	for i, u := range cycle {
+		if seen[u.ID()] {
+			t.Errorf("cycle for %q repeats node %d", name, u.ID())
+		}
+		seen[u.ID()] = true
+		for j, v := range cycle {
+			d := j - i
+			if d < 0 {
+				d = -d
+			}
+			adjacent := d == 1 || d == len(cycle)-1
+			if i != j && g.HasEdgeBetween(u, v) != adjacent {
+				t.Errorf("cycle for %q is not chordless at %d-%d: %v", name, u.ID(), v.ID(), ids(cycle))
+			}
+		}
+	}
+}
+
+// checkPEO checks that peo is a perfect elimination ordering of g.
+func checkPEO(t *testing.T, name string, g graph.Undirected, peo []graph.Node) {
+	if len(peo) != len(g.Nodes()) {
+		t.Errorf("unexpected perfect elimination ordering length for %q: got:%d want:%d", name, len(peo), len(g.Nodes()))
+		return
+	}
+	pos := make(map[int]int)
+	for i, n := range peo {
+		pos[n.ID()] = i
+	}
+	for i, u := range peo {
+		var later []graph.Node
+		for _, v := range g.From(u) {
+			if pos[v.ID()] > i {
+				later = append(later, v)
+			}
+		}
+		for j, v := range later {
+			for _, w := range later[j+1:] {
+				if !g.HasEdgeBetween(v, w) {
+					t.Errorf("later neighbours %d and %d of %d are not adjacent in ordering for %q", v.ID(), w.ID(), u.ID(), name)
+				}
+			}
+		}
+	}
+}
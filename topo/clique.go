@@ -0,0 +1,252 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"sort"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+	"github.com/gonum/graph/internal/set"
+)
+
+// MaximumClique returns a clique of the undirected graph g with the greatest
+// number of nodes, ordered by node ID. The clique is found by a branch and
+// bound search using greedy colourings of candidate nodes as bounds.
+//
+//	Tomita and Seki "An efficient branch-and-bound algorithm for finding a
+//	maximum clique" doi:10.1007/3-540-45066-1_22
+//
+//	Tomita, Sutani, Higashi, Takahashi and Wakatsuki "A simple and faster
+//	branch-and-bound algorithm for finding a maximum clique"
+//	doi:10.1007/978-3-642-11440-3_18
+func MaximumClique(g graph.Undirected) []graph.Node {
+	c := newCliqueGraph(g)
+	s := cliqueSearch{cliqueGraph: c}
+	p, bound := c.colourSort(c.order, nil)
+	s.expand(nil, 0, p, bound)
+	return c.nodesOf(s.best)
+}
+
+// MaximumWeightClique returns a clique of the undirected graph g with the
+// greatest total node weight, ordered by node ID, and its weight. Node weights
+// are given by the weight function which must return non-negative values;
+// MaximumWeightClique will panic otherwise. The clique is found by a branch
+// and bound search using weighted greedy colourings of candidate nodes as
+// bounds.
+//
+//	Kumlander "A new exact algorithm for the maximum-weight clique problem
+//	based on a heuristic vertex-coloring and a backtrack search"
+func MaximumWeightClique(g graph.Undirected, weight func(graph.Node) float64) (clique []graph.Node, w float64) {
+	c := newCliqueGraph(g)
+	s := cliqueSearch{cliqueGraph: c, weight: make([]float64, len(c.nodes))}
+	for i, n := range c.nodes {
+		w := weight(n)
+		if w < 0 {
+			panic("topo: negative node weight")
+		}
+		s.weight[i] = w
+	}
+	p, bound := c.colourSort(c.order, s.weight)
+	s.expand(nil, 0, p, bound)
+	return c.nodesOf(s.best), s.bestWeight
+}
+
+// KCliques returns all the cliques of the undirected graph g with exactly
+// k nodes, each ordered by node ID. KCliques returns nil if k is less
+// than one.
+func KCliques(g graph.Undirected, k int) [][]graph.Node {
+	if k < 1 {
+		return nil
+	}
+	c := newCliqueGraph(g)
+
+	// Nodes are only extended by neighbours later in
+	// the degeneracy order so that each clique is
+	// found once and candidate sets remain small.
+	pos := make([]int, len(c.nodes))
+	for i, u := range c.order {
+		pos[u] = i
+	}
+	var cliques [][]graph.Node
+	var extend func(r, p []int)
+	extend = func(r, p []int) {
+		if len(r) == k {
+			cliques = append(cliques, c.nodesOf(r))
+			return
+		}
+		if len(r)+len(p) < k {
+			return
+		}
+		for i, v := range p {
+			var next []int
+			for _, w := range p[i+1:] {
+				if c.adj[v].Has(w) {
+					next = append(next, w)
+				}
+			}
+			extend(append(r[:len(r):len(r)], v), next)
+		}
+	}
+	for _, u := range c.order {
+		var p []int
+		for w := range c.adj[u] {
+			if pos[w] > pos[u] {
+				p = append(p, w)
+			}
+		}
+		sort.Sort(byPosition{nodes: p, pos: pos})
+		extend([]int{u}, p)
+	}
+	return cliques
+}
+
+// cliqueGraph is an index-based representation of an undirected
+// graph without self loops used for clique search.
+type cliqueGraph struct {
+	nodes []graph.Node
+	adj   []set.Ints
+
+	// order holds the node indices
+	// in smallest-last order.
+	order []int
+}
+
+func newCliqueGraph(g graph.Undirected) cliqueGraph {
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+	indexOf := make(map[int]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+	adj := make([]set.Ints, len(nodes))
+	for i, u := range nodes {
+		adj[i] = make(set.Ints)
+		for _, v := range g.From(u) {
+			if j := indexOf[v.ID()]; j != i {
+				adj[i].Add(j)
+			}
+		}
+	}
+	sl, _ := VertexOrdering(g)
+	order := make([]int, len(sl))
+	for i, n := range sl {
+		order[i] = indexOf[n.ID()]
+	}
+	return cliqueGraph{nodes: nodes, adj: adj, order: order}
+}
+
+// colourSort greedily colours the nodes in p in order and returns them
+// ordered by colour with, for each node, an upper bound on the size or
+// weight of a clique within the nodes up to and including the node. If
+// weight is nil, all nodes have unit weight.
+func (c cliqueGraph) colourSort(p []int, weight []float64) (sorted []int, bound []float64) {
+	var classes [][]int
+	for _, v := range p {
+		k := 0
+	find:
+		for ; k < len(classes); k++ {
+			for _, u := range classes[k] {
+				if c.adj[v].Has(u) {
+					continue find
+				}
+			}
+			break
+		}
+		if k == len(classes) {
+			classes = append(classes, nil)
+		}
+		classes[k] = append(classes[k], v)
+	}
+
+	sorted = make([]int, 0, len(p))
+	bound = make([]float64, 0, len(p))
+	var sum float64
+	for _, class := range classes {
+		max := 1.0
+		if weight != nil {
+			max = 0
+			for _, v := range class {
+				if weight[v] > max {
+					max = weight[v]
+				}
+			}
+		}
+		sum += max
+		for _, v := range class {
+			sorted = append(sorted, v)
+			bound = append(bound, sum)
+		}
+	}
+	return sorted, bound
+}
+
+// nodesOf returns the nodes with the given indices ordered by ID.
+func (c cliqueGraph) nodesOf(idx []int) []graph.Node {
+	nodes := make([]graph.Node, len(idx))
+	for i, v := range idx {
+		nodes[i] = c.nodes[v]
+	}
+	sort.Sort(ordered.ByID(nodes))
+	return nodes
+}
+
+// cliqueSearch is the state of the branch and bound search performed
+// by MaximumClique and MaximumWeightClique.
+type cliqueSearch struct {
+	cliqueGraph
+
+	// weight holds the node weights, or
+	// nil for unit weights.
+	weight []float64
+
+	best       []int
+	bestWeight float64
+}
+
+// expand extends the clique r with weight w by nodes in the candidate set p,
+// where bound holds the colour bounds for p returned by colourSort.
+func (s *cliqueSearch) expand(r []int, w float64, p []int, bound []float64) {
+	for i := len(p) - 1; i >= 0; i-- {
+		if w+bound[i] <= s.bestWeight && s.best != nil {
+			return
+		}
+		v := p[i]
+		rv := append(r[:len(r):len(r)], v)
+		wv := w + s.nodeWeight(v)
+
+		var next []int
+		for _, u := range p[:i] {
+			if s.adj[v].Has(u) {
+				next = append(next, u)
+			}
+		}
+		if wv > s.bestWeight || s.best == nil {
+			s.best = rv
+			s.bestWeight = wv
+		}
+		if len(next) != 0 {
+			np, nb := s.colourSort(next, s.weight)
+			s.expand(rv, wv, np, nb)
+		}
+	}
+}
+
+func (s *cliqueSearch) nodeWeight(v int) float64 {
+	if s.weight == nil {
+		return 1
+	}
+	return s.weight[v]
+}
+
+// byPosition sorts node indices by their position in an ordering.
+type byPosition struct {
+	nodes []int
+	pos   []int
+}
+
+func (s byPosition) Len() int           { return len(s.nodes) }
+func (s byPosition) Less(i, j int) bool { return s.pos[s.nodes[i]] < s.pos[s.nodes[j]] }
+func (s byPosition) Swap(i, j int)      { s.nodes[i], s.nodes[j] = s.nodes[j], s.nodes[i] }
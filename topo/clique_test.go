@@ -0,0 +1,186 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+)
+
+var maximumCliqueTests = []struct {
+	name string
+	g    []intset
+	want int
+}{
+	{name: "empty", g: nil, want: 0},
+	{name: "isolated", g: []intset{0: nil, 1: nil}, want: 1},
+	{name: "Batagelj-Zaversnik", g: batageljZaversnikGraph, want: 4},
+	{name: "wheel", g: colouringTests[4].g, want: 3},
+	{name: "Petersen", g: colouringTests[5].g, want: 2},
+}
+
+func TestMaximumClique(t *testing.T) {
+	for _, test := range maximumCliqueTests {
+		g := undirectedFrom(test.g)
+		got := MaximumClique(g)
+		checkClique(t, test.name, g, got)
+		if len(got) != test.want {
+			t.Errorf("unexpected maximum clique size for %q: got:%d want:%d", test.name, len(got), test.want)
+		}
+	}
+}
+
+func TestMaximumCliqueRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		g := undirectedFrom(gnpAdjacency(rnd, 30, 0.6))
+		name := fmt.Sprintf("random %d", i)
+
+		var want int
+		for _, c := range BronKerbosch(g) {
+			if len(c) > want {
+				want = len(c)
+			}
+		}
+		got := MaximumClique(g)
+		checkClique(t, name, g, got)
+		if len(got) != want {
+			t.Errorf("unexpected maximum clique size for %s: got:%d want:%d", name, len(got), want)
+		}
+	}
+}
+
+func TestMaximumWeightCliqueRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		g := undirectedFrom(gnpAdjacency(rnd, 25, 0.5))
+		name := fmt.Sprintf("random %d", i)
+		weights := make(map[int]float64)
+		for _, n := range g.Nodes() {
+			weights[n.ID()] = float64(rnd.Intn(10))
+		}
+		weight := func(n graph.Node) float64 { return weights[n.ID()] }
+
+		// With non-negative weights some maximal
+		// clique is a maximum weight clique.
+		var want float64
+		for _, c := range BronKerbosch(g) {
+			var w float64
+			for _, n := range c {
+				w += weight(n)
+			}
+			if w > want {
+				want = w
+			}
+		}
+		got, w := MaximumWeightClique(g, weight)
+		checkClique(t, name, g, got)
+		var sum float64
+		for _, n := range got {
+			sum += weight(n)
+		}
+		if w != sum {
+			t.Errorf("returned weight does not match clique weight for %s: got:%v want:%v", name, w, sum)
+		}
+		if w != want {
+			t.Errorf("unexpected maximum clique weight for %s: got:%v want:%v", name, w, want)
+		}
+	}
+}
+
+func TestMaximumWeightCliqueNegative(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for negative weight")
+		}
+	}()
+	MaximumWeightClique(undirectedFrom(batageljZaversnikGraph), func(graph.Node) float64 { return -1 })
+}
+
+func TestKCliques(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 10; i++ {
+		g := undirectedFrom(gnpAdjacency(rnd, 15, 0.5))
+		for k := 0; k <= 6; k++ {
+			name := fmt.Sprintf("random %d k=%d", i, k)
+			want := make(map[string]bool)
+			if k > 0 {
+				for _, c := range BronKerbosch(g) {
+					sort.Sort(ordered.ByID(c))
+					subsets(c, k, nil, func(s []graph.Node) {
+						want[fmt.Sprint(ids(s))] = true
+					})
+				}
+			}
+			got := make(map[string]bool)
+			for _, c := range KCliques(g, k) {
+				if len(c) != k {
+					t.Errorf("unexpected clique size for %s: got:%d want:%d", name, len(c), k)
+				}
+				checkClique(t, name, g, c)
+				key := fmt.Sprint(ids(c))
+				if got[key] {
+					t.Errorf("duplicate clique for %s: %s", name, key)
+				}
+				got[key] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("unexpected cliques for %s: got:%d want:%d", name, len(got), len(want))
+			}
+		}
+	}
+}
+
+func gnpAdjacency(rnd *rand.Rand, n int, p float64) []intset {
+	adj := make([]intset, n)
+	for u := 0; u < n; u++ {
+		adj[u] = make(intset)
+		for v := u + 1; v < n; v++ {
+			if rnd.Float64() < p {
+				adj[u][v] = struct{}{}
+			}
+		}
+	}
+	return adj
+}
+
+func ids(nodes []graph.Node) []int {
+	ids := make([]int, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID()
+	}
+	return ids
+}
+
+// checkClique checks that c is a clique of g ordered by node ID.
+func checkClique(t *testing.T, name string, g graph.Undirected, c []graph.Node) {
+	for i, u := range c {
+		if i != 0 && c[i-1].ID() >= u.ID() {
+			t.Errorf("clique for %s not ordered by ID: %v", name, ids(c))
+		}
+		for _, v := range c[i+1:] {
+			if !g.HasEdgeBetween(u, v) {
+				t.Errorf("nodes %d and %d of clique for %s not adjacent", u.ID(), v.ID(), name)
+			}
+		}
+	}
+}
+
+// subsets calls fn with each k-subset of s.
+func subsets(s []graph.Node, k int, prefix []graph.Node, fn func([]graph.Node)) {
+	if len(prefix) == k {
+		fn(prefix)
+		return
+	}
+	for i, n := range s {
+		subsets(s[i+1:], k, append(prefix[:len(prefix):len(prefix)], n), fn)
+	}
+}
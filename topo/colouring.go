@@ -0,0 +1,463 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"sort"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+	"github.com/gonum/graph/internal/set"
+)
+
+// LargestFirst returns the nodes of g ordered by decreasing degree, with
+// ties broken by ascending node ID. It is suitable for use as the order
+// parameter of GreedyColouring.
+func LargestFirst(g graph.Undirected) []graph.Node {
+	c := newIndexGraph(g)
+	order := make([]int, len(c.nodes))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Stable(byDegree{order: order, adj: c.adj})
+	nodes := make([]graph.Node, len(order))
+	for i, u := range order {
+		nodes[i] = c.nodes[u]
+	}
+	return nodes
+}
+
+// SmallestLast returns the nodes of g in smallest-last order, the reverse
+// of the order in which nodes of minimum degree are successively removed
+// from g. A greedy colouring in smallest-last order uses at most d+1
+// colours where d is the degeneracy of g. It is suitable for use as the
+// order parameter of GreedyColouring.
+func SmallestLast(g graph.Undirected) []graph.Node {
+	order, _ := VertexOrdering(g)
+	return order
+}
+
+// GreedyColouring returns a vertex colouring of g obtained by assigning to
+// each node in turn the least colour not used by its neighbours, and the
+// number of colours used. Nodes are coloured in the given order, which
+// must hold each node of g exactly once. If order is nil, nodes are coloured
+// in ascending ID order. Colours are numbered from zero and keyed by node ID.
+// Self loops are ignored.
+func GreedyColouring(g graph.Undirected, order []graph.Node) (k int, colours map[int]int) {
+	c := newIndexGraph(g)
+	if order == nil {
+		order = c.nodes
+	}
+	colours = make(map[int]int, len(c.nodes))
+	used := make(set.Ints)
+	for _, n := range order {
+		u := c.indexOf[n.ID()]
+		for prev := range used {
+			delete(used, prev)
+		}
+		for _, v := range c.adj[u] {
+			if col, ok := colours[c.nodes[v].ID()]; ok {
+				used.Add(col)
+			}
+		}
+		col := 0
+		for used.Has(col) {
+			col++
+		}
+		colours[n.ID()] = col
+		if col+1 > k {
+			k = col + 1
+		}
+	}
+	return k, colours
+}
+
+// DSatur returns a vertex colouring of g obtained by the DSatur heuristic,
+// and the number of colours used. At each step DSatur colours the uncoloured
+// node with the greatest number of distinct colours among its neighbours,
+// with ties broken by degree in the uncoloured subgraph, using the least
+// available colour. Colours are numbered from zero and keyed by node ID.
+// Self loops are ignored.
+//
+//	Brélaz "New methods to color the vertices of a graph"
+//	doi:10.1145/359094.359101
+func DSatur(g graph.Undirected) (k int, colours map[int]int) {
+	c := newIndexGraph(g)
+	col := c.dsatur()
+	return c.colourMap(col)
+}
+
+// ExactColouring returns a vertex colouring of g using the minimum number of
+// colours, and that number, the chromatic number of g. The colouring is found
+// by a DSatur-based branch and bound search which takes exponential time in
+// the worst case, so ExactColouring is only suitable for small graphs. Colours
+// are numbered from zero and keyed by node ID. Self loops are ignored.
+func ExactColouring(g graph.Undirected) (k int, colours map[int]int) {
+	c := newIndexGraph(g)
+	n := len(c.nodes)
+	if n == 0 {
+		return 0, map[int]int{}
+	}
+
+	// The upper bound is given by the DSatur heuristic
+	// and the lower bound by the size of the largest
+	// clique in g.
+	best := c.dsatur()
+	k, _ = c.colourMap(best)
+	var lower int
+	for _, clique := range BronKerbosch(g) {
+		if len(clique) > lower {
+			lower = len(clique)
+		}
+	}
+	if k == lower {
+		return c.colourMap(best)
+	}
+
+	bb := colourSearch{
+		indexGraph: c,
+		best:       best,
+		k:          k,
+		lower:      lower,
+		col:        make([]int, n),
+		count:      make([][]int, n),
+	}
+	for i := range bb.col {
+		bb.col[i] = -1
+		bb.count[i] = make([]int, k)
+	}
+	bb.search(0, 0)
+	return c.colourMap(bb.best)
+}
+
+// colourSearch is the state of the branch and bound search
+// performed by ExactColouring.
+type colourSearch struct {
+	indexGraph
+
+	// best and k hold the best colouring found so far
+	// and the number of colours it uses, and lower is
+	// a lower bound on the chromatic number.
+	best  []int
+	k     int
+	lower int
+
+	// col holds the colours of the nodes in the
+	// current partial colouring, with -1 for
+	// uncoloured nodes, and count holds for each
+	// node the number of its neighbours coloured
+	// with each colour.
+	col   []int
+	count [][]int
+}
+
+// search extends the partial colouring with coloured nodes coloured
+// using the colours 0 to used-1. It returns whether an optimal colouring
+// has been found.
+func (s *colourSearch) search(coloured, used int) bool {
+	if coloured == len(s.col) {
+		s.best = append(s.best[:0], s.col...)
+		s.k = used
+		return s.k == s.lower
+	}
+
+	// Choose the uncoloured node with the
+	// greatest saturation.
+	u, maxSat, maxDeg := -1, -1, -1
+	for v, cv := range s.col {
+		if cv >= 0 {
+			continue
+		}
+		var sat int
+		for _, c := range s.count[v][:used] {
+			if c != 0 {
+				sat++
+			}
+		}
+		var deg int
+		for _, w := range s.adj[v] {
+			if s.col[w] < 0 {
+				deg++
+			}
+		}
+		if sat > maxSat || (sat == maxSat && deg > maxDeg) {
+			u, maxSat, maxDeg = v, sat, deg
+		}
+	}
+
+	// Try each colour already used, and then a new
+	// colour, while the colouring can still improve
+	// on the best found so far.
+	for c := 0; c <= used && c < s.k-1; c++ {
+		if s.count[u][c] != 0 {
+			continue
+		}
+		s.set(u, c)
+		next := used
+		if c == used {
+			next++
+		}
+		if s.search(coloured+1, next) {
+			return true
+		}
+		s.unset(u)
+	}
+	return false
+}
+
+func (s *colourSearch) set(u, c int) {
+	s.col[u] = c
+	for _, v := range s.adj[u] {
+		s.count[v][c]++
+	}
+}
+
+func (s *colourSearch) unset(u int) {
+	c := s.col[u]
+	s.col[u] = -1
+	for _, v := range s.adj[u] {
+		s.count[v][c]--
+	}
+}
+
+// EdgeColouring returns an edge colouring of g obtained by the Misra-Gries
+// algorithm, and the number of colours used, which is at most one more than
+// the maximum degree of g. Colours are numbered from zero and keyed by the
+// IDs of the end nodes of each edge, lower ID first. Self loops are ignored.
+//
+//	Misra and Gries "A constructive proof of Vizing's theorem"
+//	doi:10.1016/0020-0190(92)90041-S
+func EdgeColouring(g graph.Undirected) (k int, colours map[[2]int]int) {
+	c := newIndexGraph(g)
+	n := len(c.nodes)
+
+	// colour holds the colour of each edge keyed by
+	// neighbour for each node, and at holds the
+	// neighbour joined by each colour.
+	colour := make([]map[int]int, n)
+	at := make([]map[int]int, n)
+	for u := range colour {
+		colour[u] = make(map[int]int)
+		at[u] = make(map[int]int)
+	}
+	setColour := func(u, v, c int) {
+		colour[u][v] = c
+		colour[v][u] = c
+		at[u][c] = v
+		at[v][c] = u
+	}
+	clearColour := func(u, v int) {
+		c := colour[u][v]
+		delete(colour[u], v)
+		delete(colour[v], u)
+		delete(at[u], c)
+		delete(at[v], c)
+	}
+	isFree := func(u, c int) bool {
+		_, ok := at[u][c]
+		return !ok
+	}
+	freeColour := func(u int) int {
+		for c := 0; ; c++ {
+			if isFree(u, c) {
+				return c
+			}
+		}
+	}
+
+	for u := range c.adj {
+		for _, v := range c.adj[u] {
+			if v < u {
+				continue
+			}
+
+			// Build a maximal fan of u starting at v.
+			fan := []int{v}
+			inFan := set.Ints{v: struct{}{}}
+			for {
+				last := fan[len(fan)-1]
+				next := -1
+				for _, w := range c.adj[u] {
+					cw, ok := colour[u][w]
+					if ok && !inFan.Has(w) && isFree(last, cw) {
+						next = w
+						break
+					}
+				}
+				if next < 0 {
+					break
+				}
+				fan = append(fan, next)
+				inFan.Add(next)
+			}
+
+			cu := freeColour(u)
+			d := freeColour(fan[len(fan)-1])
+
+			// Invert the cd-path from u.
+			if cu != d {
+				type edge struct{ u, v, c int }
+				var path []edge
+				x, want := u, d
+				for {
+					y, ok := at[x][want]
+					if !ok {
+						break
+					}
+					path = append(path, edge{x, y, want})
+					x = y
+					if want == d {
+						want = cu
+					} else {
+						want = d
+					}
+				}
+				for _, e := range path {
+					clearColour(e.u, e.v)
+				}
+				for _, e := range path {
+					if e.c == d {
+						setColour(e.u, e.v, cu)
+					} else {
+						setColour(e.u, e.v, d)
+					}
+				}
+			}
+
+			// Find the prefix of the fan ending at a node
+			// with d free, and rotate it.
+			end := -1
+		prefix:
+			for i, w := range fan {
+				if i != 0 {
+					cw, ok := colour[u][w]
+					if !ok || !isFree(fan[i-1], cw) {
+						break prefix
+					}
+				}
+				if isFree(w, d) {
+					end = i
+					break
+				}
+			}
+			if end < 0 {
+				panic("misra-gries: no fan rotation")
+			}
+			shift := make([]int, end)
+			for i := 0; i < end; i++ {
+				shift[i] = colour[u][fan[i+1]]
+				clearColour(u, fan[i+1])
+			}
+			for i, c := range shift {
+				setColour(u, fan[i], c)
+			}
+			setColour(u, fan[end], d)
+		}
+	}
+
+	colours = make(map[[2]int]int)
+	for u := range colour {
+		for v, col := range colour[u] {
+			if v < u {
+				continue
+			}
+			colours[[2]int{c.nodes[u].ID(), c.nodes[v].ID()}] = col
+			if col+1 > k {
+				k = col + 1
+			}
+		}
+	}
+	return k, colours
+}
+
+// indexGraph is an index-based representation of an undirected
+// graph without self loops.
+type indexGraph struct {
+	nodes   []graph.Node
+	indexOf map[int]int
+	adj     [][]int
+}
+
+func newIndexGraph(g graph.Undirected) indexGraph {
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+	indexOf := make(map[int]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+	adj := make([][]int, len(nodes))
+	for i, u := range nodes {
+		for _, v := range g.From(u) {
+			j := indexOf[v.ID()]
+			if j != i {
+				adj[i] = append(adj[i], j)
+			}
+		}
+		sort.Ints(adj[i])
+	}
+	return indexGraph{nodes: nodes, indexOf: indexOf, adj: adj}
+}
+
+// dsatur returns the DSatur colouring of the graph indexed by node.
+func (c indexGraph) dsatur() []int {
+	n := len(c.nodes)
+	col := make([]int, n)
+	for i := range col {
+		col[i] = -1
+	}
+	neighbourColours := make([]set.Ints, n)
+	for i := range neighbourColours {
+		neighbourColours[i] = make(set.Ints)
+	}
+	deg := make([]int, n)
+	for i, a := range c.adj {
+		deg[i] = len(a)
+	}
+	for range c.nodes {
+		u := -1
+		for v, cv := range col {
+			if cv >= 0 {
+				continue
+			}
+			if u < 0 || neighbourColours[v].Count() > neighbourColours[u].Count() ||
+				(neighbourColours[v].Count() == neighbourColours[u].Count() && deg[v] > deg[u]) {
+				u = v
+			}
+		}
+		cu := 0
+		for neighbourColours[u].Has(cu) {
+			cu++
+		}
+		col[u] = cu
+		for _, v := range c.adj[u] {
+			neighbourColours[v].Add(cu)
+			deg[v]--
+		}
+	}
+	return col
+}
+
+// colourMap returns the number of colours in the colouring col
+// and the colouring keyed by node ID.
+func (c indexGraph) colourMap(col []int) (k int, colours map[int]int) {
+	colours = make(map[int]int, len(col))
+	for i, cu := range col {
+		colours[c.nodes[i].ID()] = cu
+		if cu+1 > k {
+			k = cu + 1
+		}
+	}
+	return k, colours
+}
+
+// byDegree sorts node indices by decreasing degree.
+type byDegree struct {
+	order []int
+	adj   [][]int
+}
+
+func (s byDegree) Len() int           { return len(s.order) }
+func (s byDegree) Less(i, j int) bool { return len(s.adj[s.order[i]]) > len(s.adj[s.order[j]]) }
+func (s byDegree) Swap(i, j int)      { s.order[i], s.order[j] = s.order[j], s.order[i] }
@@ -0,0 +1,313 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+var colouringTests = []struct {
+	name      string
+	g         []intset
+	chromatic int
+}{
+	{name: "empty", g: nil, chromatic: 0},
+	{name: "isolated", g: []intset{0: nil, 1: nil, 2: nil}, chromatic: 1},
+	{
+		name: "odd cycle",
+		g: []intset{
+			0: linksTo(1),
+			1: linksTo(2),
+			2: linksTo(3),
+			3: linksTo(4),
+			4: linksTo(0),
+		},
+		chromatic: 3,
+	},
+	{
+		name: "even cycle",
+		g: []intset{
+			0: linksTo(1),
+			1: linksTo(2),
+			2: linksTo(3),
+			3: linksTo(0),
+		},
+		chromatic: 2,
+	},
+	{
+		name: "wheel",
+		g: []intset{
+			0: linksTo(1, 2, 3, 4, 5),
+			1: linksTo(2),
+			2: linksTo(3),
+			3: linksTo(4),
+			4: linksTo(5),
+			5: linksTo(1),
+		},
+		chromatic: 4,
+	},
+	{
+		name: "Petersen",
+		g: []intset{
+			0: linksTo(1, 4, 5),
+			1: linksTo(2, 6),
+			2: linksTo(3, 7),
+			3: linksTo(4, 8),
+			4: linksTo(9),
+			5: linksTo(7, 8),
+			6: linksTo(8, 9),
+			7: linksTo(9),
+			8: nil,
+			9: nil,
+		},
+		chromatic: 3,
+	},
+	{
+		// The Grötzsch graph is triangle-free
+		// with chromatic number 4.
+		name: "Grötzsch",
+		g: []intset{
+			0:  linksTo(1, 4, 6, 9),
+			1:  linksTo(2, 5, 7),
+			2:  linksTo(3, 6, 8),
+			3:  linksTo(4, 7, 9),
+			4:  linksTo(5, 8),
+			5:  linksTo(10),
+			6:  linksTo(10),
+			7:  linksTo(10),
+			8:  linksTo(10),
+			9:  linksTo(10),
+			10: nil,
+		},
+		chromatic: 4,
+	},
+	{name: "Batagelj-Zaversnik", g: batageljZaversnikGraph, chromatic: 4},
+}
+
+func TestColouring(t *testing.T) {
+	for _, test := range colouringTests {
+		g := undirectedFrom(test.g)
+
+		k, c := ExactColouring(g)
+		checkColouring(t, test.name+" exact", g, k, c)
+		if k != test.chromatic {
+			t.Errorf("unexpected chromatic number for %q: got:%d want:%d", test.name, k, test.chromatic)
+		}
+
+		k, c = DSatur(g)
+		checkColouring(t, test.name+" DSatur", g, k, c)
+		if k < test.chromatic {
+			t.Errorf("DSatur colouring of %q uses fewer than the chromatic number of colours: %d", test.name, k)
+		}
+
+		for _, order := range []struct {
+			name  string
+			nodes []graph.Node
+		}{
+			{name: "ID", nodes: nil},
+			{name: "largest first", nodes: LargestFirst(g)},
+			{name: "smallest last", nodes: SmallestLast(g)},
+		} {
+			k, c = GreedyColouring(g, order.nodes)
+			checkColouring(t, test.name+" "+order.name, g, k, c)
+			if k < test.chromatic {
+				t.Errorf("%s greedy colouring of %q uses fewer than the chromatic number of colours: %d", order.name, test.name, k)
+			}
+		}
+
+		// Smallest last colouring uses at most one
+		// more colour than the degeneracy.
+		_, cores := VertexOrdering(g)
+		k, _ = GreedyColouring(g, SmallestLast(g))
+		if len(test.g) != 0 && k > len(cores) {
+			t.Errorf("smallest last colouring of %q exceeds degeneracy bound: got:%d want<=%d", test.name, k, len(cores))
+		}
+	}
+}
+
+func TestLargestFirst(t *testing.T) {
+	g := undirectedFrom(colouringTests[4].g) // wheel
+	order := LargestFirst(g)
+	if order[0].ID() != 0 {
+		t.Errorf("unexpected first node for wheel: got:%d want:0", order[0].ID())
+	}
+	for i := 1; i < len(order); i++ {
+		if len(g.From(order[i-1])) < len(g.From(order[i])) {
+			t.Errorf("nodes not in largest first order: %v", order)
+		}
+	}
+}
+
+func TestExactColouringRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		const n = 8
+		adj := make([]intset, n)
+		for u := 0; u < n; u++ {
+			adj[u] = make(intset)
+			for v := u + 1; v < n; v++ {
+				if rnd.Float64() < 0.5 {
+					adj[u][v] = struct{}{}
+				}
+			}
+		}
+		g := undirectedFrom(adj)
+		name := fmt.Sprintf("random %d", i)
+		k, c := ExactColouring(g)
+		checkColouring(t, name, g, k, c)
+		if want := bruteForceChromatic(g); k != want {
+			t.Errorf("unexpected chromatic number for %s: got:%d want:%d", name, k, want)
+		}
+		if kd, _ := DSatur(g); kd < k {
+			t.Errorf("DSatur colouring of %s uses fewer colours than exact colouring: %d < %d", name, kd, k)
+		}
+	}
+}
+
+func TestEdgeColouring(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	tests := colouringTests
+	for i := 0; i < 20; i++ {
+		const n = 12
+		adj := make([]intset, n)
+		for u := 0; u < n; u++ {
+			adj[u] = make(intset)
+			for v := u + 1; v < n; v++ {
+				if rnd.Float64() < 0.4 {
+					adj[u][v] = struct{}{}
+				}
+			}
+		}
+		tests = append(tests, struct {
+			name      string
+			g         []intset
+			chromatic int
+		}{name: fmt.Sprintf("random %d", i), g: adj})
+	}
+
+	for _, test := range tests {
+		g := undirectedFrom(test.g)
+		k, c := EdgeColouring(g)
+
+		var maxDegree, edges int
+		for _, u := range g.Nodes() {
+			d := len(g.From(u))
+			edges += d
+			if d > maxDegree {
+				maxDegree = d
+			}
+		}
+		edges /= 2
+		if len(c) != edges {
+			t.Errorf("unexpected number of coloured edges for %q: got:%d want:%d", test.name, len(c), edges)
+		}
+		if k < maxDegree || k > maxDegree+1 {
+			t.Errorf("unexpected number of edge colours for %q: got:%d want:%d or %d", test.name, k, maxDegree, maxDegree+1)
+		}
+		for _, u := range g.Nodes() {
+			seen := make(map[int]bool)
+			for _, v := range g.From(u) {
+				key := [2]int{u.ID(), v.ID()}
+				if key[0] > key[1] {
+					key[0], key[1] = key[1], key[0]
+				}
+				col, ok := c[key]
+				if !ok {
+					t.Errorf("edge %v not coloured for %q", key, test.name)
+					continue
+				}
+				if col < 0 || col >= k {
+					t.Errorf("edge colour out of range for %q: %d", test.name, col)
+				}
+				if seen[col] {
+					t.Errorf("colour %d repeated at node %d for %q", col, u.ID(), test.name)
+				}
+				seen[col] = true
+			}
+		}
+	}
+}
+
+func undirectedFrom(adj []intset) graph.Undirected {
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	for u, e := range adj {
+		if !g.Has(simple.Node(u)) {
+			g.AddNode(simple.Node(u))
+		}
+		for v := range e {
+			if !g.Has(simple.Node(v)) {
+				g.AddNode(simple.Node(v))
+			}
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+		}
+	}
+	return g
+}
+
+// checkColouring checks that c is a proper vertex colouring of g with k colours.
+func checkColouring(t *testing.T, name string, g graph.Undirected, k int, c map[int]int) {
+	nodes := g.Nodes()
+	if len(c) != len(nodes) {
+		t.Errorf("unexpected number of coloured nodes for %q: got:%d want:%d", name, len(c), len(nodes))
+	}
+	used := make(map[int]bool)
+	for _, u := range nodes {
+		cu, ok := c[u.ID()]
+		if !ok {
+			t.Errorf("node %d not coloured for %q", u.ID(), name)
+			continue
+		}
+		if cu < 0 || cu >= k {
+			t.Errorf("colour out of range for %q: %d", name, cu)
+		}
+		used[cu] = true
+		for _, v := range g.From(u) {
+			if v.ID() != u.ID() && c[v.ID()] == cu {
+				t.Errorf("adjacent nodes %d and %d share colour %d for %q", u.ID(), v.ID(), cu, name)
+			}
+		}
+	}
+	if len(used) != k {
+		t.Errorf("unexpected number of colours used for %q: got:%d want:%d", name, len(used), k)
+	}
+}
+
+// bruteForceChromatic returns the chromatic number of g by exhaustive search.
+func bruteForceChromatic(g graph.Undirected) int {
+	nodes := g.Nodes()
+	for k := 1; ; k++ {
+		col := make(map[int]int)
+		var try func(i int) bool
+		try = func(i int) bool {
+			if i == len(nodes) {
+				return true
+			}
+			u := nodes[i]
+		next:
+			for c := 0; c < k; c++ {
+				for _, v := range g.From(u) {
+					if cv, ok := col[v.ID()]; ok && cv == c {
+						continue next
+					}
+				}
+				col[u.ID()] = c
+				if try(i + 1) {
+					return true
+				}
+				delete(col, u.ID())
+			}
+			return false
+		}
+		if try(0) {
+			return k
+		}
+	}
+}
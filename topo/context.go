@@ -0,0 +1,30 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.7
+// +build go1.7
+
+package topo
+
+import (
+	"context"
+
+	"github.com/gonum/graph"
+)
+
+// CyclesInContext returns the set of elementary cycles in the graph g in the
+// same way as CyclesIn, but stops the search if ctx is cancelled or its
+// deadline expires. In that case the cycles found before the search stopped
+// are returned with ctx.Err().
+func CyclesInContext(ctx context.Context, g graph.Directed) ([][]graph.Node, error) {
+	return cyclesIn(g, ctx.Err)
+}
+
+// BronKerboschContext returns the set of maximal cliques of the undirected graph
+// g in the same way as BronKerbosch, but stops the search if ctx is cancelled or
+// its deadline expires. In that case the maximal cliques found before the search
+// stopped are returned with ctx.Err().
+func BronKerboschContext(ctx context.Context, g graph.Undirected) ([][]graph.Node, error) {
+	return bronKerboschCliques(g, ctx.Err)
+}
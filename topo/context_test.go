@@ -0,0 +1,59 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.7
+// +build go1.7
+
+package topo
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCyclesInContext(t *testing.T) {
+	g := completeDirected(6)
+	all := CyclesIn(g)
+
+	got, err := CyclesInContext(context.Background(), g)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(got) != len(all) {
+		t.Errorf("unexpected number of cycles: got:%d want:%d", len(got), len(all))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	got, err = CyclesInContext(ctx, g)
+	if err != context.Canceled {
+		t.Errorf("unexpected error for cancelled context: got:%v want:%v", err, context.Canceled)
+	}
+	if len(got) != 0 {
+		t.Errorf("unexpected cycles for cancelled context: %d", len(got))
+	}
+}
+
+func TestBronKerboschContext(t *testing.T) {
+	g := undirectedFrom(batageljZaversnikGraph)
+	all := BronKerbosch(g)
+
+	got, err := BronKerboschContext(context.Background(), g)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(got) != len(all) {
+		t.Errorf("unexpected number of cliques: got:%d want:%d", len(got), len(all))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	got, err = BronKerboschContext(ctx, g)
+	if err != context.Canceled {
+		t.Errorf("unexpected error for cancelled context: got:%v want:%v", err, context.Canceled)
+	}
+	if len(got) != 0 {
+		t.Errorf("unexpected cliques for cancelled context: %d", len(got))
+	}
+}
@@ -0,0 +1,468 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+
+	"github.com/gonum/graph"
+)
+
+// CycleBasis returns a fundamental cycle basis of the undirected graph g
+// with respect to a breadth-first spanning forest. Each cycle is returned
+// with its first node repeated at the end. Self loops are ignored.
+func CycleBasis(g graph.Undirected) [][]graph.Node {
+	c := newIndexGraph(g)
+	n := len(c.nodes)
+	parent := make([]int, n)
+	depth := make([]int, n)
+	for i := range parent {
+		parent[i] = -2
+	}
+	var cycles [][]graph.Node
+	for root := range c.nodes {
+		if parent[root] != -2 {
+			continue
+		}
+		parent[root] = -1
+		queue := []int{root}
+		for len(queue) != 0 {
+			u := queue[0]
+			queue = queue[1:]
+			for _, v := range c.adj[u] {
+				if parent[v] == -2 {
+					parent[v] = u
+					depth[v] = depth[u] + 1
+					queue = append(queue, v)
+				}
+			}
+		}
+	}
+
+	// Each non-tree edge closes a fundamental cycle
+	// with the tree paths to its end points' lowest
+	// common ancestor.
+	for u, nbrs := range c.adj {
+		for _, v := range nbrs {
+			if v < u || parent[v] == u || parent[u] == v {
+				continue
+			}
+			var left, right []int
+			x, y := u, v
+			for x != y {
+				if depth[x] >= depth[y] {
+					left = append(left, x)
+					x = parent[x]
+				} else {
+					right = append(right, y)
+					y = parent[y]
+				}
+			}
+			cycle := make([]graph.Node, 0, len(left)+len(right)+2)
+			for _, w := range left {
+				cycle = append(cycle, c.nodes[w])
+			}
+			cycle = append(cycle, c.nodes[x])
+			for i := len(right) - 1; i >= 0; i-- {
+				cycle = append(cycle, c.nodes[right[i]])
+			}
+			cycle = append(cycle, c.nodes[u])
+			cycles = append(cycles, cycle)
+		}
+	}
+	return cycles
+}
+
+// MinimumCycleBasis returns a cycle basis of the undirected graph g with the
+// least total weight. If g implements graph.Weighter, its Weight method is
+// used to obtain edge weights, otherwise every edge has unit weight. Each
+// cycle is returned with its first node repeated at the end. Self loops are
+// ignored. MinimumCycleBasis will panic if g has a negative edge weight.
+//
+// The basis is selected greedily from the candidate cycles of Horton,
+// formed from the shortest paths from each node to the ends of each edge.
+//
+//	Horton "A polynomial-time algorithm to find the shortest cycle basis of a
+//	graph" doi:10.1137/0216026
+func MinimumCycleBasis(g graph.Undirected) [][]graph.Node {
+	c := newIndexGraph(g)
+	n := len(c.nodes)
+
+	var weight func(x, y graph.Node) (w float64, ok bool)
+	if wg, ok := g.(graph.Weighter); ok {
+		weight = wg.Weight
+	} else {
+		weight = func(x, y graph.Node) (float64, bool) { return 1, true }
+	}
+	var edges []undirectedEdge
+	var edgeWeight []float64
+	edgeID := make(map[undirectedEdge]int)
+	for u, nbrs := range c.adj {
+		for _, v := range nbrs {
+			if v < u {
+				continue
+			}
+			w, _ := weight(c.nodes[u], c.nodes[v])
+			if w < 0 {
+				panic("topo: negative edge weight")
+			}
+			edgeID[undirectedEdge{u, v}] = len(edges)
+			edgeID[undirectedEdge{v, u}] = len(edges)
+			edges = append(edges, undirectedEdge{u, v})
+			edgeWeight = append(edgeWeight, w)
+		}
+	}
+	dim := len(edges) - n + len(ConnectedComponents(g))
+	if dim == 0 {
+		return nil
+	}
+
+	// Collect the candidate cycles that are simple.
+	var cands []cycleCandidate
+	seen := make(map[string]bool)
+	for root := range c.nodes {
+		parent := c.shortestPathTree(root, edgeWeight, edgeID)
+		for id, e := range edges {
+			if parent[e.u] == -2 || parent[e.v] == -2 {
+				continue
+			}
+			b := newBitset(len(edges))
+			b.toggle(id)
+			for _, x := range []int{e.u, e.v} {
+				for ; parent[x] >= 0; x = parent[x] {
+					b.toggle(edgeID[undirectedEdge{x, parent[x]}])
+				}
+			}
+			key := b.key()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			if !isSimpleCycle(b, edges) {
+				continue
+			}
+			var w float64
+			var size int
+			for id := range edges {
+				if b.has(id) {
+					w += edgeWeight[id]
+					size++
+				}
+			}
+			cands = append(cands, cycleCandidate{edges: b, weight: w, size: size})
+		}
+	}
+	sort.Stable(byCycleWeight(cands))
+
+	// Greedily select linearly independent candidates
+	// over GF(2), keeping a basis of reduced rows keyed
+	// by their lowest set bit.
+	basis := make(map[int]bitset)
+	var cycles [][]graph.Node
+	for _, cand := range cands {
+		r := cand.edges.clone()
+		for {
+			p := r.lowest()
+			if p < 0 {
+				break
+			}
+			row, ok := basis[p]
+			if !ok {
+				basis[p] = r
+				cycles = append(cycles, c.cycleNodes(cand.edges, edges))
+				break
+			}
+			r.xor(row)
+		}
+		if len(cycles) == dim {
+			break
+		}
+	}
+	return cycles
+}
+
+// Girth returns the number of edges in a shortest cycle of the undirected
+// graph g. Girth returns zero if g has no cycles. Self loops are ignored.
+func Girth(g graph.Undirected) int {
+	c := newIndexGraph(g)
+	var girth int
+	for u := range c.nodes {
+		if cycle := c.shortestCycleThrough(u); cycle != nil {
+			if k := len(cycle); girth == 0 || k < girth {
+				girth = k
+			}
+		}
+	}
+	return girth
+}
+
+// ShortestCycleThrough returns a cycle of the undirected graph g through the
+// node n with the fewest edges, with n as its first and last node. It returns
+// nil if no cycle passes through n. Self loops are ignored.
+func ShortestCycleThrough(g graph.Undirected, n graph.Node) []graph.Node {
+	c := newIndexGraph(g)
+	u, ok := c.indexOf[n.ID()]
+	if !ok {
+		return nil
+	}
+	cycle := c.shortestCycleThrough(u)
+	if cycle == nil {
+		return nil
+	}
+	nodes := make([]graph.Node, len(cycle)+1)
+	for i, v := range cycle {
+		nodes[i] = c.nodes[v]
+	}
+	nodes[len(cycle)] = n
+	return nodes
+}
+
+// shortestCycleThrough returns the nodes of a shortest cycle through
+// u, starting at u, or nil if there is no cycle through u.
+func (c indexGraph) shortestCycleThrough(u int) []int {
+	// A breadth-first search from u labels each node with
+	// the branch of the search tree that reached it. The
+	// shortest cycle through u is closed by an edge joining
+	// two branches with the least total depth.
+	n := len(c.nodes)
+	parent := make([]int, n)
+	depth := make([]int, n)
+	branch := make([]int, n)
+	for i := range parent {
+		parent[i] = -2
+	}
+	parent[u] = -1
+	branch[u] = u
+	queue := []int{u}
+	bestX, bestY, best := -1, -1, math.MaxInt64
+	for len(queue) != 0 {
+		x := queue[0]
+		queue = queue[1:]
+		if 2*depth[x]+1 >= best {
+			break
+		}
+		for _, y := range c.adj[x] {
+			switch {
+			case parent[y] == -2:
+				parent[y] = x
+				depth[y] = depth[x] + 1
+				if x == u {
+					branch[y] = y
+				} else {
+					branch[y] = branch[x]
+				}
+				queue = append(queue, y)
+			case y != u && x != u && branch[x] != branch[y]:
+				if k := depth[x] + depth[y] + 1; k < best {
+					bestX, bestY, best = x, y, k
+				}
+			}
+		}
+	}
+	if bestX < 0 {
+		return nil
+	}
+	var left, right []int
+	for x := bestX; x != u; x = parent[x] {
+		left = append(left, x)
+	}
+	for y := bestY; y != u; y = parent[y] {
+		right = append(right, y)
+	}
+	cycle := []int{u}
+	for i := len(left) - 1; i >= 0; i-- {
+		cycle = append(cycle, left[i])
+	}
+	return append(cycle, right...)
+}
+
+// shortestPathTree returns the parent of each node in a shortest path tree
+// from root, with -1 for the root and -2 for unreachable nodes.
+func (c indexGraph) shortestPathTree(root int, weight []float64, edgeID map[undirectedEdge]int) []int {
+	n := len(c.nodes)
+	dist := make([]float64, n)
+	parent := make([]int, n)
+	for i := range dist {
+		dist[i] = math.Inf(1)
+		parent[i] = -2
+	}
+	dist[root] = 0
+	parent[root] = -1
+	q := &distanceQueue{{node: root}}
+	for q.Len() != 0 {
+		mid := heap.Pop(q).(distanceItem)
+		if mid.dist > dist[mid.node] {
+			continue
+		}
+		for _, v := range c.adj[mid.node] {
+			d := dist[mid.node] + weight[edgeID[undirectedEdge{mid.node, v}]]
+			if d < dist[v] {
+				dist[v] = d
+				parent[v] = mid.node
+				heap.Push(q, distanceItem{node: v, dist: d})
+			}
+		}
+	}
+	return parent
+}
+
+// cycleNodes returns the nodes of the simple cycle with the given edge set,
+// starting from its lowest indexed node and with that node repeated at the
+// end.
+func (c indexGraph) cycleNodes(b bitset, edges []undirectedEdge) []graph.Node {
+	nbrs := make(map[int][]int)
+	for id, e := range edges {
+		if b.has(id) {
+			nbrs[e.u] = append(nbrs[e.u], e.v)
+			nbrs[e.v] = append(nbrs[e.v], e.u)
+		}
+	}
+	start := -1
+	for u := range nbrs {
+		if start < 0 || u < start {
+			start = u
+		}
+	}
+	next := nbrs[start][0]
+	if nbrs[start][1] < next {
+		next = nbrs[start][1]
+	}
+	cycle := []graph.Node{c.nodes[start]}
+	for prev, u := start, next; u != start; {
+		cycle = append(cycle, c.nodes[u])
+		w := nbrs[u][0]
+		if w == prev {
+			w = nbrs[u][1]
+		}
+		prev, u = u, w
+	}
+	return append(cycle, c.nodes[start])
+}
+
+// isSimpleCycle returns whether the edge set b forms a single simple cycle.
+func isSimpleCycle(b bitset, edges []undirectedEdge) bool {
+	degree := make(map[int]int)
+	parent := make(map[int]int)
+	var find func(int) int
+	find = func(x int) int {
+		if parent[x] != x {
+			parent[x] = find(parent[x])
+		}
+		return parent[x]
+	}
+	var count int
+	for id, e := range edges {
+		if !b.has(id) {
+			continue
+		}
+		count++
+		for _, x := range []int{e.u, e.v} {
+			degree[x]++
+			if _, ok := parent[x]; !ok {
+				parent[x] = x
+			}
+		}
+		parent[find(e.u)] = find(e.v)
+	}
+	if count == 0 {
+		return false
+	}
+	root := -1
+	for x, d := range degree {
+		if d != 2 {
+			return false
+		}
+		r := find(x)
+		if root >= 0 && r != root {
+			return false
+		}
+		root = r
+	}
+	return true
+}
+
+// undirectedEdge is an edge between two node indices.
+type undirectedEdge struct{ u, v int }
+
+// cycleCandidate is a candidate cycle for a minimum cycle basis.
+type cycleCandidate struct {
+	edges  bitset
+	weight float64
+	size   int
+}
+
+// byCycleWeight sorts cycle candidates by weight and then by size.
+type byCycleWeight []cycleCandidate
+
+func (c byCycleWeight) Len() int { return len(c) }
+func (c byCycleWeight) Less(i, j int) bool {
+	if c[i].weight != c[j].weight {
+		return c[i].weight < c[j].weight
+	}
+	return c[i].size < c[j].size
+}
+func (c byCycleWeight) Swap(i, j int) { c[i], c[j] = c[j], c[i] }
+
+// bitset is a set of small non-negative integers.
+type bitset []uint64
+
+func newBitset(n int) bitset { return make(bitset, (n+63)/64) }
+
+func (b bitset) has(i int) bool { return b[i/64]&(1<<uint(i%64)) != 0 }
+func (b bitset) toggle(i int)   { b[i/64] ^= 1 << uint(i%64) }
+
+func (b bitset) xor(a bitset) {
+	for i := range b {
+		b[i] ^= a[i]
+	}
+}
+
+func (b bitset) clone() bitset { return append(bitset(nil), b...) }
+
+// lowest returns the least element of b, or -1 if b is empty.
+func (b bitset) lowest() int {
+	for i, w := range b {
+		if w != 0 {
+			for j := 0; j < 64; j++ {
+				if w&(1<<uint(j)) != 0 {
+					return i*64 + j
+				}
+			}
+		}
+	}
+	return -1
+}
+
+func (b bitset) key() string {
+	k := make([]byte, 0, 8*len(b))
+	for _, w := range b {
+		for j := uint(0); j < 64; j += 8 {
+			k = append(k, byte(w>>j))
+		}
+	}
+	return string(k)
+}
+
+// distanceItem is a node and its tentative shortest path distance.
+type distanceItem struct {
+	node int
+	dist float64
+}
+
+// distanceQueue is a priority queue of nodes ordered by distance.
+type distanceQueue []distanceItem
+
+func (q distanceQueue) Len() int            { return len(q) }
+func (q distanceQueue) Less(i, j int) bool  { return q[i].dist < q[j].dist }
+func (q distanceQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *distanceQueue) Push(x interface{}) { *q = append(*q, x.(distanceItem)) }
+func (q *distanceQueue) Pop() interface{} {
+	t := *q
+	var x distanceItem
+	x, *q = t[len(t)-1], t[:len(t)-1]
+	return x
+}
@@ -0,0 +1,330 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+var (
+	// cube is the cube graph Q₃.
+	cube = []intset{
+		0: linksTo(1, 3, 4),
+		1: linksTo(2, 5),
+		2: linksTo(3, 6),
+		3: linksTo(7),
+		4: linksTo(5, 7),
+		5: linksTo(6),
+		6: linksTo(7),
+	}
+
+	// naphthalene is the carbon skeleton of naphthalene,
+	// two hexagons sharing the edge 0-5.
+	naphthalene = []intset{
+		0: linksTo(1, 5, 6),
+		1: linksTo(2),
+		2: linksTo(3),
+		3: linksTo(4),
+		4: linksTo(5),
+		5: linksTo(9),
+		6: linksTo(7),
+		7: linksTo(8),
+		8: linksTo(9),
+	}
+)
+
+var cycleBasisTests = []struct {
+	name   string
+	g      []intset
+	girth  int
+	weight float64 // Total length of a minimum cycle basis, or -1 if not known.
+}{
+	{name: "empty", g: nil, girth: 0, weight: 0},
+	{name: "tree", g: chordalityTests[7].g, girth: 0, weight: 0},
+	{name: "K4", g: chordalityTests[2].g, girth: 3, weight: 9},
+	{name: "C5", g: colouringTests[2].g, girth: 5, weight: 5},
+	{name: "cube", g: cube, girth: 4, weight: 20},
+	{name: "naphthalene", g: naphthalene, girth: 6, weight: 12},
+	{name: "Petersen", g: colouringTests[5].g, girth: 5, weight: 30},
+	{name: "K3,3", g: planarityTests[4].g, girth: 4, weight: 16},
+	{name: "Grötzsch", g: colouringTests[6].g, girth: 4, weight: -1},
+	{name: "two triangles and a square", g: chordalityTests[9].g, girth: 3, weight: 10},
+}
+
+func TestCycleBasis(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	tests := cycleBasisTests
+	for i := 0; i < 20; i++ {
+		tests = append(tests, struct {
+			name   string
+			g      []intset
+			girth  int
+			weight float64
+		}{name: fmt.Sprintf("random %d", i), g: gnpAdjacency(rnd, 9, 0.35), weight: -1})
+	}
+	for _, test := range tests {
+		g := undirectedFrom(test.g)
+		dim := cycleSpaceDimension(g)
+
+		basis := CycleBasis(g)
+		checkCycleBasis(t, test.name+" fundamental", g, basis, dim)
+
+		min := MinimumCycleBasis(g)
+		checkCycleBasis(t, test.name+" minimum", g, min, dim)
+		var weight float64
+		for _, c := range min {
+			weight += float64(len(c) - 1)
+		}
+		want := test.weight
+		if want < 0 {
+			want = bruteForceMinimumCycleBasisWeight(g)
+		}
+		if weight != want {
+			t.Errorf("unexpected minimum cycle basis weight for %q: got:%v want:%v", test.name, weight, want)
+		}
+		var fundamental float64
+		for _, c := range basis {
+			fundamental += float64(len(c) - 1)
+		}
+		if fundamental < weight {
+			t.Errorf("fundamental cycle basis lighter than minimum for %q: %v < %v", test.name, fundamental, weight)
+		}
+	}
+}
+
+func TestMinimumCycleBasisWeighted(t *testing.T) {
+	// A square with a diagonal. With unit weights the minimum
+	// basis is the two triangles, but a heavy diagonal makes
+	// the square part of the minimum basis.
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.Edge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: 1},
+		{F: simple.Node(2), T: simple.Node(3), W: 1},
+		{F: simple.Node(3), T: simple.Node(0), W: 1},
+		{F: simple.Node(0), T: simple.Node(2), W: 10},
+	} {
+		g.SetEdge(e)
+	}
+	basis := MinimumCycleBasis(g)
+	checkCycleBasis(t, "weighted square", g, basis, 2)
+	var hasSquare bool
+	for _, c := range basis {
+		if len(c) == 5 {
+			hasSquare = true
+		}
+	}
+	if !hasSquare {
+		t.Errorf("minimum cycle basis of weighted square does not contain the square: %v", basis)
+	}
+}
+
+func TestGirth(t *testing.T) {
+	for _, test := range cycleBasisTests {
+		g := undirectedFrom(test.g)
+		if got := Girth(g); got != test.girth {
+			t.Errorf("unexpected girth for %q: got:%d want:%d", test.name, got, test.girth)
+		}
+	}
+}
+
+func TestShortestCycleThrough(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	var tests []struct {
+		name string
+		g    graph.Undirected
+	}
+	for _, test := range cycleBasisTests {
+		tests = append(tests, struct {
+			name string
+			g    graph.Undirected
+		}{name: test.name, g: undirectedFrom(test.g)})
+	}
+	for i := 0; i < 20; i++ {
+		tests = append(tests, struct {
+			name string
+			g    graph.Undirected
+		}{name: fmt.Sprintf("random %d", i), g: undirectedFrom(gnpAdjacency(rnd, 9, 0.3))})
+	}
+	for _, test := range tests {
+		cycles := simpleCycles(test.g)
+		for _, n := range test.g.Nodes() {
+			want := 0
+			for _, c := range cycles {
+				for _, u := range c {
+					if u.ID() == n.ID() && (want == 0 || len(c)-1 < want) {
+						want = len(c) - 1
+					}
+				}
+			}
+			got := ShortestCycleThrough(test.g, n)
+			if want == 0 {
+				if got != nil {
+					t.Errorf("unexpected cycle through %d for %q: %v", n.ID(), test.name, ids(got))
+				}
+				continue
+			}
+			if len(got)-1 != want {
+				t.Errorf("unexpected shortest cycle length through %d for %q: got:%d want:%d", n.ID(), test.name, len(got)-1, want)
+				continue
+			}
+			if got[0].ID() != n.ID() || got[len(got)-1].ID() != n.ID() {
+				t.Errorf("cycle through %d for %q does not start and end at it: %v", n.ID(), test.name, ids(got))
+			}
+			checkCycle(t, test.name, test.g, got)
+		}
+	}
+}
+
+// cycleSpaceDimension returns the dimension of the cycle space of g.
+func cycleSpaceDimension(g graph.Undirected) int {
+	var edges int
+	for _, n := range g.Nodes() {
+		edges += len(g.From(n))
+	}
+	return edges/2 - len(g.Nodes()) + len(ConnectedComponents(g))
+}
+
+// checkCycleBasis checks that basis is a set of dim independent cycles of g.
+func checkCycleBasis(t *testing.T, name string, g graph.Undirected, basis [][]graph.Node, dim int) {
+	if len(basis) != dim {
+		t.Errorf("unexpected cycle basis size for %q: got:%d want:%d", name, len(basis), dim)
+	}
+	var rows []map[[2]int]bool
+	for _, c := range basis {
+		checkCycle(t, name, g, c)
+		rows = append(rows, cycleEdges(c))
+	}
+	if rank(rows) != len(rows) {
+		t.Errorf("cycles in basis for %q are not independent", name)
+	}
+}
+
+// checkCycle checks that c is a closed simple cycle in g.
+func checkCycle(t *testing.T, name string, g graph.Undirected, c []graph.Node) {
+	if len(c) < 4 || c[0].ID() != c[len(c)-1].ID() {
+		t.Errorf("invalid cycle for %q: %v", name, ids(c))
+		return
+	}
+	seen := make(map[int]bool)
+	for i, u := range c[:len(c)-1] {
+		if seen[u.ID()] {
+			t.Errorf("cycle for %q is not simple: %v", name, ids(c))
+		}
+		seen[u.ID()] = true
+		if !g.HasEdgeBetween(u, c[i+1]) {
+			t.Errorf("cycle for %q uses non-edge %d-%d: %v", name, u.ID(), c[i+1].ID(), ids(c))
+		}
+	}
+}
+
+func cycleEdges(c []graph.Node) map[[2]int]bool {
+	e := make(map[[2]int]bool)
+	for i := range c[:len(c)-1] {
+		u, v := c[i].ID(), c[i+1].ID()
+		if u > v {
+			u, v = v, u
+		}
+		e[[2]int{u, v}] = true
+	}
+	return e
+}
+
+// rank returns the rank of the edge sets over GF(2).
+func rank(rows []map[[2]int]bool) int {
+	var basis []map[[2]int]bool
+	for _, r := range rows {
+		r = xorEdges(r, nil)
+		for _, b := range basis {
+			if r[pivot(b)] {
+				r = xorEdges(r, b)
+			}
+		}
+		if len(r) != 0 {
+			for i, b := range basis {
+				if b[pivot(r)] {
+					basis[i] = xorEdges(b, r)
+				}
+			}
+			basis = append(basis, r)
+		}
+	}
+	return len(basis)
+}
+
+func pivot(r map[[2]int]bool) [2]int {
+	var p [2]int
+	first := true
+	for e := range r {
+		if first || e[0] < p[0] || (e[0] == p[0] && e[1] < p[1]) {
+			p = e
+			first = false
+		}
+	}
+	return p
+}
+
+func xorEdges(a, b map[[2]int]bool) map[[2]int]bool {
+	r := make(map[[2]int]bool)
+	for e := range a {
+		r[e] = true
+	}
+	for e := range b {
+		if r[e] {
+			delete(r, e)
+		} else {
+			r[e] = true
+		}
+	}
+	return r
+}
+
+// simpleCycles returns the simple cycles of g with at least three nodes,
+// each closed and in both orientations.
+func simpleCycles(g graph.Undirected) [][]graph.Node {
+	d := simple.NewDirectedGraph(0, math.Inf(1))
+	for _, u := range g.Nodes() {
+		if !d.Has(u) {
+			d.AddNode(u)
+		}
+		for _, v := range g.From(u) {
+			d.SetEdge(simple.Edge{F: u, T: v})
+		}
+	}
+	var cycles [][]graph.Node
+	for _, c := range CyclesIn(d) {
+		if len(c) > 3 {
+			cycles = append(cycles, c)
+		}
+	}
+	return cycles
+}
+
+// bruteForceMinimumCycleBasisWeight returns the total length of a minimum
+// cycle basis of g by greedy selection from all simple cycles.
+func bruteForceMinimumCycleBasisWeight(g graph.Undirected) float64 {
+	cycles := simpleCycles(g)
+	for i := 1; i < len(cycles); i++ {
+		for j := i; j > 0 && len(cycles[j]) < len(cycles[j-1]); j-- {
+			cycles[j], cycles[j-1] = cycles[j-1], cycles[j]
+		}
+	}
+	var rows []map[[2]int]bool
+	var weight float64
+	for _, c := range cycles {
+		next := append(rows[:len(rows):len(rows)], cycleEdges(c))
+		if rank(next) == len(next) {
+			rows = next
+			weight += float64(len(c) - 1)
+		}
+	}
+	return weight
+}
@@ -0,0 +1,333 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"sort"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+	"github.com/gonum/graph/internal/set"
+)
+
+// Layers returns a layering of the directed acyclic graph g where each node
+// is placed in the earliest layer that follows the layers of all the nodes
+// with edges to it. Nodes within a layer have no edges between them and so
+// may be processed in parallel once the preceding layers are complete. The
+// nodes of each layer are sorted by ID. If g is not acyclic, Layers returns
+// nil and an Unorderable error listing the cyclic components of g.
+func Layers(g graph.Directed) ([][]graph.Node, error) {
+	sorted, err := SortStabilized(g, nil)
+	if err != nil {
+		return nil, err
+	}
+	level := make(map[int]int, len(sorted))
+	var layers [][]graph.Node
+	for _, n := range sorted {
+		var l int
+		for _, p := range g.To(n) {
+			if level[p.ID()]+1 > l {
+				l = level[p.ID()] + 1
+			}
+		}
+		level[n.ID()] = l
+		if l == len(layers) {
+			layers = append(layers, nil)
+		}
+		layers[l] = append(layers[l], n)
+	}
+	for _, l := range layers {
+		sort.Sort(ordered.ByID(l))
+	}
+	return layers, nil
+}
+
+// CoffmanGraham returns a layering of the directed acyclic graph g with at most
+// width nodes in each layer, using the Coffman-Graham algorithm. Every edge of
+// g leads from an earlier layer to a later layer. Each node is placed in the
+// lowest layer above all its successors that has room. When width is two the
+// number of layers is the minimum possible. The nodes of each layer are sorted
+// by ID. If g is not acyclic, CoffmanGraham returns nil and
+// an Unorderable error listing the cyclic components of g. CoffmanGraham will
+// panic if width is less than one.
+//
+// The algorithm is described in:
+//
+// Coffman and Graham, "Optimal scheduling for two-processor systems", Acta
+// Informatica 1 (1972).
+func CoffmanGraham(g graph.Directed, width int) ([][]graph.Node, error) {
+	if width < 1 {
+		panic("topo: non-positive layer width")
+	}
+	sorted, err := SortStabilized(g, nil)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]graph.Node, len(sorted))
+	copy(nodes, sorted)
+	sort.Sort(ordered.ByID(nodes))
+	succ, pred := transitiveReduction(g, nodes)
+
+	// Label the nodes so that each node's label is larger than
+	// its predecessors', preferring nodes whose predecessors have
+	// the lexicographically smallest decreasing sequence of labels.
+	label := make(map[int]int, len(nodes))
+	order := make([]graph.Node, 0, len(nodes))
+	keys := make(map[int][]int, len(nodes))
+	for len(order) < len(nodes) {
+		var (
+			best    graph.Node
+			bestKey []int
+		)
+	candidates:
+		for _, n := range nodes {
+			if _, ok := label[n.ID()]; ok {
+				continue
+			}
+			for _, p := range pred[n.ID()] {
+				if _, ok := label[p.ID()]; !ok {
+					continue candidates
+				}
+			}
+			key, ok := keys[n.ID()]
+			if !ok {
+				for _, p := range pred[n.ID()] {
+					key = append(key, label[p.ID()])
+				}
+				sort.Sort(sort.Reverse(sort.IntSlice(key)))
+				keys[n.ID()] = key
+			}
+			if best == nil || lexLess(key, bestKey) {
+				best = n
+				bestKey = key
+			}
+		}
+		label[best.ID()] = len(order)
+		order = append(order, best)
+	}
+
+	// Fill layers from the sinks, taking nodes in decreasing
+	// label order and placing each node in the lowest layer
+	// above all its successors that has room.
+	layer := make(map[int]int, len(nodes))
+	var layers [][]graph.Node
+	for i := len(order) - 1; i >= 0; i-- {
+		n := order[i]
+		cur := 0
+		for _, s := range succ[n.ID()] {
+			if l := layer[s.ID()] + 1; l > cur {
+				cur = l
+			}
+		}
+		for cur < len(layers) && len(layers[cur]) == width {
+			cur++
+		}
+		if cur == len(layers) {
+			layers = append(layers, nil)
+		}
+		layer[n.ID()] = cur
+		layers[cur] = append(layers[cur], n)
+	}
+	for i, j := 0, len(layers)-1; i < j; i, j = i+1, j-1 {
+		layers[i], layers[j] = layers[j], layers[i]
+	}
+	for _, l := range layers {
+		sort.Sort(ordered.ByID(l))
+	}
+	return layers, nil
+}
+
+// lexLess returns whether a is lexicographically less than b.
+func lexLess(a, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// transitiveReduction returns the successors and predecessors of the
+// nodes of the directed acyclic graph g after removing every edge u->v
+// for which there is another path from u to v.
+func transitiveReduction(g graph.Directed, nodes []graph.Node) (succ, pred map[int][]graph.Node) {
+	succ = make(map[int][]graph.Node, len(nodes))
+	pred = make(map[int][]graph.Node, len(nodes))
+	for _, u := range nodes {
+		// Find the nodes reachable from u by
+		// paths of two or more edges.
+		far := make(set.Ints)
+		var stack []graph.Node
+		for _, v := range g.From(u) {
+			stack = append(stack, g.From(v)...)
+		}
+		for len(stack) != 0 {
+			n := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if far.Has(n.ID()) {
+				continue
+			}
+			far.Add(n.ID())
+			stack = append(stack, g.From(n)...)
+		}
+		for _, v := range g.From(u) {
+			if !far.Has(v.ID()) {
+				succ[u.ID()] = append(succ[u.ID()], v)
+				pred[v.ID()] = append(pred[v.ID()], u)
+			}
+		}
+	}
+	return succ, pred
+}
+
+// LongestPath returns a longest path in the directed acyclic graph g and its
+// length. If g implements graph.Weighter, its Weight method is used to obtain
+// edge weights, otherwise every edge has unit weight. A path may start at any
+// node, so a path with negative length is never returned. When more than one
+// longest path exists, the path ending earliest in the stabilized topological
+// ordering of g is returned. If g is not acyclic, LongestPath returns an
+// Unorderable error listing the cyclic components of g.
+func LongestPath(g graph.Directed) (path []graph.Node, length float64, err error) {
+	sorted, err := SortStabilized(g, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(sorted) == 0 {
+		return nil, 0, nil
+	}
+	weight := edgeWeightFunc(g, 1)
+
+	dist := make(map[int]float64, len(sorted))
+	prev := make(map[int]graph.Node)
+	end := sorted[0]
+	for _, v := range sorted {
+		preds := g.To(v)
+		sort.Sort(ordered.ByID(preds))
+		for _, u := range preds {
+			if d := dist[u.ID()] + weight(u, v); d > dist[v.ID()] {
+				dist[v.ID()] = d
+				prev[v.ID()] = u
+			}
+		}
+		if dist[v.ID()] > dist[end.ID()] {
+			end = v
+		}
+	}
+
+	for n := end; n != nil; n = prev[n.ID()] {
+		path = append(path, n)
+	}
+	reverse(path)
+	return path, dist[end.ID()], nil
+}
+
+// CriticalPathAnalysis holds the result of a critical path analysis of a
+// directed acyclic graph of tasks.
+type CriticalPathAnalysis struct {
+	// Path is a critical path through the graph:
+	// a chain of tasks none of which may be
+	// delayed without delaying the project.
+	Path []graph.Node
+
+	// Length is the minimum total duration
+	// of the project.
+	Length float64
+
+	// EarliestStart, LatestStart and Slack hold the
+	// earliest and latest start times of each task and
+	// the difference between them, keyed by node ID.
+	EarliestStart map[int]float64
+	LatestStart   map[int]float64
+	Slack         map[int]float64
+}
+
+// CriticalPath performs a critical path analysis of the directed acyclic graph
+// g, where nodes are tasks and an edge from u to v indicates that v may not
+// start until u has finished. The duration of each task is given by duration;
+// if duration is nil, tasks take no time. If g implements graph.Weighter, its
+// Weight method gives an additional delay between the end of u and the start
+// of v, otherwise edges have no delay. If g is not acyclic, CriticalPath returns
+// an Unorderable error listing the cyclic components of g.
+func CriticalPath(g graph.Directed, duration func(graph.Node) float64) (CriticalPathAnalysis, error) {
+	sorted, err := SortStabilized(g, nil)
+	if err != nil {
+		return CriticalPathAnalysis{}, err
+	}
+	if duration == nil {
+		duration = func(graph.Node) float64 { return 0 }
+	}
+	weight := edgeWeightFunc(g, 0)
+
+	cpa := CriticalPathAnalysis{
+		EarliestStart: make(map[int]float64, len(sorted)),
+		LatestStart:   make(map[int]float64, len(sorted)),
+		Slack:         make(map[int]float64, len(sorted)),
+	}
+	if len(sorted) == 0 {
+		return cpa, nil
+	}
+
+	finish := make(map[int]float64, len(sorted))
+	var end graph.Node
+	for _, v := range sorted {
+		var es float64
+		for i, u := range g.To(v) {
+			t := finish[u.ID()] + weight(u, v)
+			if i == 0 || t > es {
+				es = t
+			}
+		}
+		cpa.EarliestStart[v.ID()] = es
+		finish[v.ID()] = es + duration(v)
+		if end == nil || finish[v.ID()] > cpa.Length {
+			end = v
+			cpa.Length = finish[v.ID()]
+		}
+	}
+
+	for i := len(sorted) - 1; i >= 0; i-- {
+		v := sorted[i]
+		lf := cpa.Length
+		for _, w := range g.From(v) {
+			t := cpa.LatestStart[w.ID()] - weight(v, w)
+			if t < lf {
+				lf = t
+			}
+		}
+		ls := lf - duration(v)
+		cpa.LatestStart[v.ID()] = ls
+		cpa.Slack[v.ID()] = ls - cpa.EarliestStart[v.ID()]
+	}
+
+	// Trace back from the last task to finish through the
+	// predecessors that determined each earliest start time.
+	for n := end; n != nil; {
+		cpa.Path = append(cpa.Path, n)
+		var next graph.Node
+		preds := g.To(n)
+		sort.Sort(ordered.ByID(preds))
+		for _, u := range preds {
+			if finish[u.ID()]+weight(u, n) == cpa.EarliestStart[n.ID()] {
+				next = u
+				break
+			}
+		}
+		n = next
+	}
+	reverse(cpa.Path)
+	return cpa, nil
+}
+
+// edgeWeightFunc returns a function returning the weight of the edge
+// from u to v in g, if g is a graph.Weighter, or def otherwise.
+func edgeWeightFunc(g graph.Graph, def float64) func(u, v graph.Node) float64 {
+	if wg, ok := g.(graph.Weighter); ok {
+		return func(u, v graph.Node) float64 {
+			w, _ := wg.Weight(u, v)
+			return w
+		}
+	}
+	return func(u, v graph.Node) float64 { return def }
+}
@@ -0,0 +1,348 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+// directedFrom returns a directed graph with unit edge weights
+// built from the adjacency list adj.
+func directedFrom(adj []intset) *simple.DirectedGraph {
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	for u, e := range adj {
+		if !g.Has(simple.Node(u)) {
+			g.AddNode(simple.Node(u))
+		}
+		for v := range e {
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v), W: 1})
+		}
+	}
+	return g
+}
+
+var layersTests = []struct {
+	name string
+	g    []intset
+
+	want        [][]int
+	wantErr     bool
+	coffmanW    int
+	wantCoffman [][]int
+}{
+	{name: "empty", g: nil, want: nil, coffmanW: 1, wantCoffman: nil},
+	{
+		name: "diamond",
+		g: []intset{
+			0: linksTo(1, 2),
+			1: linksTo(3),
+			2: linksTo(3),
+			3: linksTo(4),
+			5: nil,
+		},
+		want:        [][]int{{0, 5}, {1, 2}, {3}, {4}},
+		coffmanW:    1,
+		wantCoffman: [][]int{{0}, {5}, {1}, {2}, {3}, {4}},
+	},
+	{
+		name: "transitive",
+		g: []intset{
+			0: linksTo(1, 2),
+			1: linksTo(2),
+		},
+		want:        [][]int{{0}, {1}, {2}},
+		coffmanW:    2,
+		wantCoffman: [][]int{{0}, {1}, {2}},
+	},
+	{
+		name:        "antichain",
+		g:           []intset{0: nil, 1: nil, 2: nil, 3: nil, 4: nil},
+		want:        [][]int{{0, 1, 2, 3, 4}},
+		coffmanW:    2,
+		wantCoffman: [][]int{{0}, {1, 2}, {3, 4}},
+	},
+	{
+		name: "lower layer with room",
+		g: []intset{
+			0: linksTo(1, 8),
+			1: linksTo(5, 8),
+			2: linksTo(0, 5),
+			3: linksTo(5),
+			4: linksTo(8),
+			5: linksTo(8),
+			6: nil,
+			7: linksTo(0),
+			8: nil,
+		},
+		want:        [][]int{{2, 3, 4, 6, 7}, {0}, {1}, {5}, {8}},
+		coffmanW:    2,
+		wantCoffman: [][]int{{2, 7}, {0}, {1, 3}, {4, 5}, {6, 8}},
+	},
+	{
+		name: "cycle",
+		g: []intset{
+			0: linksTo(1),
+			1: linksTo(2),
+			2: linksTo(0),
+		},
+		wantErr:  true,
+		coffmanW: 1,
+	},
+}
+
+func TestLayers(t *testing.T) {
+	for _, test := range layersTests {
+		g := directedFrom(test.g)
+		layers, err := Layers(g)
+		if (err != nil) != test.wantErr {
+			t.Errorf("unexpected error for %q: %v", test.name, err)
+			continue
+		}
+		if _, ok := err.(Unorderable); err != nil && !ok {
+			t.Errorf("unexpected error type for %q: %T", test.name, err)
+		}
+		if got := layerIDs(layers); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected layers for %q: got:%v want:%v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestCoffmanGraham(t *testing.T) {
+	for _, test := range layersTests {
+		g := directedFrom(test.g)
+		layers, err := CoffmanGraham(g, test.coffmanW)
+		if (err != nil) != test.wantErr {
+			t.Errorf("unexpected error for %q: %v", test.name, err)
+			continue
+		}
+		if got := layerIDs(layers); !reflect.DeepEqual(got, test.wantCoffman) {
+			t.Errorf("unexpected layers for %q: got:%v want:%v", test.name, got, test.wantCoffman)
+		}
+	}
+}
+
+func TestCoffmanGrahamRandom(t *testing.T) {
+	const n = 30
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		adj := make([]intset, n)
+		for u := 0; u < n; u++ {
+			adj[u] = make(intset)
+			for v := u + 1; v < n; v++ {
+				if rnd.Float64() < 0.1 {
+					adj[u][v] = struct{}{}
+				}
+			}
+		}
+		g := directedFrom(adj)
+		for width := 1; width <= 4; width++ {
+			layers, err := CoffmanGraham(g, width)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			checkLayering(t, g, layers, width)
+		}
+		layers, _ := Layers(g)
+		checkLayering(t, g, layers, n)
+	}
+}
+
+func TestCoffmanGrahamOptimal(t *testing.T) {
+	// Coffman-Graham layering is optimal for width two.
+	const n = 9
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		adj := make([]intset, n)
+		for u := 0; u < n; u++ {
+			adj[u] = make(intset)
+		}
+		perm := rnd.Perm(n)
+		for u := 0; u < n; u++ {
+			for v := u + 1; v < n; v++ {
+				if rnd.Float64() < 0.25 {
+					adj[perm[u]][perm[v]] = struct{}{}
+				}
+			}
+		}
+		g := directedFrom(adj)
+		layers, err := CoffmanGraham(g, 2)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkLayering(t, g, layers, 2)
+		if want := minLayers(adj, 2); len(layers) != want {
+			t.Errorf("unexpected number of layers for %v: got:%d want:%d", adj, len(layers), want)
+		}
+	}
+}
+
+// minLayers returns the minimum number of layers of at most width
+// nodes needed to layer the directed acyclic graph adj, by breadth
+// first search over the sets of nodes already placed.
+func minLayers(adj []intset, width int) int {
+	n := len(adj)
+	var pred = make([]int, n)
+	for u, e := range adj {
+		for v := range e {
+			pred[v] |= 1 << uint(u)
+		}
+	}
+	all := 1<<uint(n) - 1
+	depth := map[int]int{0: 0}
+	queue := []int{0}
+	for len(queue) != 0 {
+		placed := queue[0]
+		queue = queue[1:]
+		if placed == all {
+			return depth[placed]
+		}
+		var ready []int
+		for v := 0; v < n; v++ {
+			if placed&(1<<uint(v)) == 0 && pred[v]&placed == pred[v] {
+				ready = append(ready, v)
+			}
+		}
+		for sub := 1; sub < 1<<uint(len(ready)); sub++ {
+			var layer, size int
+			for j, v := range ready {
+				if sub&(1<<uint(j)) != 0 {
+					layer |= 1 << uint(v)
+					size++
+				}
+			}
+			if size > width {
+				continue
+			}
+			if _, ok := depth[placed|layer]; !ok {
+				depth[placed|layer] = depth[placed] + 1
+				queue = append(queue, placed|layer)
+			}
+		}
+	}
+	panic("topo: cyclic graph")
+}
+
+func checkLayering(t *testing.T, g graph.Directed, layers [][]graph.Node, width int) {
+	layer := make(map[int]int)
+	for i, l := range layers {
+		if len(l) > width {
+			t.Errorf("layer %d exceeds width %d: %d nodes", i, width, len(l))
+		}
+		for _, n := range l {
+			if _, ok := layer[n.ID()]; ok {
+				t.Errorf("node %d in more than one layer", n.ID())
+			}
+			layer[n.ID()] = i
+		}
+	}
+	if len(layer) != len(g.Nodes()) {
+		t.Errorf("unexpected number of layered nodes: got:%d want:%d", len(layer), len(g.Nodes()))
+	}
+	for _, u := range g.Nodes() {
+		for _, v := range g.From(u) {
+			if layer[u.ID()] >= layer[v.ID()] {
+				t.Errorf("edge %d->%d does not lead to a later layer", u.ID(), v.ID())
+			}
+		}
+	}
+}
+
+func layerIDs(layers [][]graph.Node) [][]int {
+	if layers == nil {
+		return nil
+	}
+	l := make([][]int, len(layers))
+	for i, nodes := range layers {
+		l[i] = ids(nodes)
+	}
+	return l
+}
+
+func TestLongestPath(t *testing.T) {
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.Edge{
+		{F: simple.Node(0), T: simple.Node(1), W: 2},
+		{F: simple.Node(0), T: simple.Node(2), W: 1},
+		{F: simple.Node(1), T: simple.Node(3), W: 1},
+		{F: simple.Node(2), T: simple.Node(3), W: 5},
+		{F: simple.Node(3), T: simple.Node(4), W: 1},
+		{F: simple.Node(1), T: simple.Node(4), W: 3},
+	} {
+		g.SetEdge(e)
+	}
+	path, length, err := LongestPath(g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []int{0, 2, 3, 4}; !reflect.DeepEqual(ids(path), want) {
+		t.Errorf("unexpected longest path: got:%v want:%v", ids(path), want)
+	}
+	if length != 7 {
+		t.Errorf("unexpected longest path length: got:%v want:7", length)
+	}
+
+	g.SetEdge(simple.Edge{F: simple.Node(4), T: simple.Node(0), W: 1})
+	if _, _, err := LongestPath(g); err == nil {
+		t.Error("expected error for cyclic graph")
+	}
+}
+
+func TestCriticalPath(t *testing.T) {
+	g := directedFrom([]intset{
+		0: linksTo(2),
+		1: linksTo(2, 3),
+		2: linksTo(4),
+		3: linksTo(4),
+		4: nil,
+	})
+	// Edges have no delay.
+	for _, e := range g.Edges() {
+		g.SetEdge(simple.Edge{F: e.From(), T: e.To()})
+	}
+	durations := map[int]float64{0: 3, 1: 2, 2: 4, 3: 2, 4: 1}
+	duration := func(n graph.Node) float64 { return durations[n.ID()] }
+
+	cpa, err := CriticalPath(g, duration)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []int{0, 2, 4}; !reflect.DeepEqual(ids(cpa.Path), want) {
+		t.Errorf("unexpected critical path: got:%v want:%v", ids(cpa.Path), want)
+	}
+	if cpa.Length != 8 {
+		t.Errorf("unexpected length: got:%v want:8", cpa.Length)
+	}
+	wantES := map[int]float64{0: 0, 1: 0, 2: 3, 3: 2, 4: 7}
+	wantSlack := map[int]float64{0: 0, 1: 1, 2: 0, 3: 3, 4: 0}
+	if !reflect.DeepEqual(cpa.EarliestStart, wantES) {
+		t.Errorf("unexpected earliest start times: got:%v want:%v", cpa.EarliestStart, wantES)
+	}
+	if !reflect.DeepEqual(cpa.Slack, wantSlack) {
+		t.Errorf("unexpected slack: got:%v want:%v", cpa.Slack, wantSlack)
+	}
+
+	// Delaying the start of task 2 after task 1 moves
+	// the critical path through task 1.
+	g.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2), W: 2})
+	cpa, err = CriticalPath(g, duration)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []int{1, 2, 4}; !reflect.DeepEqual(ids(cpa.Path), want) {
+		t.Errorf("unexpected critical path with edge delay: got:%v want:%v", ids(cpa.Path), want)
+	}
+	if cpa.Length != 9 {
+		t.Errorf("unexpected length with edge delay: got:%v want:9", cpa.Length)
+	}
+	if cpa.Slack[0] != 1 {
+		t.Errorf("unexpected slack for task 0 with edge delay: got:%v want:1", cpa.Slack[0])
+	}
+}
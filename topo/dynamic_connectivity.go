@@ -0,0 +1,299 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"sort"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+	"github.com/gonum/graph/internal/set"
+	"github.com/gonum/graph/simple"
+)
+
+// DynamicConnectivity is an undirected graph that maintains its connected
+// components as edges and nodes are added and removed, allowing constant
+// time connectivity queries.
+//
+// A spanning forest of the graph is maintained alongside a component label
+// for each node. Adding an edge between components relabels the smaller
+// component. Removing a spanning forest edge searches the two halves of the
+// split tree in lock step so that only the smaller half is fully explored,
+// and then looks for a replacement edge leaving the smaller half; if none
+// exists the smaller half is relabelled as a new component. Removing an edge
+// that is not in the spanning forest takes constant time.
+type DynamicConnectivity struct {
+	g *simple.UndirectedGraph
+
+	// forest holds the spanning forest adjacency.
+	forest map[int]set.Ints
+	// comp maps node IDs to component labels.
+	comp map[int]int
+	// size holds the number of nodes in each component.
+	size map[int]int
+
+	nextComp int
+}
+
+// NewDynamicConnectivity returns an empty DynamicConnectivity with the
+// specified self and absent edge weight values.
+func NewDynamicConnectivity(self, absent float64) *DynamicConnectivity {
+	return &DynamicConnectivity{
+		g: simple.NewUndirectedGraph(self, absent),
+
+		forest: make(map[int]set.Ints),
+		comp:   make(map[int]int),
+		size:   make(map[int]int),
+	}
+}
+
+// NewNodeID returns a new unique ID for a node to be added to d. The returned ID does
+// not become a valid ID in d until it is added to d.
+func (d *DynamicConnectivity) NewNodeID() int {
+	return d.g.NewNodeID()
+}
+
+// AddNode adds n to the graph as a new component. It panics if the added node ID
+// matches an existing node ID.
+func (d *DynamicConnectivity) AddNode(n graph.Node) {
+	d.g.AddNode(n)
+	c := d.nextComp
+	d.nextComp++
+	d.forest[n.ID()] = make(set.Ints)
+	d.comp[n.ID()] = c
+	d.size[c] = 1
+}
+
+// RemoveNode removes n from the graph, as well as any edges attached to it. If the
+// node is not in the graph it is a no-op.
+func (d *DynamicConnectivity) RemoveNode(n graph.Node) {
+	if !d.g.Has(n) {
+		return
+	}
+	for _, m := range d.g.From(n) {
+		d.RemoveEdge(d.g.EdgeBetween(n, m))
+	}
+	d.g.RemoveNode(n)
+	c := d.comp[n.ID()]
+	delete(d.size, c)
+	delete(d.comp, n.ID())
+	delete(d.forest, n.ID())
+}
+
+// SetEdge adds e, an edge from one node to another. If the nodes do not exist, they
+// are added. SetEdge will panic if the IDs of the e.From and e.To are equal.
+func (d *DynamicConnectivity) SetEdge(e graph.Edge) {
+	u, v := e.From(), e.To()
+	for _, n := range []graph.Node{u, v} {
+		if !d.g.Has(n) {
+			d.AddNode(n)
+		}
+	}
+	existed := d.g.HasEdgeBetween(u, v)
+	d.g.SetEdge(e)
+	if existed {
+		return
+	}
+
+	cu, cv := d.comp[u.ID()], d.comp[v.ID()]
+	if cu == cv {
+		return
+	}
+	if d.size[cu] < d.size[cv] {
+		u, v = v, u
+		cu, cv = cv, cu
+	}
+	// u is now in the larger component.
+	d.size[cu] += d.size[cv]
+	delete(d.size, cv)
+	d.relabel(v.ID(), cu)
+	d.forest[u.ID()].Add(v.ID())
+	d.forest[v.ID()].Add(u.ID())
+}
+
+// RemoveEdge removes e from the graph, leaving the terminal nodes. If the edge does
+// not exist it is a no-op.
+func (d *DynamicConnectivity) RemoveEdge(e graph.Edge) {
+	u, v := e.From(), e.To()
+	if !d.g.HasEdgeBetween(u, v) {
+		return
+	}
+	d.g.RemoveEdge(e)
+	uid, vid := u.ID(), v.ID()
+	if !d.forest[uid].Has(vid) {
+		return
+	}
+	d.forest[uid].Remove(vid)
+	d.forest[vid].Remove(uid)
+
+	small := d.smallerHalf(uid, vid)
+	for id := range small {
+		for _, m := range d.g.From(d.g.Node(id)) {
+			if !small.Has(m.ID()) {
+				// Reconnect the halves with
+				// a replacement edge.
+				d.forest[id].Add(m.ID())
+				d.forest[m.ID()].Add(id)
+				return
+			}
+		}
+	}
+
+	c := d.nextComp
+	d.nextComp++
+	d.size[d.comp[uid]] -= len(small)
+	d.size[c] = len(small)
+	for id := range small {
+		d.comp[id] = c
+	}
+}
+
+// Connected returns whether u and v are in the graph and are connected
+// by a path.
+func (d *DynamicConnectivity) Connected(u, v graph.Node) bool {
+	cu, ok := d.comp[u.ID()]
+	if !ok {
+		return false
+	}
+	cv, ok := d.comp[v.ID()]
+	return ok && cu == cv
+}
+
+// Count returns the number of connected components in the graph.
+func (d *DynamicConnectivity) Count() int {
+	return len(d.size)
+}
+
+// Component returns the nodes of the connected component holding n, sorted
+// by ID. If n is not in the graph, Component returns nil.
+func (d *DynamicConnectivity) Component(n graph.Node) []graph.Node {
+	if !d.g.Has(n) {
+		return nil
+	}
+	var nodes []graph.Node
+	seen := set.Ints{n.ID(): struct{}{}}
+	stack := []int{n.ID()}
+	for len(stack) != 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		nodes = append(nodes, d.g.Node(id))
+		for m := range d.forest[id] {
+			if !seen.Has(m) {
+				seen.Add(m)
+				stack = append(stack, m)
+			}
+		}
+	}
+	sort.Sort(ordered.ByID(nodes))
+	return nodes
+}
+
+// Components returns the connected components of the graph.
+func (d *DynamicConnectivity) Components() [][]graph.Node {
+	byComp := make(map[int][]graph.Node, len(d.size))
+	for _, n := range d.g.Nodes() {
+		c := d.comp[n.ID()]
+		byComp[c] = append(byComp[c], n)
+	}
+	cc := make([][]graph.Node, 0, len(byComp))
+	for _, c := range byComp {
+		cc = append(cc, c)
+	}
+	return cc
+}
+
+// Node returns the node in the graph with the given ID.
+func (d *DynamicConnectivity) Node(id int) graph.Node {
+	return d.g.Node(id)
+}
+
+// Has returns whether the node exists within the graph.
+func (d *DynamicConnectivity) Has(n graph.Node) bool {
+	return d.g.Has(n)
+}
+
+// Nodes returns all the nodes in the graph.
+func (d *DynamicConnectivity) Nodes() []graph.Node {
+	return d.g.Nodes()
+}
+
+// Edges returns all the edges in the graph.
+func (d *DynamicConnectivity) Edges() []graph.Edge {
+	return d.g.Edges()
+}
+
+// From returns all nodes in d that can be reached directly from n.
+func (d *DynamicConnectivity) From(n graph.Node) []graph.Node {
+	return d.g.From(n)
+}
+
+// HasEdgeBetween returns whether an edge exists between nodes x and y.
+func (d *DynamicConnectivity) HasEdgeBetween(x, y graph.Node) bool {
+	return d.g.HasEdgeBetween(x, y)
+}
+
+// Edge returns the edge from u to v if such an edge exists and nil otherwise.
+// The node v must be directly reachable from u as defined by the From method.
+func (d *DynamicConnectivity) Edge(u, v graph.Node) graph.Edge {
+	return d.g.Edge(u, v)
+}
+
+// EdgeBetween returns the edge between nodes x and y.
+func (d *DynamicConnectivity) EdgeBetween(x, y graph.Node) graph.Edge {
+	return d.g.EdgeBetween(x, y)
+}
+
+// Weight returns the weight for the edge between x and y if Edge(x, y) returns a non-nil Edge.
+// If x and y are the same node or there is no joining edge between the two nodes the weight
+// value returned is either the graph's absent or self value. Weight returns true if an edge
+// exists between x and y or if x and y have the same ID, false otherwise.
+func (d *DynamicConnectivity) Weight(x, y graph.Node) (w float64, ok bool) {
+	return d.g.Weight(x, y)
+}
+
+// Degree returns the degree of n in d.
+func (d *DynamicConnectivity) Degree(n graph.Node) int {
+	return d.g.Degree(n)
+}
+
+// relabel sets the component label of all nodes in the
+// spanning tree holding the node with ID id to c.
+func (d *DynamicConnectivity) relabel(id, c int) {
+	d.comp[id] = c
+	stack := []int{id}
+	for len(stack) != 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for m := range d.forest[id] {
+			if d.comp[m] != c {
+				d.comp[m] = c
+				stack = append(stack, m)
+			}
+		}
+	}
+}
+
+// smallerHalf returns the nodes of the smaller of the spanning trees
+// holding the nodes with IDs u and v. The trees are searched in lock
+// step so the work done is proportional to the size of the smaller tree.
+func (d *DynamicConnectivity) smallerHalf(u, v int) set.Ints {
+	seen := [2]set.Ints{{u: struct{}{}}, {v: struct{}{}}}
+	queue := [2][]int{{u}, {v}}
+	for {
+		for i := range queue {
+			if len(queue[i]) == 0 {
+				return seen[i]
+			}
+			id := queue[i][0]
+			queue[i] = queue[i][1:]
+			for m := range d.forest[id] {
+				if !seen[i].Has(m) {
+					seen[i].Add(m)
+					queue[i] = append(queue[i], m)
+				}
+			}
+		}
+	}
+}
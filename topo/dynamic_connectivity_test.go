@@ -0,0 +1,96 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/graph/simple"
+)
+
+func TestDynamicConnectivity(t *testing.T) {
+	d := NewDynamicConnectivity(0, math.Inf(1))
+	for _, e := range [][2]int{{0, 1}, {1, 2}, {2, 0}, {3, 4}} {
+		d.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	if got := d.Count(); got != 2 {
+		t.Errorf("unexpected number of components: got:%d want:2", got)
+	}
+	if !d.Connected(simple.Node(0), simple.Node(2)) {
+		t.Error("expected 0 and 2 to be connected")
+	}
+	if d.Connected(simple.Node(0), simple.Node(3)) {
+		t.Error("unexpected connection between 0 and 3")
+	}
+
+	// Removing any one edge of the triangle leaves it connected.
+	d.RemoveEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	if !d.Connected(simple.Node(0), simple.Node(1)) {
+		t.Error("expected 0 and 1 to remain connected")
+	}
+	d.RemoveEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2)})
+	if d.Connected(simple.Node(0), simple.Node(1)) {
+		t.Error("unexpected connection between 0 and 1")
+	}
+	if got := d.Count(); got != 3 {
+		t.Errorf("unexpected number of components: got:%d want:3", got)
+	}
+
+	d.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(4)})
+	if got, want := ids(d.Component(simple.Node(3))), []int{1, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected component: got:%v want:%v", got, want)
+	}
+	d.RemoveNode(simple.Node(4))
+	if got := d.Count(); got != 3 {
+		t.Errorf("unexpected number of components after node removal: got:%d want:3", got)
+	}
+}
+
+func TestDynamicConnectivityRandom(t *testing.T) {
+	const n = 25
+	rnd := rand.New(rand.NewSource(1))
+	d := NewDynamicConnectivity(0, math.Inf(1))
+	for i := 0; i < n; i++ {
+		d.AddNode(simple.Node(i))
+	}
+	for i := 0; i < 2000; i++ {
+		u := simple.Node(rnd.Intn(n))
+		v := simple.Node(rnd.Intn(n))
+		switch op := rnd.Intn(10); {
+		case u == v:
+			continue
+		case op < 5:
+			d.SetEdge(simple.Edge{F: u, T: v})
+		case op < 9:
+			d.RemoveEdge(simple.Edge{F: u, T: v})
+		default:
+			d.RemoveNode(u)
+			d.AddNode(u)
+		}
+
+		cc := ConnectedComponents(d)
+		if got := d.Count(); got != len(cc) {
+			t.Fatalf("unexpected number of components after operation %d: got:%d want:%d", i, got, len(cc))
+		}
+		label := make(map[int]int)
+		for j, c := range cc {
+			for _, n := range c {
+				label[n.ID()] = j
+			}
+		}
+		for x := 0; x < n; x++ {
+			for y := x + 1; y < n; y++ {
+				want := label[x] == label[y]
+				if got := d.Connected(simple.Node(x), simple.Node(y)); got != want {
+					t.Fatalf("unexpected connectivity between %d and %d after operation %d: got:%t want:%t",
+						x, y, i, got, want)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,458 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+	"github.com/gonum/graph/internal/set"
+	"github.com/gonum/graph/simple"
+)
+
+// DynamicSCC is a directed graph that maintains its strongly connected
+// components and a topological ordering of those components as edges are
+// added and removed.
+//
+// Edge insertion uses the Pearce-Kelly dynamic topological ordering
+// algorithm, extended to merge components when an inserted edge closes a
+// cycle. The work done by an insertion is bounded by the part of the graph
+// lying between the end points of the new edge in the current ordering.
+// Removing an edge or node within a component recalculates that component's
+// strongly connected components and renumbers the ordering of all
+// components; removals between components do not change the ordering.
+//
+// The algorithm is described in:
+//
+// Pearce and Kelly, "A dynamic topological sort algorithm for directed acyclic
+// graphs", J. Exp. Algorithmics 11 (2006).
+type DynamicSCC struct {
+	g *simple.DirectedGraph
+
+	// comp maps node IDs to component IDs.
+	comp map[int]int
+	// members holds the nodes of each component.
+	members map[int]set.Nodes
+	// ord holds the topological position of each
+	// component. Positions are distinct but need
+	// not be contiguous.
+	ord map[int]int
+
+	nextComp int
+	nextOrd  int
+}
+
+// NewDynamicSCC returns an empty DynamicSCC with the specified self and
+// absent edge weight values.
+func NewDynamicSCC(self, absent float64) *DynamicSCC {
+	return &DynamicSCC{
+		g: simple.NewDirectedGraph(self, absent),
+
+		comp:    make(map[int]int),
+		members: make(map[int]set.Nodes),
+		ord:     make(map[int]int),
+	}
+}
+
+// NewNodeID returns a new unique ID for a node to be added to d. The returned ID does
+// not become a valid ID in d until it is added to d.
+func (d *DynamicSCC) NewNodeID() int {
+	return d.g.NewNodeID()
+}
+
+// AddNode adds n to the graph as a new component placed last in the
+// topological ordering. It panics if the added node ID matches an existing
+// node ID.
+func (d *DynamicSCC) AddNode(n graph.Node) {
+	d.g.AddNode(n)
+	c := d.nextComp
+	d.nextComp++
+	d.comp[n.ID()] = c
+	d.members[c] = set.Nodes{n.ID(): n}
+	d.ord[c] = d.nextOrd
+	d.nextOrd++
+}
+
+// RemoveNode removes n from the graph, as well as any edges attached to it. If the
+// node is not in the graph it is a no-op.
+func (d *DynamicSCC) RemoveNode(n graph.Node) {
+	if !d.g.Has(n) {
+		return
+	}
+	d.g.RemoveNode(n)
+	c := d.comp[n.ID()]
+	delete(d.comp, n.ID())
+	d.members[c].Remove(n)
+	if len(d.members[c]) == 0 {
+		delete(d.members, c)
+		delete(d.ord, c)
+		return
+	}
+	d.split(c)
+}
+
+// SetEdge adds e, an edge from one node to another. If the nodes do not exist, they are
+// added. If the new edge closes a cycle, the components on the cycle are merged.
+// SetEdge will panic if the IDs of the e.From and e.To are equal.
+func (d *DynamicSCC) SetEdge(e graph.Edge) {
+	d.ensure(e.From(), e.To())
+	d.g.SetEdge(e)
+	d.insert(d.comp[e.From().ID()], d.comp[e.To().ID()])
+}
+
+// TrySetEdge adds e to the graph if doing so does not join two distinct strongly
+// connected components, and returns whether the edge was added. If the graph is
+// acyclic, TrySetEdge adds e only if the graph remains acyclic. If the nodes do
+// not exist, they are added. TrySetEdge will panic if the IDs of the e.From and
+// e.To are equal.
+func (d *DynamicSCC) TrySetEdge(e graph.Edge) bool {
+	u, v := e.From(), e.To()
+	if u.ID() == v.ID() {
+		panic(fmt.Sprintf("topo: adding self edge: %d", u.ID()))
+	}
+	if d.g.Has(u) && d.g.Has(v) && d.wouldMerge(d.comp[u.ID()], d.comp[v.ID()]) {
+		return false
+	}
+	d.SetEdge(e)
+	return true
+}
+
+// WouldMerge returns whether adding an edge from u to v would join two distinct
+// strongly connected components, closing a cycle through nodes that are not
+// already mutually reachable. If either node is not in the graph, WouldMerge
+// returns false.
+func (d *DynamicSCC) WouldMerge(u, v graph.Node) bool {
+	if !d.g.Has(u) || !d.g.Has(v) {
+		return false
+	}
+	return d.wouldMerge(d.comp[u.ID()], d.comp[v.ID()])
+}
+
+// RemoveEdge removes e from the graph, leaving the terminal nodes. If the edge does
+// not exist it is a no-op. If e was within a strongly connected component, the
+// component is split as required.
+func (d *DynamicSCC) RemoveEdge(e graph.Edge) {
+	u, v := e.From(), e.To()
+	if !d.g.HasEdgeFromTo(u, v) {
+		return
+	}
+	d.g.RemoveEdge(e)
+	if c := d.comp[u.ID()]; c == d.comp[v.ID()] {
+		d.split(c)
+	}
+}
+
+// Component returns the nodes in the strongly connected component holding n,
+// sorted by ID. If n is not in the graph, Component returns nil.
+func (d *DynamicSCC) Component(n graph.Node) []graph.Node {
+	c, ok := d.comp[n.ID()]
+	if !ok {
+		return nil
+	}
+	return d.nodesOf(c)
+}
+
+// SameComponent returns whether u and v are in the graph and in the same
+// strongly connected component.
+func (d *DynamicSCC) SameComponent(u, v graph.Node) bool {
+	cu, ok := d.comp[u.ID()]
+	if !ok {
+		return false
+	}
+	cv, ok := d.comp[v.ID()]
+	return ok && cu == cv
+}
+
+// Components returns the strongly connected components of the graph in
+// topological order, with each component's nodes sorted by ID.
+func (d *DynamicSCC) Components() [][]graph.Node {
+	comps := d.ordered()
+	sccs := make([][]graph.Node, len(comps))
+	for i, c := range comps {
+		sccs[i] = d.nodesOf(c)
+	}
+	return sccs
+}
+
+// IsAcyclic returns whether the graph has no cycles.
+func (d *DynamicSCC) IsAcyclic() bool {
+	return len(d.members) == len(d.comp)
+}
+
+// Sort returns the nodes of the graph in a topological order, with the same
+// semantics as the Sort function: if the graph is not acyclic, an Unorderable
+// error is returned listing the cyclic components, and the position of each
+// cyclic component within the sorted nodes is marked with a nil graph.Node.
+func (d *DynamicSCC) Sort() (sorted []graph.Node, err error) {
+	sccs := d.Components()
+	for i, j := 0, len(sccs)-1; i < j; i, j = i+1, j-1 {
+		sccs[i], sccs[j] = sccs[j], sccs[i]
+	}
+	return sortedFrom(sccs, lexical)
+}
+
+// Node returns the node in the graph with the given ID.
+func (d *DynamicSCC) Node(id int) graph.Node {
+	return d.g.Node(id)
+}
+
+// Has returns whether the node exists within the graph.
+func (d *DynamicSCC) Has(n graph.Node) bool {
+	return d.g.Has(n)
+}
+
+// Nodes returns all the nodes in the graph.
+func (d *DynamicSCC) Nodes() []graph.Node {
+	return d.g.Nodes()
+}
+
+// Edges returns all the edges in the graph.
+func (d *DynamicSCC) Edges() []graph.Edge {
+	return d.g.Edges()
+}
+
+// From returns all nodes in d that can be reached directly from n.
+func (d *DynamicSCC) From(n graph.Node) []graph.Node {
+	return d.g.From(n)
+}
+
+// To returns all nodes in d that can reach directly to n.
+func (d *DynamicSCC) To(n graph.Node) []graph.Node {
+	return d.g.To(n)
+}
+
+// HasEdgeBetween returns whether an edge exists between nodes x and y without
+// considering direction.
+func (d *DynamicSCC) HasEdgeBetween(x, y graph.Node) bool {
+	return d.g.HasEdgeBetween(x, y)
+}
+
+// HasEdgeFromTo returns whether an edge exists in the graph from u to v.
+func (d *DynamicSCC) HasEdgeFromTo(u, v graph.Node) bool {
+	return d.g.HasEdgeFromTo(u, v)
+}
+
+// Edge returns the edge from u to v if such an edge exists and nil otherwise.
+// The node v must be directly reachable from u as defined by the From method.
+func (d *DynamicSCC) Edge(u, v graph.Node) graph.Edge {
+	return d.g.Edge(u, v)
+}
+
+// Weight returns the weight for the edge between x and y if Edge(x, y) returns a non-nil Edge.
+// If x and y are the same node or there is no joining edge between the two nodes the weight
+// value returned is either the graph's absent or self value. Weight returns true if an edge
+// exists between x and y or if x and y have the same ID, false otherwise.
+func (d *DynamicSCC) Weight(x, y graph.Node) (w float64, ok bool) {
+	return d.g.Weight(x, y)
+}
+
+// ensure adds any of the given nodes that are not already in the graph.
+func (d *DynamicSCC) ensure(nodes ...graph.Node) {
+	for _, n := range nodes {
+		if !d.g.Has(n) {
+			d.AddNode(n)
+		}
+	}
+}
+
+// wouldMerge returns whether an edge from component cu to component cv
+// would close a cycle between distinct components.
+func (d *DynamicSCC) wouldMerge(cu, cv int) bool {
+	if cu == cv || d.ord[cu] < d.ord[cv] {
+		return false
+	}
+	return d.reach(cv, d.ord[cu], true).Has(cu)
+}
+
+// insert restores the topological ordering of components after the
+// insertion of an edge from component cu to component cv, merging
+// components if the edge closes a cycle.
+func (d *DynamicSCC) insert(cu, cv int) {
+	if cu == cv || d.ord[cu] < d.ord[cv] {
+		return
+	}
+
+	// Find the affected region: the components reachable
+	// from cv and the components reaching cu that lie
+	// between cv and cu in the current ordering.
+	fwd := d.reach(cv, d.ord[cu], true)
+	bwd := d.reach(cu, d.ord[cv], false)
+
+	var pool []int
+	for c := range fwd {
+		pool = append(pool, d.ord[c])
+	}
+	for c := range bwd {
+		if !fwd.Has(c) {
+			pool = append(pool, d.ord[c])
+		}
+	}
+	sort.Ints(pool)
+
+	// Components both reachable from cv and reaching cu
+	// are on a cycle through the new edge.
+	var cycle []int
+	if fwd.Has(cu) {
+		for c := range fwd {
+			if bwd.Has(c) {
+				cycle = append(cycle, c)
+			}
+		}
+	}
+	for _, c := range cycle {
+		fwd.Remove(c)
+		bwd.Remove(c)
+	}
+
+	// Place the components reaching cu first, then the
+	// merged cycle, then the components reachable from cv,
+	// each in their previous relative order.
+	before := d.byOrder(bwd)
+	after := d.byOrder(fwd)
+	for i, c := range before {
+		d.ord[c] = pool[i]
+	}
+	for i, c := range after {
+		d.ord[c] = pool[len(pool)-len(after)+i]
+	}
+	if cycle != nil {
+		d.ord[d.merge(cycle)] = pool[len(before)]
+	}
+}
+
+// reach returns the set of components reachable from start, following
+// edges forwards or backwards, without passing through a component with
+// a position beyond bound; above bound when following edges forwards and
+// below bound when following edges backwards.
+func (d *DynamicSCC) reach(start, bound int, forward bool) set.Ints {
+	seen := set.Ints{start: struct{}{}}
+	stack := []int{start}
+	for len(stack) != 0 {
+		c := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, n := range d.members[c] {
+			var next []graph.Node
+			if forward {
+				next = d.g.From(n)
+			} else {
+				next = d.g.To(n)
+			}
+			for _, m := range next {
+				o := d.comp[m.ID()]
+				if seen.Has(o) {
+					continue
+				}
+				if forward && d.ord[o] > bound || !forward && d.ord[o] < bound {
+					continue
+				}
+				seen.Add(o)
+				stack = append(stack, o)
+			}
+		}
+	}
+	return seen
+}
+
+// merge joins the given components into a single component and returns
+// its ID. The position of the returned component is not set.
+func (d *DynamicSCC) merge(comps []int) int {
+	sort.Ints(comps)
+	dst := comps[0]
+	for _, c := range comps[1:] {
+		for id, n := range d.members[c] {
+			d.members[dst][id] = n
+			d.comp[id] = dst
+		}
+		delete(d.members, c)
+		delete(d.ord, c)
+	}
+	return dst
+}
+
+// split replaces the component c with its strongly connected components,
+// and renumbers the positions of all components.
+func (d *DynamicSCC) split(c int) {
+	sub := simple.NewDirectedGraph(0, 0)
+	for _, n := range d.members[c] {
+		sub.AddNode(n)
+	}
+	for _, n := range d.members[c] {
+		for _, m := range d.g.From(n) {
+			if d.comp[m.ID()] == c {
+				sub.SetEdge(simple.Edge{F: n, T: m})
+			}
+		}
+	}
+	sccs := TarjanSCC(sub)
+	if len(sccs) == 1 {
+		return
+	}
+
+	comps := d.ordered()
+	d.nextOrd = 0
+	for _, o := range comps {
+		if o != c {
+			d.ord[o] = d.nextOrd
+			d.nextOrd++
+			continue
+		}
+		delete(d.members, c)
+		delete(d.ord, c)
+		// TarjanSCC returns components in reverse
+		// topological order.
+		for i := len(sccs) - 1; i >= 0; i-- {
+			p := d.nextComp
+			d.nextComp++
+			d.members[p] = make(set.Nodes, len(sccs[i]))
+			for _, n := range sccs[i] {
+				d.members[p][n.ID()] = n
+				d.comp[n.ID()] = p
+			}
+			d.ord[p] = d.nextOrd
+			d.nextOrd++
+		}
+	}
+}
+
+// ordered returns the IDs of all components in topological order.
+func (d *DynamicSCC) ordered() []int {
+	comps := make(set.Ints, len(d.members))
+	for c := range d.members {
+		comps.Add(c)
+	}
+	return d.byOrder(comps)
+}
+
+// byOrder returns the components in s sorted by position.
+func (d *DynamicSCC) byOrder(s set.Ints) []int {
+	comps := make([]int, 0, len(s))
+	for c := range s {
+		comps = append(comps, c)
+	}
+	sort.Sort(byOrd{comps: comps, ord: d.ord})
+	return comps
+}
+
+// nodesOf returns the nodes of component c sorted by ID.
+func (d *DynamicSCC) nodesOf(c int) []graph.Node {
+	nodes := make([]graph.Node, 0, len(d.members[c]))
+	for _, n := range d.members[c] {
+		nodes = append(nodes, n)
+	}
+	sort.Sort(ordered.ByID(nodes))
+	return nodes
+}
+
+// byOrd sorts component IDs by their position.
+type byOrd struct {
+	comps []int
+	ord   map[int]int
+}
+
+func (s byOrd) Len() int           { return len(s.comps) }
+func (s byOrd) Less(i, j int) bool { return s.ord[s.comps[i]] < s.ord[s.comps[j]] }
+func (s byOrd) Swap(i, j int)      { s.comps[i], s.comps[j] = s.comps[j], s.comps[i] }
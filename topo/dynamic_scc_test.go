@@ -0,0 +1,180 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+	"github.com/gonum/graph/simple"
+)
+
+func TestDynamicSCC(t *testing.T) {
+	d := NewDynamicSCC(0, math.Inf(1))
+	for _, e := range [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 4}} {
+		if !d.TrySetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])}) {
+			t.Errorf("unexpected rejection of edge %v", e)
+		}
+	}
+	if !d.IsAcyclic() {
+		t.Error("expected acyclic graph")
+	}
+	if !d.WouldMerge(simple.Node(3), simple.Node(1)) {
+		t.Error("expected edge 3->1 to close a cycle")
+	}
+	if d.TrySetEdge(simple.Edge{F: simple.Node(3), T: simple.Node(1)}) {
+		t.Error("unexpected acceptance of cycle-closing edge 3->1")
+	}
+	if d.HasEdgeFromTo(simple.Node(3), simple.Node(1)) {
+		t.Error("unexpected edge 3->1 after rejection")
+	}
+
+	// Insert edges against the current order.
+	d.SetEdge(simple.Edge{F: simple.Node(5), T: simple.Node(0)})
+	sorted, err := d.Sort()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := ids(sorted), []int{5, 0, 1, 2, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected sort order: got:%v want:%v", got, want)
+	}
+
+	d.SetEdge(simple.Edge{F: simple.Node(3), T: simple.Node(1)})
+	if d.IsAcyclic() {
+		t.Error("expected cyclic graph")
+	}
+	if got, want := ids(d.Component(simple.Node(2))), []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected component: got:%v want:%v", got, want)
+	}
+	sorted, err = d.Sort()
+	if err == nil {
+		t.Error("expected Unorderable error")
+	}
+	if got, want := len(sorted), 4; got != want {
+		t.Errorf("unexpected sorted length: got:%d want:%d", got, want)
+	}
+
+	d.RemoveEdge(simple.Edge{F: simple.Node(2), T: simple.Node(3)})
+	if !d.IsAcyclic() {
+		t.Error("expected acyclic graph after removing cycle edge")
+	}
+	if d.SameComponent(simple.Node(1), simple.Node(3)) {
+		t.Error("unexpected shared component after split")
+	}
+	checkDynamicSCC(t, d)
+}
+
+func TestDynamicSCCRandom(t *testing.T) {
+	const n = 20
+	rnd := rand.New(rand.NewSource(1))
+	d := NewDynamicSCC(0, math.Inf(1))
+	for i := 0; i < n; i++ {
+		d.AddNode(simple.Node(i))
+	}
+	for i := 0; i < 1000; i++ {
+		u := simple.Node(rnd.Intn(n))
+		v := simple.Node(rnd.Intn(n))
+		switch op := rnd.Intn(10); {
+		case u == v:
+			continue
+		case op < 5:
+			d.SetEdge(simple.Edge{F: u, T: v})
+		case op < 8:
+			d.RemoveEdge(simple.Edge{F: u, T: v})
+		case op < 9:
+			for _, w := range d.From(u) {
+				d.RemoveEdge(simple.Edge{F: u, T: w})
+			}
+		default:
+			d.RemoveNode(u)
+			d.AddNode(u)
+		}
+		if !checkDynamicSCC(t, d) {
+			t.Fatalf("failed after operation %d", i)
+		}
+	}
+}
+
+func TestDynamicSCCAcyclic(t *testing.T) {
+	const n = 30
+	rnd := rand.New(rand.NewSource(1))
+	d := NewDynamicSCC(0, math.Inf(1))
+	for i := 0; i < 500; i++ {
+		u := simple.Node(rnd.Intn(n))
+		v := simple.Node(rnd.Intn(n))
+		if u == v {
+			continue
+		}
+		want := !d.Has(u) || !d.Has(v) || !pathExists(d, v, u)
+		if got := d.TrySetEdge(simple.Edge{F: u, T: v}); got != want {
+			t.Fatalf("unexpected result for edge %d->%d: got:%t want:%t", u, v, got, want)
+		}
+		if !d.IsAcyclic() {
+			t.Fatalf("graph became cyclic after edge %d->%d", u, v)
+		}
+	}
+	checkDynamicSCC(t, d)
+}
+
+// checkDynamicSCC checks the components of d against TarjanSCC and checks
+// that the component ordering is topological.
+func checkDynamicSCC(t *testing.T, d *DynamicSCC) bool {
+	ok := true
+	got := d.Components()
+	want := TarjanSCC(d)
+	if !reflect.DeepEqual(canonicalSCCs(got), canonicalSCCs(want)) {
+		t.Errorf("unexpected components: got:%v want:%v", canonicalSCCs(got), canonicalSCCs(want))
+		ok = false
+	}
+	pos := make(map[int]int)
+	for i, c := range got {
+		for _, n := range c {
+			pos[n.ID()] = i
+		}
+	}
+	for _, u := range d.Nodes() {
+		for _, v := range d.From(u) {
+			if pos[u.ID()] > pos[v.ID()] {
+				t.Errorf("edge %d->%d violates component order", u.ID(), v.ID())
+				ok = false
+			}
+		}
+	}
+	return ok
+}
+
+func canonicalSCCs(sccs [][]graph.Node) [][]int {
+	c := make([][]int, len(sccs))
+	for i, s := range sccs {
+		s = append([]graph.Node(nil), s...)
+		sort.Sort(ordered.ByID(s))
+		c[i] = ids(s)
+	}
+	sort.Sort(ordered.BySliceValues(c))
+	return c
+}
+
+func pathExists(g graph.Directed, from, to graph.Node) bool {
+	seen := make(map[int]bool)
+	stack := []graph.Node{from}
+	for len(stack) != 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if n.ID() == to.ID() {
+			return true
+		}
+		if seen[n.ID()] {
+			continue
+		}
+		seen[n.ID()] = true
+		stack = append(stack, g.From(n)...)
+	}
+	return false
+}
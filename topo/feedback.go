@@ -0,0 +1,347 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"sort"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+)
+
+// GreedyFeedbackArcSet returns a set of edges of the directed graph g whose
+// removal leaves g acyclic, found using the greedy heuristic of Eades, Lin
+// and Smyth. The nodes of g are ordered by repeatedly taking sinks to the end
+// of the ordering, sources to the start of the ordering and otherwise the node
+// with the greatest difference between out-degree and in-degree to the start
+// of the ordering; the returned edges are those leading backwards in the
+// ordering. Self loops are always included in the returned set. The returned
+// set is not necessarily minimum; see ExactFeedbackArcSet.
+//
+//	Eades, Lin and Smyth "A fast and effective heuristic for the feedback arc
+//	set problem" doi:10.1016/0020-0190(93)90079-O
+func GreedyFeedbackArcSet(g graph.Directed) []graph.Edge {
+	d := newDigraph(g)
+	n := len(d.nodes)
+	alive := make([]bool, n)
+	in := make([]int, n)
+	out := make([]int, n)
+	for i := range d.nodes {
+		alive[i] = true
+		in[i] = len(d.in[i])
+		out[i] = len(d.out[i])
+	}
+	remove := func(i int) {
+		alive[i] = false
+		for _, j := range d.out[i] {
+			in[j]--
+		}
+		for _, j := range d.in[i] {
+			out[j]--
+		}
+	}
+
+	var head, tail []int
+	for left := n; left > 0; {
+		changed := true
+		for changed {
+			changed = false
+			for i := range d.nodes {
+				if alive[i] && out[i] == 0 {
+					tail = append(tail, i)
+					remove(i)
+					left--
+					changed = true
+				}
+			}
+			for i := range d.nodes {
+				if alive[i] && in[i] == 0 {
+					head = append(head, i)
+					remove(i)
+					left--
+					changed = true
+				}
+			}
+		}
+		if left == 0 {
+			break
+		}
+		best := -1
+		for i := range d.nodes {
+			if alive[i] && (best < 0 || out[i]-in[i] > out[best]-in[best]) {
+				best = i
+			}
+		}
+		head = append(head, best)
+		remove(best)
+		left--
+	}
+
+	pos := make([]int, n)
+	for p, i := range head {
+		pos[i] = p
+	}
+	for p, i := range tail {
+		pos[i] = n - 1 - p
+	}
+	return d.backwardEdges(g, pos)
+}
+
+// maxExactFeedbackNodes is the largest strongly connected component size
+// accepted by ExactFeedbackArcSet.
+const maxExactFeedbackNodes = 20
+
+// ExactFeedbackArcSet returns a minimum set of edges of the directed graph g
+// whose removal leaves g acyclic. Only edges within strongly connected
+// components can lie on cycles, so each component is solved independently by
+// dynamic programming over subsets of its nodes. This takes exponential time
+// and space in the size of the largest component, so ExactFeedbackArcSet is
+// only suitable for graphs with small strongly connected components. It will
+// panic if g has a strongly connected component with more than 20 nodes. Self
+// loops are always included in the returned set.
+func ExactFeedbackArcSet(g graph.Directed) []graph.Edge {
+	d := newDigraph(g)
+	pos := make([]int, len(d.nodes))
+	var offset int
+	for _, scc := range TarjanSCC(g) {
+		k := len(scc)
+		if k > maxExactFeedbackNodes {
+			panic("topo: strongly connected component too large for exact feedback arc set")
+		}
+		idx := make([]int, k)
+		local := make(map[int]uint, k)
+		for l, n := range scc {
+			idx[l] = d.indexOf[n.ID()]
+			local[idx[l]] = uint(l)
+		}
+		succ := make([]uint32, k)
+		for l, i := range idx {
+			for _, j := range d.out[i] {
+				if m, ok := local[j]; ok && j != i {
+					succ[l] |= 1 << m
+				}
+			}
+		}
+
+		// cost[s] is the least number of backward edges in an
+		// ordering of the nodes in s, and last[s] the final node
+		// of such an ordering.
+		cost := make([]int32, 1<<uint(k))
+		last := make([]int8, 1<<uint(k))
+		for s := uint32(1); s < 1<<uint(k); s++ {
+			cost[s] = -1
+			for v := uint(0); v < uint(k); v++ {
+				if s&(1<<v) == 0 {
+					continue
+				}
+				rest := s &^ (1 << v)
+				c := cost[rest] + int32(popcount(succ[v]&rest))
+				if cost[s] < 0 || c < cost[s] {
+					cost[s] = c
+					last[s] = int8(v)
+				}
+			}
+		}
+
+		// TarjanSCC returns components in reverse topological
+		// order, so fill positions from the end.
+		s := uint32(1)<<uint(k) - 1
+		for p := len(d.nodes) - offset - 1; s != 0; p-- {
+			v := uint(last[s])
+			pos[idx[v]] = p
+			s &^= 1 << v
+		}
+		offset += k
+	}
+	return d.backwardEdges(g, pos)
+}
+
+// GreedyFeedbackVertexSet returns a set of nodes of the directed graph g whose
+// removal leaves g acyclic. Nodes that cannot lie on a cycle are repeatedly
+// discarded, and then the node with the greatest product of in-degree and
+// out-degree is added to the set, until no nodes remain. Nodes with self loops
+// are always included. Finally, each node of the set is returned to the graph
+// in reverse order of selection if that does not create a cycle, so no proper
+// subset of the returned set is a feedback vertex set. The returned nodes are
+// sorted by ID. The returned set is not necessarily minimum.
+func GreedyFeedbackVertexSet(g graph.Directed) []graph.Node {
+	d := newDigraph(g)
+	n := len(d.nodes)
+	alive := make([]bool, n)
+	in := make([]int, n)
+	out := make([]int, n)
+	for i := range d.nodes {
+		alive[i] = true
+		for _, j := range d.in[i] {
+			if j != i {
+				in[i]++
+			}
+		}
+		for _, j := range d.out[i] {
+			if j != i {
+				out[i]++
+			}
+		}
+	}
+	remove := func(i int) {
+		alive[i] = false
+		for _, j := range d.out[i] {
+			if j != i {
+				in[j]--
+			}
+		}
+		for _, j := range d.in[i] {
+			if j != i {
+				out[j]--
+			}
+		}
+	}
+
+	var chosen []int
+	for i := range d.nodes {
+		if d.loop[i] {
+			chosen = append(chosen, i)
+			remove(i)
+		}
+	}
+	for {
+		changed := true
+		for changed {
+			changed = false
+			for i := range d.nodes {
+				if alive[i] && (in[i] == 0 || out[i] == 0) {
+					remove(i)
+					changed = true
+				}
+			}
+		}
+		best := -1
+		for i := range d.nodes {
+			if alive[i] && (best < 0 || in[i]*out[i] > in[best]*out[best]) {
+				best = i
+			}
+		}
+		if best < 0 {
+			break
+		}
+		chosen = append(chosen, best)
+		remove(best)
+	}
+
+	// Drop redundant nodes.
+	removed := make([]bool, n)
+	for _, i := range chosen {
+		removed[i] = true
+	}
+	for k := len(chosen) - 1; k >= 0; k-- {
+		i := chosen[k]
+		if d.loop[i] {
+			continue
+		}
+		removed[i] = false
+		if d.hasCycle(removed) {
+			removed[i] = true
+		}
+	}
+
+	var fvs []graph.Node
+	for i, r := range removed {
+		if r {
+			fvs = append(fvs, d.nodes[i])
+		}
+	}
+	return fvs
+}
+
+// digraph is a directed graph with nodes indexed by position in ID order.
+type digraph struct {
+	nodes   []graph.Node
+	indexOf map[int]int
+	out, in [][]int
+	loop    []bool
+}
+
+func newDigraph(g graph.Directed) digraph {
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+	d := digraph{
+		nodes:   nodes,
+		indexOf: make(map[int]int, len(nodes)),
+		out:     make([][]int, len(nodes)),
+		in:      make([][]int, len(nodes)),
+		loop:    make([]bool, len(nodes)),
+	}
+	for i, n := range nodes {
+		d.indexOf[n.ID()] = i
+	}
+	for i, u := range nodes {
+		for _, v := range g.From(u) {
+			j := d.indexOf[v.ID()]
+			if j == i {
+				d.loop[i] = true
+			}
+			d.out[i] = append(d.out[i], j)
+			d.in[j] = append(d.in[j], i)
+		}
+	}
+	for i := range nodes {
+		sort.Ints(d.out[i])
+		sort.Ints(d.in[i])
+	}
+	return d
+}
+
+// backwardEdges returns the edges of g leading from a node to a node at
+// an earlier or equal position in pos.
+func (d digraph) backwardEdges(g graph.Directed, pos []int) []graph.Edge {
+	var edges []graph.Edge
+	for i, u := range d.nodes {
+		for _, j := range d.out[i] {
+			if pos[j] <= pos[i] {
+				edges = append(edges, g.Edge(u, d.nodes[j]))
+			}
+		}
+	}
+	return edges
+}
+
+// hasCycle returns whether d has a cycle avoiding the removed nodes.
+func (d digraph) hasCycle(removed []bool) bool {
+	const (
+		unvisited = iota
+		active
+		done
+	)
+	state := make([]int, len(d.nodes))
+	type frame struct{ node, next int }
+	for r := range d.nodes {
+		if removed[r] || state[r] != unvisited {
+			continue
+		}
+		stack := []frame{{node: r}}
+		state[r] = active
+		for len(stack) != 0 {
+			f := &stack[len(stack)-1]
+			if f.next == len(d.out[f.node]) {
+				state[f.node] = done
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			j := d.out[f.node][f.next]
+			f.next++
+			if removed[j] {
+				continue
+			}
+			switch state[j] {
+			case active:
+				return true
+			case unvisited:
+				state[j] = active
+				stack = append(stack, frame{node: j})
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,194 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+var feedbackTests = []struct {
+	name string
+	g    []intset
+
+	arcs  int
+	nodes int
+}{
+	{name: "empty", g: nil, arcs: 0, nodes: 0},
+	{name: "path", g: []intset{0: linksTo(1), 1: linksTo(2)}, arcs: 0, nodes: 0},
+	{name: "triangle", g: []intset{0: linksTo(1), 1: linksTo(2), 2: linksTo(0)}, arcs: 1, nodes: 1},
+	{
+		name: "two cycles",
+		g: []intset{
+			0: linksTo(1),
+			1: linksTo(0, 2),
+			2: linksTo(3),
+			3: linksTo(4),
+			4: linksTo(2),
+		},
+		arcs:  2,
+		nodes: 2,
+	},
+	{
+		name: "shared node",
+		g: []intset{
+			0: linksTo(1, 3),
+			1: linksTo(2),
+			2: linksTo(0),
+			3: linksTo(4),
+			4: linksTo(0),
+		},
+		arcs:  2,
+		nodes: 1,
+	},
+	{
+		name: "complete 4",
+		g: []intset{
+			0: linksTo(1, 2, 3),
+			1: linksTo(0, 2, 3),
+			2: linksTo(0, 1, 3),
+			3: linksTo(0, 1, 2),
+		},
+		arcs:  6,
+		nodes: 3,
+	},
+}
+
+func TestFeedbackArcSet(t *testing.T) {
+	for _, test := range feedbackTests {
+		g := directedFrom(test.g)
+		exact := ExactFeedbackArcSet(g)
+		if len(exact) != test.arcs {
+			t.Errorf("unexpected exact feedback arc set size for %q: got:%d want:%d", test.name, len(exact), test.arcs)
+		}
+		checkFeedbackArcSet(t, test.name, g, exact)
+
+		greedy := GreedyFeedbackArcSet(g)
+		if len(greedy) < test.arcs {
+			t.Errorf("greedy feedback arc set for %q smaller than minimum: got:%d want>=%d", test.name, len(greedy), test.arcs)
+		}
+		checkFeedbackArcSet(t, test.name, g, greedy)
+	}
+}
+
+func TestFeedbackArcSetRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 30; i++ {
+		g := randomDigraph(rnd, 6, 0.3)
+		exact := ExactFeedbackArcSet(g)
+		checkFeedbackArcSet(t, "random", g, exact)
+		if want := bruteForceFeedbackArcSet(g); len(exact) != want {
+			t.Errorf("unexpected exact feedback arc set size for random graph %d: got:%d want:%d", i, len(exact), want)
+		}
+		greedy := GreedyFeedbackArcSet(g)
+		checkFeedbackArcSet(t, "random", g, greedy)
+		if len(greedy) < len(exact) {
+			t.Errorf("greedy feedback arc set smaller than minimum for random graph %d", i)
+		}
+	}
+}
+
+func TestGreedyFeedbackVertexSet(t *testing.T) {
+	for _, test := range feedbackTests {
+		g := directedFrom(test.g)
+		fvs := GreedyFeedbackVertexSet(g)
+		if len(fvs) != test.nodes {
+			t.Errorf("unexpected feedback vertex set size for %q: got:%d want:%d", test.name, len(fvs), test.nodes)
+		}
+		checkFeedbackVertexSet(t, test.name, g, fvs)
+	}
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 30; i++ {
+		g := randomDigraph(rnd, 15, 0.15)
+		checkFeedbackVertexSet(t, "random", g, GreedyFeedbackVertexSet(g))
+	}
+}
+
+func randomDigraph(rnd *rand.Rand, n int, p float64) *simple.DirectedGraph {
+	adj := make([]intset, n)
+	for u := range adj {
+		adj[u] = make(intset)
+		for v := 0; v < n; v++ {
+			if u != v && rnd.Float64() < p {
+				adj[u][v] = struct{}{}
+			}
+		}
+	}
+	return directedFrom(adj)
+}
+
+// checkFeedbackArcSet checks that removing fas from g leaves it acyclic.
+func checkFeedbackArcSet(t *testing.T, name string, g *simple.DirectedGraph, fas []graph.Edge) {
+	h := copyDirected(g)
+	for _, e := range fas {
+		if !g.HasEdgeFromTo(e.From(), e.To()) {
+			t.Errorf("feedback arc %d->%d for %q not in graph", e.From().ID(), e.To().ID(), name)
+		}
+		h.RemoveEdge(e)
+	}
+	if _, err := Sort(h); err != nil {
+		t.Errorf("graph %q not acyclic after removing feedback arc set: %v", name, err)
+	}
+}
+
+// checkFeedbackVertexSet checks that removing fvs from g leaves it acyclic
+// and that no node of fvs can be returned without creating a cycle.
+func checkFeedbackVertexSet(t *testing.T, name string, g *simple.DirectedGraph, fvs []graph.Node) {
+	h := copyDirected(g)
+	for _, n := range fvs {
+		h.RemoveNode(n)
+	}
+	if _, err := Sort(h); err != nil {
+		t.Errorf("graph %q not acyclic after removing feedback vertex set: %v", name, err)
+	}
+	for _, n := range fvs {
+		h := copyDirected(g)
+		for _, m := range fvs {
+			if m.ID() != n.ID() {
+				h.RemoveNode(m)
+			}
+		}
+		if _, err := Sort(h); err == nil {
+			t.Errorf("feedback vertex set for %q not minimal: %d is redundant", name, n.ID())
+		}
+	}
+}
+
+func copyDirected(g *simple.DirectedGraph) *simple.DirectedGraph {
+	h := simple.NewDirectedGraph(0, 0)
+	for _, n := range g.Nodes() {
+		h.AddNode(n)
+	}
+	for _, e := range g.Edges() {
+		h.SetEdge(e)
+	}
+	return h
+}
+
+// bruteForceFeedbackArcSet returns the size of a minimum feedback arc set
+// of g by trying all subsets of edges.
+func bruteForceFeedbackArcSet(g *simple.DirectedGraph) int {
+	edges := g.Edges()
+	best := len(edges)
+	for mask := 0; mask < 1<<uint(len(edges)); mask++ {
+		k := popcount(uint32(mask))
+		if k >= best {
+			continue
+		}
+		h := copyDirected(g)
+		for i, e := range edges {
+			if mask&(1<<uint(i)) != 0 {
+				h.RemoveEdge(e)
+			}
+		}
+		if _, err := Sort(h); err == nil {
+			best = k
+		}
+	}
+	return best
+}
@@ -26,15 +26,29 @@ type johnson struct {
 	stack []graph.Node
 
 	result [][]graph.Node
+
+	// cancelled, if not nil, is called by
+	// circuit and stops the search if it
+	// returns a non-nil error, held in err.
+	cancelled func() error
+	err       error
 }
 
 // CyclesIn returns the set of elementary cycles in the graph g.
 func CyclesIn(g graph.Directed) [][]graph.Node {
+	cycles, _ := cyclesIn(g, nil)
+	return cycles
+}
+
+// cyclesIn returns the elementary cycles of g found before cancelled, if not
+// nil, returns a non-nil error, and that error.
+func cyclesIn(g graph.Directed, cancelled func() error) ([][]graph.Node, error) {
 	jg := johnsonGraphFrom(g)
 	j := johnson{
-		adjacent: jg,
-		b:        make([]set.Ints, len(jg.orig)),
-		blocked:  make([]bool, len(jg.orig)),
+		cancelled: cancelled,
+		adjacent:  jg,
+		b:         make([]set.Ints, len(jg.orig)),
+		blocked:   make([]bool, len(jg.orig)),
 	}
 
 	// len(j.nodes) is the order of g.
@@ -63,14 +77,22 @@ func CyclesIn(g graph.Directed) [][]graph.Node {
 		}
 		//L3:
 		_ = j.circuit(j.s)
+		if j.err != nil {
+			break
+		}
 		j.s++
 	}
 
-	return j.result
+	return j.result, j.err
 }
 
 // circuit is the CIRCUIT sub-procedure in the paper.
 func (j *johnson) circuit(v int) bool {
+	if j.cancelled != nil {
+		if j.err = j.cancelled(); j.err != nil {
+			return false
+		}
+	}
 	f := false
 	n := j.adjacent.orig[v]
 	j.stack = append(j.stack, n)
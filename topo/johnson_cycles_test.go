@@ -5,11 +5,13 @@
 package topo
 
 import (
+	"errors"
 	"math"
 	"reflect"
 	"sort"
 	"testing"
 
+	"github.com/gonum/graph"
 	"github.com/gonum/graph/internal/ordered"
 	"github.com/gonum/graph/simple"
 )
@@ -117,3 +119,41 @@ func TestCyclesIn(t *testing.T) {
 		}
 	}
 }
+
+func TestCyclesInCancelled(t *testing.T) {
+	g := completeDirected(6)
+	all := CyclesIn(g)
+
+	errStop := errors.New("stop")
+	var calls int
+	got, err := cyclesIn(g, func() error {
+		if calls++; calls > 20 {
+			return errStop
+		}
+		return nil
+	})
+	if err != errStop {
+		t.Errorf("unexpected error for partial search: got:%v want:%v", err, errStop)
+	}
+	if len(got) == 0 || len(got) >= len(all) {
+		t.Errorf("unexpected number of cycles for partial search: got:%d want in (0, %d)", len(got), len(all))
+	}
+	for _, c := range got {
+		if !IsPathIn(g, c) || c[0].ID() != c[len(c)-1].ID() {
+			t.Errorf("partial result holds invalid cycle: %v", c)
+		}
+	}
+}
+
+// completeDirected returns the complete directed graph on n nodes.
+func completeDirected(n int) graph.Directed {
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	for u := 0; u < n; u++ {
+		for v := 0; v < n; v++ {
+			if u != v {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+	}
+	return g
+}
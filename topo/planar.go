@@ -0,0 +1,614 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"sort"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+)
+
+// Embedding is a combinatorial embedding of an undirected graph. It holds
+// for each node ID the neighbours of the node in clockwise order.
+type Embedding map[int][]graph.Node
+
+// IsPlanar returns whether the undirected graph g is planar.
+func IsPlanar(g graph.Undirected) bool {
+	p := newPlanarGraph(g)
+	return p.planar(nil) != nil
+}
+
+// Planarity returns whether the undirected graph g is planar. If g is planar,
+// embedding holds a planar embedding of g and kuratowski is nil. Otherwise
+// embedding is nil and kuratowski holds the edges of a subgraph of g that is
+// a subdivision of K₅ or K₃,₃. Self loops are ignored.
+//
+// The planarity test and embedding use the left-right planarity algorithm
+// and take linear time. The Kuratowski subgraph is found by repeated edge
+// deletion and takes quadratic time in the number of edges of g.
+//
+//	Brandes "The Left-Right Planarity Test"
+//	http://www.inf.uni-konstanz.de/algo/publications/b-lrpt-sub.pdf
+func Planarity(g graph.Undirected) (planar bool, embedding Embedding, kuratowski []graph.Edge) {
+	p := newPlanarGraph(g)
+	rot := p.planar(nil)
+	if rot == nil {
+		return false, nil, p.kuratowski(g)
+	}
+	embedding = make(Embedding, len(p.nodes))
+	for u, r := range rot {
+		nbrs := make([]graph.Node, len(r))
+		for i, v := range r {
+			nbrs[i] = p.nodes[v]
+		}
+		embedding[p.nodes[u].ID()] = nbrs
+	}
+	return true, embedding, nil
+}
+
+// planarGraph is an index-based representation of an undirected
+// graph without self loops used for planarity testing.
+type planarGraph struct {
+	nodes []graph.Node
+	adj   [][]int
+}
+
+func newPlanarGraph(g graph.Undirected) planarGraph {
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+	indexOf := make(map[int]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+	adj := make([][]int, len(nodes))
+	for i, u := range nodes {
+		for _, v := range g.From(u) {
+			if j := indexOf[v.ID()]; j != i {
+				adj[i] = append(adj[i], j)
+			}
+		}
+		sort.Ints(adj[i])
+	}
+	return planarGraph{nodes: nodes, adj: adj}
+}
+
+// planar returns the clockwise rotation of neighbours about each node of a
+// planar embedding of the graph, or nil if the graph is not planar. If
+// removed is not nil, edges in removed are considered absent.
+func (p planarGraph) planar(removed map[lrEdge]bool) [][]int {
+	adj := p.adj
+	if removed != nil {
+		adj = make([][]int, len(p.adj))
+		for u, nbrs := range p.adj {
+			for _, v := range nbrs {
+				if !removed[lrEdge{u, v}] && !removed[lrEdge{v, u}] {
+					adj[u] = append(adj[u], v)
+				}
+			}
+		}
+	}
+	return newLRPlanarity(adj).embed()
+}
+
+// kuratowski returns the edges of a minimal non-planar subgraph of the
+// non-planar graph g.
+func (p planarGraph) kuratowski(g graph.Undirected) []graph.Edge {
+	removed := make(map[lrEdge]bool)
+	for u, nbrs := range p.adj {
+		for _, v := range nbrs {
+			if v < u {
+				continue
+			}
+			e := lrEdge{u, v}
+			removed[e] = true
+			if p.planar(removed) != nil {
+				delete(removed, e)
+			}
+		}
+	}
+	var edges []graph.Edge
+	for u, nbrs := range p.adj {
+		for _, v := range nbrs {
+			if v > u && !removed[lrEdge{u, v}] {
+				edges = append(edges, g.Edge(p.nodes[u], p.nodes[v]))
+			}
+		}
+	}
+	return edges
+}
+
+// lrEdge is an oriented edge.
+type lrEdge struct{ u, v int }
+
+// noEdge is the absent edge.
+var noEdge = lrEdge{-1, -1}
+
+// lrInterval is an interval of return edges.
+type lrInterval struct{ low, high lrEdge }
+
+func (i lrInterval) empty() bool { return i.low == noEdge && i.high == noEdge }
+
+// conflictPair is a pair of intervals of return edges
+// that must be embedded on opposite sides.
+type conflictPair struct{ left, right lrInterval }
+
+func newConflictPair() *conflictPair {
+	return &conflictPair{
+		left:  lrInterval{low: noEdge, high: noEdge},
+		right: lrInterval{low: noEdge, high: noEdge},
+	}
+}
+
+func (p *conflictPair) swap() { p.left, p.right = p.right, p.left }
+
+// lrPlanarity holds the state of the left-right planarity test.
+type lrPlanarity struct {
+	adj [][]int
+
+	roots      []int
+	height     []int
+	parentEdge []lrEdge
+
+	// oriented holds the DFS orientation of
+	// the graph's edges in the order they
+	// were oriented.
+	oriented [][]int
+	isEdge   map[lrEdge]bool
+
+	lowpt, lowpt2 map[lrEdge]int
+	nestingDepth  map[lrEdge]int
+
+	ref  map[lrEdge]lrEdge
+	side map[lrEdge]int
+
+	stack       []*conflictPair
+	stackBottom map[lrEdge]*conflictPair
+	lowptEdge   map[lrEdge]lrEdge
+
+	leftRef, rightRef []int
+}
+
+func newLRPlanarity(adj [][]int) *lrPlanarity {
+	n := len(adj)
+	lr := &lrPlanarity{
+		adj:        adj,
+		height:     make([]int, n),
+		parentEdge: make([]lrEdge, n),
+		oriented:   make([][]int, n),
+		isEdge:     make(map[lrEdge]bool),
+
+		lowpt:        make(map[lrEdge]int),
+		lowpt2:       make(map[lrEdge]int),
+		nestingDepth: make(map[lrEdge]int),
+
+		ref:  make(map[lrEdge]lrEdge),
+		side: make(map[lrEdge]int),
+
+		stackBottom: make(map[lrEdge]*conflictPair),
+		lowptEdge:   make(map[lrEdge]lrEdge),
+
+		leftRef:  make([]int, n),
+		rightRef: make([]int, n),
+	}
+	for i := range lr.height {
+		lr.height[i] = -1
+		lr.parentEdge[i] = noEdge
+	}
+	return lr
+}
+
+// embed performs the left-right planarity test and returns the
+// clockwise neighbour rotations of a planar embedding, or nil if
+// the graph is not planar.
+func (lr *lrPlanarity) embed() [][]int {
+	n := len(lr.adj)
+	var m int
+	for _, nbrs := range lr.adj {
+		m += len(nbrs)
+	}
+	m /= 2
+	if n > 2 && m > 3*n-6 {
+		return nil
+	}
+
+	// Orientation phase.
+	for v := range lr.adj {
+		if lr.height[v] < 0 {
+			lr.height[v] = 0
+			lr.roots = append(lr.roots, v)
+			lr.orient(v)
+		}
+	}
+
+	// Testing phase.
+	for v := range lr.oriented {
+		lr.sortByNesting(v)
+	}
+	for _, v := range lr.roots {
+		if !lr.test(v) {
+			return nil
+		}
+	}
+
+	// Embedding phase.
+	for e, d := range lr.nestingDepth {
+		lr.nestingDepth[e] = lr.sign(e) * d
+	}
+	rot := newRotation(n)
+	for v := range lr.oriented {
+		lr.sortByNesting(v)
+		prev := -1
+		for _, w := range lr.oriented[v] {
+			rot.addCW(v, w, prev)
+			prev = w
+		}
+	}
+	for _, v := range lr.roots {
+		lr.embedFrom(v, rot)
+	}
+	return rot.cycles()
+}
+
+func (lr *lrPlanarity) sortByNesting(v int) {
+	sort.Stable(byNesting{v: v, nbrs: lr.oriented[v], depth: lr.nestingDepth})
+}
+
+// orient performs the DFS orientation of the graph from v, calculating
+// edge lowpoints and nesting depths.
+func (lr *lrPlanarity) orient(v int) {
+	e := lr.parentEdge[v]
+	for _, w := range lr.adj[v] {
+		if lr.isEdge[lrEdge{v, w}] || lr.isEdge[lrEdge{w, v}] {
+			continue
+		}
+		vw := lrEdge{v, w}
+		lr.isEdge[vw] = true
+		lr.oriented[v] = append(lr.oriented[v], w)
+		lr.lowpt[vw] = lr.height[v]
+		lr.lowpt2[vw] = lr.height[v]
+		if lr.height[w] < 0 {
+			// Tree edge.
+			lr.parentEdge[w] = vw
+			lr.height[w] = lr.height[v] + 1
+			lr.orient(w)
+		} else {
+			// Back edge.
+			lr.lowpt[vw] = lr.height[w]
+		}
+
+		// Determine the nesting depth.
+		lr.nestingDepth[vw] = 2 * lr.lowpt[vw]
+		if lr.lowpt2[vw] < lr.height[v] {
+			// The edge is chordal.
+			lr.nestingDepth[vw]++
+		}
+
+		// Update the lowpoints of the parent edge.
+		if e != noEdge {
+			switch {
+			case lr.lowpt[vw] < lr.lowpt[e]:
+				lr.lowpt2[e] = min(lr.lowpt[e], lr.lowpt2[vw])
+				lr.lowpt[e] = lr.lowpt[vw]
+			case lr.lowpt[vw] > lr.lowpt[e]:
+				lr.lowpt2[e] = min(lr.lowpt2[e], lr.lowpt[vw])
+			default:
+				lr.lowpt2[e] = min(lr.lowpt2[e], lr.lowpt2[vw])
+			}
+		}
+	}
+}
+
+// test performs the left-right partition test from v and returns
+// whether the subtree rooted at v satisfies the constraints.
+func (lr *lrPlanarity) test(v int) bool {
+	e := lr.parentEdge[v]
+	for i, w := range lr.oriented[v] {
+		ei := lrEdge{v, w}
+		lr.stackBottom[ei] = lr.top()
+		if ei == lr.parentEdge[w] {
+			// Tree edge.
+			if !lr.test(w) {
+				return false
+			}
+		} else {
+			// Back edge.
+			lr.lowptEdge[ei] = ei
+			p := newConflictPair()
+			p.right = lrInterval{low: ei, high: ei}
+			lr.stack = append(lr.stack, p)
+		}
+
+		// Integrate new return edges.
+		if lr.lowpt[ei] < lr.height[v] {
+			if i == 0 {
+				lr.lowptEdge[e] = lr.lowptEdge[ei]
+			} else if !lr.addConstraints(ei, e) {
+				return false
+			}
+		}
+	}
+
+	// Remove back edges returning to the parent.
+	if e != noEdge {
+		lr.removeBackEdges(e)
+	}
+	return true
+}
+
+func (lr *lrPlanarity) top() *conflictPair {
+	if len(lr.stack) == 0 {
+		return nil
+	}
+	return lr.stack[len(lr.stack)-1]
+}
+
+func (lr *lrPlanarity) pop() *conflictPair {
+	p := lr.stack[len(lr.stack)-1]
+	lr.stack = lr.stack[:len(lr.stack)-1]
+	return p
+}
+
+func (lr *lrPlanarity) conflicting(i lrInterval, b lrEdge) bool {
+	return !i.empty() && lr.lowpt[i.high] > lr.lowpt[b]
+}
+
+func (lr *lrPlanarity) lowest(p *conflictPair) int {
+	switch {
+	case p.left.empty():
+		return lr.lowpt[p.right.low]
+	case p.right.empty():
+		return lr.lowpt[p.left.low]
+	}
+	return min(lr.lowpt[p.left.low], lr.lowpt[p.right.low])
+}
+
+func (lr *lrPlanarity) getRef(e lrEdge) lrEdge {
+	r, ok := lr.ref[e]
+	if !ok {
+		return noEdge
+	}
+	return r
+}
+
+func (lr *lrPlanarity) addConstraints(ei, e lrEdge) bool {
+	p := newConflictPair()
+
+	// Merge return edges of ei into p.right.
+	for {
+		q := lr.pop()
+		if !q.left.empty() {
+			q.swap()
+		}
+		if !q.left.empty() {
+			return false
+		}
+		if lr.lowpt[q.right.low] > lr.lowpt[e] {
+			// Merge intervals.
+			if p.right.empty() {
+				p.right = q.right
+			} else {
+				lr.ref[p.right.low] = q.right.high
+			}
+			p.right.low = q.right.low
+		} else {
+			// Align.
+			lr.ref[q.right.low] = lr.lowptEdge[e]
+		}
+		if lr.top() == lr.stackBottom[ei] {
+			break
+		}
+	}
+
+	// Merge conflicting return edges of preceding
+	// edges into p.left.
+	for {
+		t := lr.top()
+		if t == nil || !(lr.conflicting(t.left, ei) || lr.conflicting(t.right, ei)) {
+			break
+		}
+		q := lr.pop()
+		if lr.conflicting(q.right, ei) {
+			q.swap()
+		}
+		if lr.conflicting(q.right, ei) {
+			return false
+		}
+
+		// Merge the interval below lowpt(ei) into p.right.
+		lr.ref[p.right.low] = q.right.high
+		if q.right.low != noEdge {
+			p.right.low = q.right.low
+		}
+		if p.left.empty() {
+			p.left = q.left
+		} else {
+			lr.ref[p.left.low] = q.left.high
+		}
+		p.left.low = q.left.low
+	}
+
+	if !(p.left.empty() && p.right.empty()) {
+		lr.stack = append(lr.stack, p)
+	}
+	return true
+}
+
+func (lr *lrPlanarity) removeBackEdges(e lrEdge) {
+	u := e.u
+
+	// Trim back edges ending at the parent u,
+	// dropping entire conflict pairs.
+	for len(lr.stack) != 0 && lr.lowest(lr.top()) == lr.height[u] {
+		p := lr.pop()
+		if p.left.low != noEdge {
+			lr.side[p.left.low] = -1
+		}
+	}
+
+	// Consider one more conflict pair.
+	if len(lr.stack) != 0 {
+		p := lr.pop()
+
+		// Trim the left interval.
+		for p.left.high != noEdge && p.left.high.v == u {
+			p.left.high = lr.getRef(p.left.high)
+		}
+		if p.left.high == noEdge && p.left.low != noEdge {
+			// Just emptied.
+			lr.ref[p.left.low] = p.right.low
+			lr.side[p.left.low] = -1
+			p.left.low = noEdge
+		}
+
+		// Trim the right interval.
+		for p.right.high != noEdge && p.right.high.v == u {
+			p.right.high = lr.getRef(p.right.high)
+		}
+		if p.right.high == noEdge && p.right.low != noEdge {
+			// Just emptied.
+			lr.ref[p.right.low] = p.left.low
+			lr.side[p.right.low] = -1
+			p.right.low = noEdge
+		}
+		lr.stack = append(lr.stack, p)
+	}
+
+	// The side of e is the side of a highest return edge.
+	if lr.lowpt[e] < lr.height[u] {
+		t := lr.top()
+		hl, hr := t.left.high, t.right.high
+		if hl != noEdge && (hr == noEdge || lr.lowpt[hl] > lr.lowpt[hr]) {
+			lr.ref[e] = hl
+		} else {
+			lr.ref[e] = hr
+		}
+	}
+}
+
+// sign returns the side of e, resolving references.
+func (lr *lrPlanarity) sign(e lrEdge) int {
+	side, ok := lr.side[e]
+	if !ok {
+		side = 1
+	}
+	if r := lr.getRef(e); r != noEdge {
+		side *= lr.sign(r)
+		lr.side[e] = side
+		delete(lr.ref, e)
+	}
+	return side
+}
+
+// embedFrom completes the embedding rot from v.
+func (lr *lrPlanarity) embedFrom(v int, rot *rotation) {
+	for _, w := range lr.oriented[v] {
+		ei := lrEdge{v, w}
+		if ei == lr.parentEdge[w] {
+			// Tree edge.
+			rot.addFirst(w, v)
+			lr.leftRef[v] = w
+			lr.rightRef[v] = w
+			lr.embedFrom(w, rot)
+		} else {
+			// Back edge.
+			if lr.sign(ei) == 1 {
+				rot.addCW(w, v, lr.rightRef[w])
+			} else {
+				rot.addCCW(w, v, lr.leftRef[w])
+				lr.leftRef[w] = v
+			}
+		}
+	}
+}
+
+// byNesting sorts the oriented neighbours of a node by nesting depth.
+type byNesting struct {
+	v     int
+	nbrs  []int
+	depth map[lrEdge]int
+}
+
+func (s byNesting) Len() int { return len(s.nbrs) }
+func (s byNesting) Less(i, j int) bool {
+	return s.depth[lrEdge{s.v, s.nbrs[i]}] < s.depth[lrEdge{s.v, s.nbrs[j]}]
+}
+func (s byNesting) Swap(i, j int) { s.nbrs[i], s.nbrs[j] = s.nbrs[j], s.nbrs[i] }
+
+// rotation is a rotation system under construction, holding for each node
+// a cyclic doubly linked list of its neighbours.
+type rotation struct {
+	cw, ccw []map[int]int
+	first   []int
+}
+
+func newRotation(n int) *rotation {
+	r := &rotation{
+		cw:    make([]map[int]int, n),
+		ccw:   make([]map[int]int, n),
+		first: make([]int, n),
+	}
+	for i := range r.first {
+		r.cw[i] = make(map[int]int)
+		r.ccw[i] = make(map[int]int)
+		r.first[i] = -1
+	}
+	return r
+}
+
+// addCW adds w to the rotation about v immediately clockwise of ref.
+// If ref is negative, v must have no neighbours in the rotation.
+func (r *rotation) addCW(v, w, ref int) {
+	if ref < 0 {
+		r.cw[v][w] = w
+		r.ccw[v][w] = w
+		r.first[v] = w
+		return
+	}
+	next := r.cw[v][ref]
+	r.cw[v][ref] = w
+	r.cw[v][w] = next
+	r.ccw[v][w] = ref
+	r.ccw[v][next] = w
+}
+
+// addCCW adds w to the rotation about v immediately counterclockwise of
+// ref. If ref is negative, v must have no neighbours in the rotation.
+func (r *rotation) addCCW(v, w, ref int) {
+	if ref < 0 {
+		r.addCW(v, w, -1)
+		return
+	}
+	r.addCW(v, w, r.ccw[v][ref])
+	if ref == r.first[v] {
+		r.first[v] = w
+	}
+}
+
+// addFirst adds w to the rotation about v as the first neighbour.
+func (r *rotation) addFirst(v, w int) {
+	r.addCCW(v, w, r.first[v])
+}
+
+// cycles returns the neighbours of each node in clockwise order
+// starting from the first neighbour.
+func (r *rotation) cycles() [][]int {
+	c := make([][]int, len(r.first))
+	for v, f := range r.first {
+		if f < 0 {
+			c[v] = []int{}
+			continue
+		}
+		w := f
+		for {
+			c[v] = append(c[v], w)
+			w = r.cw[v][w]
+			if w == f {
+				break
+			}
+		}
+	}
+	return c
+}
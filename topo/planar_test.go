@@ -0,0 +1,253 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+var planarityTests = []struct {
+	name string
+	g    []intset
+	want bool
+}{
+	{name: "empty", g: nil, want: true},
+	{name: "isolated", g: []intset{0: nil, 1: nil, 2: nil}, want: true},
+	{name: "K4", g: []intset{0: linksTo(1, 2, 3), 1: linksTo(2, 3), 2: linksTo(3)}, want: true},
+	{
+		name: "K5",
+		g: []intset{
+			0: linksTo(1, 2, 3, 4),
+			1: linksTo(2, 3, 4),
+			2: linksTo(3, 4),
+			3: linksTo(4),
+		},
+		want: false,
+	},
+	{
+		name: "K3,3",
+		g: []intset{
+			0: linksTo(3, 4, 5),
+			1: linksTo(3, 4, 5),
+			2: linksTo(3, 4, 5),
+		},
+		want: false,
+	},
+	{
+		name: "K3,3 minus an edge",
+		g: []intset{
+			0: linksTo(3, 4, 5),
+			1: linksTo(3, 4, 5),
+			2: linksTo(3, 4),
+		},
+		want: true,
+	},
+	{name: "wheel", g: colouringTests[4].g, want: true},
+	{name: "Petersen", g: colouringTests[5].g, want: false},
+	{name: "Grötzsch", g: colouringTests[6].g, want: false},
+	{name: "Batagelj-Zaversnik", g: batageljZaversnikGraph, want: true},
+	{
+		// The cube and octahedron joined by an edge.
+		name: "cube and octahedron",
+		g: []intset{
+			0:  linksTo(1, 3, 4),
+			1:  linksTo(2, 5),
+			2:  linksTo(3, 6),
+			3:  linksTo(7),
+			4:  linksTo(5, 7),
+			5:  linksTo(6),
+			6:  linksTo(7),
+			7:  linksTo(8),
+			8:  linksTo(9, 10, 11, 12),
+			9:  linksTo(10, 12, 13),
+			10: linksTo(11, 13),
+			11: linksTo(12, 13),
+			12: linksTo(13),
+		},
+		want: true,
+	},
+	{
+		// K5 subdivided with a disjoint triangle.
+		name: "subdivided K5",
+		g: []intset{
+			0:  linksTo(5, 2, 3, 4),
+			1:  linksTo(6, 3, 4),
+			2:  linksTo(7, 4),
+			3:  linksTo(4),
+			5:  linksTo(1),
+			6:  linksTo(2),
+			7:  linksTo(3),
+			8:  linksTo(9, 10),
+			9:  linksTo(10),
+			10: nil,
+		},
+		want: false,
+	},
+}
+
+func TestPlanarity(t *testing.T) {
+	for _, test := range planarityTests {
+		g := undirectedFrom(test.g)
+		checkPlanarity(t, test.name, g, test.want)
+	}
+}
+
+func TestPlanarityRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		// Random maximal planar graphs built by inserting
+		// nodes into faces of a triangulation are planar,
+		// and adding any edge makes them non-planar.
+		const n = 20
+		g := simple.NewUndirectedGraph(0, math.Inf(1))
+		faces := [][3]int{{0, 1, 2}, {0, 2, 1}}
+		addEdge(g, 0, 1)
+		addEdge(g, 1, 2)
+		addEdge(g, 2, 0)
+		for v := 3; v < n; v++ {
+			k := rnd.Intn(len(faces))
+			f := faces[k]
+			for _, u := range f {
+				addEdge(g, u, v)
+			}
+			faces[k] = [3]int{f[0], f[1], v}
+			faces = append(faces, [3]int{f[1], f[2], v}, [3]int{f[2], f[0], v})
+		}
+		checkPlanarity(t, fmt.Sprintf("triangulation %d", i), g, true)
+
+		for {
+			u, v := rnd.Intn(n), rnd.Intn(n)
+			if u == v || g.HasEdgeBetween(simple.Node(u), simple.Node(v)) {
+				continue
+			}
+			addEdge(g, u, v)
+			break
+		}
+		checkPlanarity(t, fmt.Sprintf("triangulation %d plus edge", i), g, false)
+	}
+
+	for i := 0; i < 50; i++ {
+		// Random sparse graphs may or may not be planar,
+		// but must be consistent.
+		g := undirectedFrom(gnpAdjacency(rnd, 12, 0.3))
+		checkPlanarity(t, fmt.Sprintf("random %d", i), g, IsPlanar(g))
+	}
+}
+
+func addEdge(g *simple.UndirectedGraph, u, v int) {
+	g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+}
+
+// checkPlanarity checks the results of IsPlanar and Planarity for g.
+func checkPlanarity(t *testing.T, name string, g graph.Undirected, want bool) {
+	if got := IsPlanar(g); got != want {
+		t.Errorf("unexpected IsPlanar result for %q: got:%t want:%t", name, got, want)
+	}
+	planar, embedding, kuratowski := Planarity(g)
+	if planar != want {
+		t.Errorf("unexpected Planarity result for %q: got:%t want:%t", name, planar, want)
+	}
+	if planar {
+		if kuratowski != nil {
+			t.Errorf("unexpected Kuratowski subgraph for planar %q", name)
+		}
+		checkEmbedding(t, name, g, embedding)
+		return
+	}
+	if embedding != nil {
+		t.Errorf("unexpected embedding for non-planar %q", name)
+	}
+	checkKuratowski(t, name, g, kuratowski)
+}
+
+// checkEmbedding checks that embedding is a rotation system of g satisfying
+// Euler's formula for each connected component.
+func checkEmbedding(t *testing.T, name string, g graph.Undirected, embedding Embedding) {
+	nodes := g.Nodes()
+	if len(embedding) != len(nodes) {
+		t.Errorf("unexpected number of nodes in embedding for %q: got:%d want:%d", name, len(embedding), len(nodes))
+		return
+	}
+	next := make(map[[2]int]int)
+	var edges int
+	for _, u := range nodes {
+		rot := embedding[u.ID()]
+		if len(rot) != len(g.From(u)) {
+			t.Errorf("unexpected rotation size for node %d of %q: got:%d want:%d", u.ID(), name, len(rot), len(g.From(u)))
+			return
+		}
+		for i, v := range rot {
+			if !g.HasEdgeBetween(u, v) {
+				t.Errorf("rotation for node %d of %q includes non-neighbour %d", u.ID(), name, v.ID())
+				return
+			}
+			next[[2]int{u.ID(), v.ID()}] = rot[(i+1)%len(rot)].ID()
+		}
+		edges += len(rot)
+	}
+	edges /= 2
+
+	// Trace the faces of the embedding.
+	seen := make(map[[2]int]bool)
+	var faces int
+	for dart := range next {
+		if seen[dart] {
+			continue
+		}
+		faces++
+		for d := dart; !seen[d]; {
+			seen[d] = true
+			d = [2]int{d[1], next[[2]int{d[1], d[0]}]}
+		}
+	}
+
+	var vertices, components int
+	for _, c := range ConnectedComponents(g) {
+		if len(c) > 1 {
+			vertices += len(c)
+			components++
+		}
+	}
+	if vertices-edges+faces != 2*components {
+		t.Errorf("embedding of %q does not satisfy Euler's formula: V=%d E=%d F=%d C=%d", name, vertices, edges, faces, components)
+	}
+}
+
+// checkKuratowski checks that edges is a subdivision of K5 or K3,3 in g.
+func checkKuratowski(t *testing.T, name string, g graph.Undirected, edges []graph.Edge) {
+	h := simple.NewUndirectedGraph(0, math.Inf(1))
+	for _, e := range edges {
+		if !g.HasEdgeBetween(e.From(), e.To()) {
+			t.Errorf("Kuratowski subgraph edge %d-%d of %q not in graph", e.From().ID(), e.To().ID(), name)
+		}
+		h.SetEdge(e)
+	}
+	degrees := make(map[int]int)
+	for _, n := range h.Nodes() {
+		d := len(h.From(n))
+		if d != 2 {
+			degrees[d]++
+		}
+	}
+	if !(len(degrees) == 1 && (degrees[4] == 5 || degrees[3] == 6)) {
+		t.Errorf("Kuratowski subgraph of %q has unexpected branch node degrees: %v", name, degrees)
+	}
+	if IsPlanar(h) {
+		t.Errorf("Kuratowski subgraph of %q is planar", name)
+	}
+	for _, e := range edges {
+		h.RemoveEdge(e)
+		if !IsPlanar(h) {
+			t.Errorf("Kuratowski subgraph of %q is not minimal", name)
+		}
+		h.SetEdge(e)
+	}
+}
@@ -47,8 +47,8 @@ func IsPathIn(g graph.Graph, path []graph.Node) bool {
 // PathExistsIn exists as a helper function. If many tests for path existence
 // are being performed, other approaches will be more efficient.
 func PathExistsIn(g graph.Graph, from, to graph.Node) bool {
-	var t traverse.BreadthFirst
-	return t.Walk(g, from, func(n graph.Node, _ int) bool { return n.ID() == to.ID() }) != nil
+	var t traverse.Bidirectional
+	return t.Reachable(g, from, to)
 }
 
 // ConnectedComponents returns the connected components of the undirected graph g.
@@ -70,3 +70,27 @@ func ConnectedComponents(g graph.Undirected) [][]graph.Node {
 
 	return cc
 }
+
+// ParallelConnectedComponents returns the connected components of the
+// undirected graph g using a parallel level-synchronous breadth-first search
+// with the given number of workers. If workers is not positive,
+// runtime.GOMAXPROCS(0) is used. Components are ordered by their lowest node
+// ID and the nodes within each component are sorted by ID. The graph must be
+// safe for concurrent reads.
+func ParallelConnectedComponents(g graph.Undirected, workers int) [][]graph.Node {
+	w := traverse.ParallelBreadthFirst{Workers: workers}
+	l := w.WalkAll(g)
+
+	var cc [][]graph.Node
+	component := make(map[int]int)
+	for i, n := range l.Nodes {
+		c, ok := component[l.Root[i]]
+		if !ok {
+			c = len(cc)
+			component[l.Root[i]] = c
+			cc = append(cc, nil)
+		}
+		cc[c] = append(cc[c], n)
+	}
+	return cc
+}
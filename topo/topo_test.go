@@ -174,3 +174,32 @@ func TestConnectedComponents(t *testing.T) {
 		}
 	}
 }
+
+func TestParallelConnectedComponents(t *testing.T) {
+	for i, test := range connectedComponentTests {
+		g := simple.NewUndirectedGraph(0, math.Inf(1))
+
+		for u, e := range test.g {
+			if !g.Has(simple.Node(u)) {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		for _, workers := range []int{0, 1, 4} {
+			cc := ParallelConnectedComponents(g, workers)
+			got := make([][]int, len(cc))
+			for j, c := range cc {
+				ids := make([]int, len(c))
+				for k, n := range c {
+					ids[k] = n.ID()
+				}
+				got[j] = ids
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("unexpected connected components for test %d with %d workers:\ngot: %v\nwant:%v", i, workers, got, test.want)
+			}
+		}
+	}
+}
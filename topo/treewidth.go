@@ -0,0 +1,258 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"math"
+	"sort"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+	"github.com/gonum/graph/internal/set"
+	"github.com/gonum/graph/simple"
+)
+
+// TreeDecomposition is a tree decomposition of an undirected graph. Every
+// node and every edge of the graph is contained in some bag, and for every
+// node of the graph the bags containing the node induce a subtree of Tree.
+type TreeDecomposition struct {
+	// Bags holds the bags of the decomposition,
+	// each ordered by node ID.
+	Bags [][]graph.Node
+
+	// Tree is the tree joining the bags. The
+	// IDs of its nodes are indices into Bags.
+	Tree graph.Undirected
+
+	// Width is the width of the decomposition,
+	// one less than the size of its largest bag.
+	Width int
+}
+
+// MinDegreeDecomposition returns a tree decomposition of the undirected graph
+// g obtained from the elimination ordering that repeatedly eliminates a node of
+// minimum degree. Self loops are ignored.
+func MinDegreeDecomposition(g graph.Undirected) TreeDecomposition {
+	return eliminationDecomposition(g, func(e *eliminationGame, v int) int {
+		return e.adj[v].Count()
+	})
+}
+
+// MinFillInDecomposition returns a tree decomposition of the undirected graph
+// g obtained from the elimination ordering that repeatedly eliminates a node
+// whose elimination adds the fewest fill edges. Self loops are ignored.
+func MinFillInDecomposition(g graph.Undirected) TreeDecomposition {
+	return eliminationDecomposition(g, func(e *eliminationGame, v int) int {
+		var fill int
+		for u := range e.adj[v] {
+			for w := range e.adj[v] {
+				if u < w && !e.adj[u].Has(w) {
+					fill++
+				}
+			}
+		}
+		return fill
+	})
+}
+
+// maxExactTreewidthNodes is the largest number of nodes accepted
+// by ExactTreeDecomposition.
+const maxExactTreewidthNodes = 25
+
+// ExactTreeDecomposition returns a tree decomposition of the undirected graph
+// g with minimum width, the treewidth of g. The decomposition is found by
+// dynamic programming over subsets of nodes and takes exponential time and
+// space, so ExactTreeDecomposition is only suitable for small graphs. It will
+// panic if g has more than 25 nodes. Self loops are ignored.
+//
+//	Bodlaender, Fomin, Koster, Kratsch and Thilikos "On exact algorithms for
+//	treewidth" doi:10.1145/2390176.2390188
+func ExactTreeDecomposition(g graph.Undirected) TreeDecomposition {
+	c := newIndexGraph(g)
+	n := len(c.nodes)
+	if n > maxExactTreewidthNodes {
+		panic("topo: graph too large for exact treewidth")
+	}
+	if n == 0 {
+		return decompositionFrom(c, nil)
+	}
+	adj := make([]uint32, n)
+	for u, nbrs := range c.adj {
+		for _, v := range nbrs {
+			adj[u] |= 1 << uint(v)
+		}
+	}
+
+	// tw[s] holds the least width of eliminating the nodes
+	// of s first, and last[s] holds the node of s eliminated
+	// last to achieve it. The node v eliminated after the
+	// nodes of s has as neighbours the nodes outside s
+	// reachable from v through s.
+	all := uint32(1)<<uint(n) - 1
+	tw := make([]int8, all+1)
+	last := make([]int8, all+1)
+	tw[0] = -1
+	for s := uint32(1); s <= all; s++ {
+		best := int8(math.MaxInt8)
+		for v := 0; v < n; v++ {
+			bit := uint32(1) << uint(v)
+			if s&bit == 0 {
+				continue
+			}
+			rest := s &^ bit
+			w := tw[rest]
+			if w >= best {
+				continue
+			}
+			if q := int8(popcount(reachable(adj, rest, v) &^ s)); q > w {
+				w = q
+			}
+			if w < best {
+				best = w
+				last[s] = int8(v)
+			}
+		}
+		tw[s] = best
+	}
+
+	order := make([]int, n)
+	for s, i := all, n-1; s != 0; i-- {
+		v := int(last[s])
+		order[i] = v
+		s &^= 1 << uint(v)
+	}
+	return decompositionFrom(c, order)
+}
+
+// reachable returns the set of nodes adjacent to the nodes reachable
+// from v through nodes in s.
+func reachable(adj []uint32, s uint32, v int) uint32 {
+	comp := uint32(1) << uint(v)
+	for {
+		var nbrs uint32
+		for u, rest := 0, comp; rest != 0; u, rest = u+1, rest>>1 {
+			if rest&1 != 0 {
+				nbrs |= adj[u]
+			}
+		}
+		next := comp | nbrs&s
+		if next == comp {
+			return nbrs &^ comp
+		}
+		comp = next
+	}
+}
+
+func popcount(x uint32) int {
+	var n int
+	for ; x != 0; x &= x - 1 {
+		n++
+	}
+	return n
+}
+
+// eliminationGame is the state of the elimination of nodes from a graph,
+// where eliminating a node makes its remaining neighbours a clique.
+type eliminationGame struct {
+	adj []set.Ints
+}
+
+// eliminationDecomposition returns the tree decomposition of g from the
+// elimination ordering that repeatedly eliminates the node with the least
+// cost, with ties broken by lowest ID.
+func eliminationDecomposition(g graph.Undirected, cost func(e *eliminationGame, v int) int) TreeDecomposition {
+	c := newIndexGraph(g)
+	n := len(c.nodes)
+	e := &eliminationGame{adj: make([]set.Ints, n)}
+	for u, nbrs := range c.adj {
+		e.adj[u] = make(set.Ints)
+		for _, v := range nbrs {
+			e.adj[u].Add(v)
+		}
+	}
+	eliminated := make([]bool, n)
+	order := make([]int, 0, n)
+	for len(order) < n {
+		v, min := -1, 0
+		for u, done := range eliminated {
+			if done {
+				continue
+			}
+			if c := cost(e, u); v < 0 || c < min {
+				v, min = u, c
+			}
+		}
+		for u := range e.adj[v] {
+			e.adj[u].Remove(v)
+			for w := range e.adj[v] {
+				if u != w {
+					e.adj[u].Add(w)
+				}
+			}
+		}
+		e.adj[v] = nil
+		eliminated[v] = true
+		order = append(order, v)
+	}
+	return decompositionFrom(c, order)
+}
+
+// decompositionFrom returns the tree decomposition of the graph c obtained
+// by eliminating its nodes in the given order. The bag for each node holds
+// the node and its neighbours when it is eliminated, and is joined to the bag
+// of the first of those neighbours to be eliminated. Bags of nodes with no
+// such neighbours are joined in a path.
+func decompositionFrom(c indexGraph, order []int) TreeDecomposition {
+	n := len(c.nodes)
+	pos := make([]int, n)
+	for i, v := range order {
+		pos[v] = i
+	}
+	adj := make([]set.Ints, n)
+	for u, nbrs := range c.adj {
+		adj[u] = make(set.Ints)
+		for _, v := range nbrs {
+			adj[u].Add(v)
+		}
+	}
+
+	d := TreeDecomposition{Bags: make([][]graph.Node, n), Width: -1}
+	tree := simple.NewUndirectedGraph(0, math.Inf(1))
+	for i := range order {
+		tree.AddNode(simple.Node(i))
+	}
+	lastRoot := -1
+	for i, v := range order {
+		bag := []graph.Node{c.nodes[v]}
+		parent := -1
+		for u := range adj[v] {
+			bag = append(bag, c.nodes[u])
+			if parent < 0 || pos[u] < pos[parent] {
+				parent = u
+			}
+			adj[u].Remove(v)
+			for w := range adj[v] {
+				if u != w {
+					adj[u].Add(w)
+				}
+			}
+		}
+		sort.Sort(ordered.ByID(bag))
+		d.Bags[i] = bag
+		if len(bag)-1 > d.Width {
+			d.Width = len(bag) - 1
+		}
+		if parent < 0 {
+			if lastRoot >= 0 {
+				tree.SetEdge(simple.Edge{F: simple.Node(lastRoot), T: simple.Node(i)})
+			}
+			lastRoot = i
+			continue
+		}
+		tree.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(pos[parent])})
+	}
+	d.Tree = tree
+	return d
+}
@@ -0,0 +1,192 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/graph"
+)
+
+var treewidthTests = []struct {
+	name string
+	g    []intset
+	want int
+}{
+	{name: "empty", g: nil, want: -1},
+	{name: "isolated", g: []intset{0: nil, 1: nil, 2: nil}, want: 0},
+	{name: "tree", g: chordalityTests[7].g, want: 1},
+	{name: "C5", g: colouringTests[2].g, want: 2},
+	{name: "K4", g: chordalityTests[2].g, want: 3},
+	{name: "wheel", g: colouringTests[4].g, want: 3},
+	{name: "Petersen", g: colouringTests[5].g, want: 4},
+	{name: "K3,3", g: planarityTests[4].g, want: 3},
+	{
+		name: "3×3 grid",
+		g: []intset{
+			0: linksTo(1, 3),
+			1: linksTo(2, 4),
+			2: linksTo(5),
+			3: linksTo(4, 6),
+			4: linksTo(5, 7),
+			5: linksTo(8),
+			6: linksTo(7),
+			7: linksTo(8),
+		},
+		want: 3,
+	},
+	{name: "Batagelj-Zaversnik", g: batageljZaversnikGraph, want: 3},
+}
+
+func TestTreeDecomposition(t *testing.T) {
+	for _, test := range treewidthTests {
+		g := undirectedFrom(test.g)
+		exact := ExactTreeDecomposition(g)
+		checkTreeDecomposition(t, test.name+" exact", g, exact)
+		if exact.Width != test.want {
+			t.Errorf("unexpected treewidth for %q: got:%d want:%d", test.name, exact.Width, test.want)
+		}
+		for _, h := range []struct {
+			name string
+			fn   func(graph.Undirected) TreeDecomposition
+		}{
+			{name: "min-degree", fn: MinDegreeDecomposition},
+			{name: "min-fill-in", fn: MinFillInDecomposition},
+		} {
+			d := h.fn(g)
+			checkTreeDecomposition(t, test.name+" "+h.name, g, d)
+			if d.Width < test.want {
+				t.Errorf("%s decomposition of %q has width less than treewidth: %d", h.name, test.name, d.Width)
+			}
+		}
+	}
+}
+
+func TestExactTreeDecompositionRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		g := undirectedFrom(gnpAdjacency(rnd, 7, 0.4))
+		name := fmt.Sprintf("random %d", i)
+		d := ExactTreeDecomposition(g)
+		checkTreeDecomposition(t, name, g, d)
+		if want := bruteForceTreewidth(g); d.Width != want {
+			t.Errorf("unexpected treewidth for %s: got:%d want:%d", name, d.Width, want)
+		}
+		for _, h := range []TreeDecomposition{MinDegreeDecomposition(g), MinFillInDecomposition(g)} {
+			checkTreeDecomposition(t, name, g, h)
+			if h.Width < d.Width {
+				t.Errorf("heuristic decomposition of %s has width less than treewidth: %d < %d", name, h.Width, d.Width)
+			}
+		}
+	}
+}
+
+// checkTreeDecomposition checks that d is a valid tree decomposition of g.
+func checkTreeDecomposition(t *testing.T, name string, g graph.Undirected, d TreeDecomposition) {
+	width := -1
+	contains := make(map[int][]int)
+	for i, bag := range d.Bags {
+		if len(bag)-1 > width {
+			width = len(bag) - 1
+		}
+		for _, n := range bag {
+			contains[n.ID()] = append(contains[n.ID()], i)
+		}
+	}
+	if d.Width != width {
+		t.Errorf("unexpected width for %q: got:%d want:%d", name, d.Width, width)
+	}
+
+	// The tree must be a tree over the bags.
+	tree := d.Tree
+	if len(tree.Nodes()) != len(d.Bags) {
+		t.Errorf("unexpected number of tree nodes for %q: got:%d want:%d", name, len(tree.Nodes()), len(d.Bags))
+		return
+	}
+	var edges int
+	for _, n := range tree.Nodes() {
+		edges += len(tree.From(n))
+	}
+	if len(d.Bags) != 0 && (edges/2 != len(d.Bags)-1 || len(ConnectedComponents(tree)) != 1) {
+		t.Errorf("decomposition tree for %q is not a tree", name)
+	}
+
+	// Every node and edge must be in a bag.
+	for _, u := range g.Nodes() {
+		if len(contains[u.ID()]) == 0 {
+			t.Errorf("node %d of %q not in any bag", u.ID(), name)
+		}
+		for _, v := range g.From(u) {
+			var found bool
+			for _, i := range contains[u.ID()] {
+				for _, w := range d.Bags[i] {
+					if w.ID() == v.ID() {
+						found = true
+					}
+				}
+			}
+			if !found {
+				t.Errorf("edge %d-%d of %q not in any bag", u.ID(), v.ID(), name)
+			}
+		}
+	}
+
+	// The bags containing each node must be connected.
+	for id, bags := range contains {
+		in := make(map[int]bool)
+		for _, i := range bags {
+			in[i] = true
+		}
+		seen := map[int]bool{bags[0]: true}
+		queue := []int{bags[0]}
+		for len(queue) != 0 {
+			i := queue[0]
+			queue = queue[1:]
+			for _, j := range tree.From(treeNode(i)) {
+				if in[j.ID()] && !seen[j.ID()] {
+					seen[j.ID()] = true
+					queue = append(queue, j.ID())
+				}
+			}
+		}
+		if len(seen) != len(bags) {
+			t.Errorf("bags containing node %d of %q are not connected", id, name)
+		}
+	}
+}
+
+type treeNode int
+
+func (n treeNode) ID() int { return int(n) }
+
+// bruteForceTreewidth returns the treewidth of g by trying all
+// elimination orderings.
+func bruteForceTreewidth(g graph.Undirected) int {
+	c := newIndexGraph(g)
+	n := len(c.nodes)
+	best := n
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	var permute func(k int)
+	permute = func(k int) {
+		if k == n {
+			if w := decompositionFrom(c, perm).Width; w < best {
+				best = w
+			}
+			return
+		}
+		for i := k; i < n; i++ {
+			perm[k], perm[i] = perm[i], perm[k]
+			permute(k + 1)
+			perm[k], perm[i] = perm[i], perm[k]
+		}
+	}
+	permute(0)
+	return best
+}
@@ -0,0 +1,91 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"fmt"
+
+	"github.com/gonum/graph/simple"
+)
+
+// Literal is a Boolean variable or its negation in a 2-SAT formula.
+type Literal struct {
+	// Var is the index of the variable.
+	Var int
+
+	// Negated indicates whether the
+	// literal is the variable's negation.
+	Negated bool
+}
+
+// Not returns the negation of l.
+func (l Literal) Not() Literal {
+	return Literal{Var: l.Var, Negated: !l.Negated}
+}
+
+// Clause is a disjunction of two literals.
+type Clause [2]Literal
+
+// TwoSAT returns whether the conjunction of clauses over n variables is
+// satisfiable, and if it is a satisfying assignment indexed by variable. The
+// implication graph of the formula, with an edge from the negation of each
+// literal in a clause to the other literal, is built as a simple.DirectedGraph
+// and its strongly connected components are found with TarjanSCC. The formula
+// is unsatisfiable exactly when a variable and its negation are in the same
+// component. TwoSAT will panic if a clause refers to a variable outside the
+// range [0, n).
+//
+//	Aspvall, Plass and Tarjan "A linear-time algorithm for testing the truth
+//	of certain quantified boolean formulas" doi:10.1016/0020-0190(79)90002-4
+func TwoSAT(n int, clauses []Clause) (assignment []bool, ok bool) {
+	g := simple.NewDirectedGraph(0, 0)
+	for v := 0; v < n; v++ {
+		g.AddNode(simple.Node(literalID(Literal{Var: v})))
+		g.AddNode(simple.Node(literalID(Literal{Var: v, Negated: true})))
+	}
+	for _, c := range clauses {
+		for _, l := range c {
+			if l.Var < 0 || l.Var >= n {
+				panic(fmt.Sprintf("topo: variable %d out of range", l.Var))
+			}
+		}
+		a, b := c[0], c[1]
+		if a.Not() == b {
+			// The clause is a tautology.
+			continue
+		}
+		g.SetEdge(simple.Edge{F: simple.Node(literalID(a.Not())), T: simple.Node(literalID(b))})
+		g.SetEdge(simple.Edge{F: simple.Node(literalID(b.Not())), T: simple.Node(literalID(a))})
+	}
+
+	// TarjanSCC returns components in reverse topological
+	// order. Setting each literal true when its component
+	// is later in the topological order than its negation's
+	// ensures no true literal implies a false one.
+	comp := make(map[int]int, 2*n)
+	for i, scc := range TarjanSCC(g) {
+		for _, l := range scc {
+			comp[l.ID()] = i
+		}
+	}
+	assignment = make([]bool, n)
+	for v := range assignment {
+		pos := comp[literalID(Literal{Var: v})]
+		neg := comp[literalID(Literal{Var: v, Negated: true})]
+		if pos == neg {
+			return nil, false
+		}
+		assignment[v] = pos < neg
+	}
+	return assignment, true
+}
+
+// literalID returns the implication graph node ID of l.
+func literalID(l Literal) int {
+	if l.Negated {
+		return 2*l.Var + 1
+	}
+	return 2 * l.Var
+}
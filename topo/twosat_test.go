@@ -0,0 +1,120 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func lit(v int) Literal    { return Literal{Var: v} }
+func notLit(v int) Literal { return Literal{Var: v, Negated: true} }
+
+var twoSATTests = []struct {
+	name    string
+	n       int
+	clauses []Clause
+	want    bool
+}{
+	{name: "empty", n: 0, want: true},
+	{name: "free", n: 3, want: true},
+	{
+		name:    "tautology",
+		n:       1,
+		clauses: []Clause{{lit(0), notLit(0)}},
+		want:    true,
+	},
+	{
+		name:    "unit",
+		n:       2,
+		clauses: []Clause{{notLit(0), notLit(0)}, {lit(0), lit(1)}},
+		want:    true,
+	},
+	{
+		name: "contradiction",
+		n:    1,
+		clauses: []Clause{
+			{lit(0), lit(0)},
+			{notLit(0), notLit(0)},
+		},
+		want: false,
+	},
+	{
+		name: "implication cycle",
+		n:    3,
+		clauses: []Clause{
+			{notLit(0), lit(1)},
+			{notLit(1), lit(2)},
+			{notLit(2), notLit(0)},
+			{lit(0), lit(1)},
+		},
+		want: true,
+	},
+	{
+		name: "unsatisfiable",
+		n:    2,
+		clauses: []Clause{
+			{lit(0), lit(1)},
+			{lit(0), notLit(1)},
+			{notLit(0), lit(1)},
+			{notLit(0), notLit(1)},
+		},
+		want: false,
+	},
+}
+
+func TestTwoSAT(t *testing.T) {
+	for _, test := range twoSATTests {
+		assignment, ok := TwoSAT(test.n, test.clauses)
+		if ok != test.want {
+			t.Errorf("unexpected satisfiability for %q: got:%t want:%t", test.name, ok, test.want)
+			continue
+		}
+		if ok && !satisfies(assignment, test.clauses) {
+			t.Errorf("assignment for %q does not satisfy clauses: %v", test.name, assignment)
+		}
+	}
+}
+
+func TestTwoSATRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		n := 1 + rnd.Intn(8)
+		clauses := make([]Clause, rnd.Intn(3*n))
+		for j := range clauses {
+			for k := range clauses[j] {
+				clauses[j][k] = Literal{Var: rnd.Intn(n), Negated: rnd.Intn(2) == 0}
+			}
+		}
+		assignment, ok := TwoSAT(n, clauses)
+		want := false
+		for mask := 0; mask < 1<<uint(n); mask++ {
+			a := make([]bool, n)
+			for v := range a {
+				a[v] = mask&(1<<uint(v)) != 0
+			}
+			if satisfies(a, clauses) {
+				want = true
+				break
+			}
+		}
+		if ok != want {
+			t.Errorf("unexpected satisfiability for random formula %d: got:%t want:%t", i, ok, want)
+			continue
+		}
+		if ok && !satisfies(assignment, clauses) {
+			t.Errorf("assignment for random formula %d does not satisfy clauses", i)
+		}
+	}
+}
+
+func satisfies(assignment []bool, clauses []Clause) bool {
+	for _, c := range clauses {
+		if assignment[c[0].Var] == c[0].Negated && assignment[c[1].Var] == c[1].Negated {
+			return false
+		}
+	}
+	return true
+}
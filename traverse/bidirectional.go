@@ -0,0 +1,120 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import "github.com/gonum/graph"
+
+// Bidirectional implements bidirectional breadth-first search between
+// pairs of nodes. The search alternately expands a level from the source
+// and from the target, choosing the side with the smaller frontier, until
+// the two searches meet.
+type Bidirectional struct {
+	// EdgeFilter, if non-nil, restricts the search
+	// to edges for which EdgeFilter(edge) is true.
+	EdgeFilter func(graph.Edge) bool
+}
+
+// Path returns a path in g with the fewest edges from the node from to the
+// node to, following edges for which EdgeFilter(edge) is true if EdgeFilter
+// is non-nil. If g is a graph.Directed, the path follows edge directions.
+// Path returns nil if no path exists or if either node is not in g.
+func (b *Bidirectional) Path(g graph.Graph, from, to graph.Node) []graph.Node {
+	if !g.Has(from) || !g.Has(to) {
+		return nil
+	}
+	if from.ID() == to.ID() {
+		return []graph.Node{from}
+	}
+
+	backward := g.From
+	if d, ok := g.(graph.Directed); ok {
+		backward = d.To
+	}
+
+	// fwd and bwd hold the node each visited node
+	// was reached from in each direction of search
+	// and its depth in that search.
+	fwd := map[int]step{from.ID(): {}}
+	bwd := map[int]step{to.ID(): {}}
+	fwdFrontier := []graph.Node{from}
+	bwdFrontier := []graph.Node{to}
+	for len(fwdFrontier) != 0 && len(bwdFrontier) != 0 {
+		var u, v graph.Node
+		if len(fwdFrontier) <= len(bwdFrontier) {
+			u, v, fwdFrontier = b.expand(g, g.From, fwdFrontier, fwd, bwd, false)
+		} else {
+			v, u, bwdFrontier = b.expand(g, backward, bwdFrontier, bwd, fwd, true)
+		}
+		if u == nil {
+			continue
+		}
+
+		// The searches have met on the edge u→v.
+		var path []graph.Node
+		for n := u; n != nil; n = fwd[n.ID()].from {
+			path = append(path, n)
+		}
+		reverse(path)
+		for n := v; n != nil; n = bwd[n.ID()].from {
+			path = append(path, n)
+		}
+		return path
+	}
+	return nil
+}
+
+// step is a node's predecessor and depth in a search.
+type step struct {
+	from  graph.Node
+	depth int
+}
+
+// Reachable returns whether there is a path in g from the node from to the
+// node to under the same conditions as Path.
+func (b *Bidirectional) Reachable(g graph.Graph, from, to graph.Node) bool {
+	return b.Path(g, from, to) != nil
+}
+
+// expand expands one level of a search frontier using the neighbours
+// returned by next, recording the nodes reached in seen; backward indicates
+// that next returns nodes with edges into the frontier. If nodes seen by the
+// other search are reached, expand returns the edge joining the two searches
+// with the fewest total edges, with the node in the frontier first.
+func (b *Bidirectional) expand(g graph.Graph, next func(graph.Node) []graph.Node, frontier []graph.Node, seen, other map[int]step, backward bool) (u, v graph.Node, level []graph.Node) {
+	best := -1
+	for _, t := range frontier {
+		depth := seen[t.ID()].depth + 1
+		for _, n := range next(t) {
+			if _, ok := seen[n.ID()]; ok {
+				continue
+			}
+			if b.EdgeFilter != nil {
+				e := g.Edge(t, n)
+				if backward {
+					e = g.Edge(n, t)
+				}
+				if !b.EdgeFilter(e) {
+					continue
+				}
+			}
+			if s, ok := other[n.ID()]; ok {
+				if best < 0 || s.depth < best {
+					u, v = t, n
+					best = s.depth
+				}
+				continue
+			}
+			seen[n.ID()] = step{from: t, depth: depth}
+			level = append(level, n)
+		}
+	}
+	return u, v, level
+}
+
+func reverse(p []graph.Node) {
+	for i, j := 0, len(p)-1; i < j; i, j = i+1, j-1 {
+		p[i], p[j] = p[j], p[i]
+	}
+}
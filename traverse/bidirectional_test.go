@@ -0,0 +1,104 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+var bidirectionalTests = []struct {
+	g        []set
+	directed bool
+	from, to int
+	want     []int
+}{
+	{g: wpBronKerboschGraph, from: 0, to: 5, want: []int{0, 4, 3, 5}},
+	{g: wpBronKerboschGraph, from: 5, to: 0, want: []int{5, 3, 4, 0}},
+	{g: wpBronKerboschGraph, from: 2, to: 2, want: []int{2}},
+	{g: wpBronKerboschGraph, directed: true, from: 0, to: 5, want: []int{0, 1, 2, 3, 5}},
+	{g: wpBronKerboschGraph, directed: true, from: 5, to: 0, want: nil},
+	{g: batageljZaversnikGraph, from: 1, to: 6, want: nil},
+	{g: batageljZaversnikGraph, from: 0, to: 0, want: []int{0}},
+	{g: batageljZaversnikGraph, from: 0, to: 21, want: nil},
+}
+
+func TestBidirectional(t *testing.T) {
+	for i, test := range bidirectionalTests {
+		var g graph.Graph
+		if test.directed {
+			g = directedFrom(test.g)
+		} else {
+			g = undirectedFrom(test.g)
+		}
+		var b Bidirectional
+		path := b.Path(g, simple.Node(test.from), simple.Node(test.to))
+		var got []int
+		for _, n := range path {
+			got = append(got, n.ID())
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected path for test %d: got:%v want:%v", i, got, test.want)
+		}
+		if reachable := b.Reachable(g, simple.Node(test.from), simple.Node(test.to)); reachable != (test.want != nil) {
+			t.Errorf("unexpected reachability for test %d: got:%t want:%t", i, reachable, test.want != nil)
+		}
+	}
+}
+
+func TestBidirectionalShortest(t *testing.T) {
+	filter := func(e graph.Edge) bool {
+		return (e.From().ID()+e.To().ID())%3 != 0
+	}
+	for _, test := range parallelTestGraphs() {
+		for _, f := range []func(graph.Edge) bool{nil, filter} {
+			b := Bidirectional{EdgeFilter: f}
+			for i, from := range test.g.Nodes() {
+				if len(test.g.Nodes()) > 60 && i%10 != 0 {
+					// Keep the quadratic number of searches small.
+					continue
+				}
+				want := hops(test.g, from, f)
+				for _, to := range test.g.Nodes() {
+					name := fmt.Sprintf("%s from=%d to=%d filtered=%t", test.name, from.ID(), to.ID(), f != nil)
+					checkPath(t, name, test.g, b.Path(test.g, from, to), from, to, want[to.ID()], f)
+				}
+			}
+		}
+	}
+}
+
+// checkPath checks that path is a path in g from the node from to the node
+// to with the given number of edges, or nil if edges is negative.
+func checkPath(t *testing.T, name string, g graph.Graph, path []graph.Node, from, to graph.Node, edges int, filter func(graph.Edge) bool) {
+	if edges < 0 {
+		if path != nil {
+			t.Errorf("%s: unexpected path: %v", name, ids(path))
+		}
+		return
+	}
+	if len(path) != edges+1 {
+		t.Errorf("%s: unexpected path length: got:%d want:%d", name, len(path)-1, edges)
+		return
+	}
+	if path[0].ID() != from.ID() || path[len(path)-1].ID() != to.ID() {
+		t.Errorf("%s: unexpected path ends: %v", name, ids(path))
+	}
+	for i := 1; i < len(path); i++ {
+		e := g.Edge(path[i-1], path[i])
+		if e == nil {
+			t.Errorf("%s: path %v not in graph", name, ids(path))
+			return
+		}
+		if filter != nil && !filter(e) {
+			t.Errorf("%s: path %v follows filtered edge", name, ids(path))
+			return
+		}
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.7
+// +build go1.7
+
+package traverse
+
+import (
+	"context"
+
+	"github.com/gonum/graph"
+)
+
+// WalkContext performs a breadth-first traversal in the same way as Walk, but
+// checks ctx before visiting each node. If ctx is cancelled or its deadline
+// expires before the traversal completes, WalkContext returns nil and ctx.Err().
+// The nodes traversed before the cancellation are reported by Visited, and the
+// traversal state is retained until Reset is called.
+func (b *BreadthFirst) WalkContext(ctx context.Context, g graph.Graph, from graph.Node, until func(n graph.Node, d int) bool) (graph.Node, error) {
+	return b.walk(g, from, until, ctx.Err)
+}
+
+// WalkContext performs a depth-first traversal in the same way as Walk, but
+// checks ctx before visiting each node. If ctx is cancelled or its deadline
+// expires before the traversal completes, WalkContext returns nil and ctx.Err().
+// The nodes traversed before the cancellation are reported by Visited, and the
+// traversal state is retained until Reset is called.
+func (d *DepthFirst) WalkContext(ctx context.Context, g graph.Graph, from graph.Node, until func(graph.Node) bool) (graph.Node, error) {
+	return d.walk(g, from, until, ctx.Err)
+}
@@ -0,0 +1,65 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.7
+// +build go1.7
+
+package traverse
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+func TestWalkContext(t *testing.T) {
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	for u, e := range wpBronKerboschGraph {
+		for v := range e {
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var b BreadthFirst
+	n, err := b.WalkContext(ctx, g, simple.Node(0), nil)
+	if n != nil || err != context.Canceled {
+		t.Errorf("unexpected result for cancelled breadth-first walk: got:%v,%v want:<nil>,%v", n, err, context.Canceled)
+	}
+	var d DepthFirst
+	n, err = d.WalkContext(ctx, g, simple.Node(0), nil)
+	if n != nil || err != context.Canceled {
+		t.Errorf("unexpected result for cancelled depth-first walk: got:%v,%v want:<nil>,%v", n, err, context.Canceled)
+	}
+
+	// Cancel part way through the walk.
+	ctx, cancel = context.WithCancel(context.Background())
+	var visited int
+	b.Reset()
+	n, err = b.WalkContext(ctx, g, simple.Node(0), func(graph.Node, int) bool {
+		visited++
+		if visited == 2 {
+			cancel()
+		}
+		return false
+	})
+	if n != nil || err != context.Canceled {
+		t.Errorf("unexpected result for interrupted breadth-first walk: got:%v,%v want:<nil>,%v", n, err, context.Canceled)
+	}
+	if visited != 2 {
+		t.Errorf("unexpected number of nodes visited before cancellation: got:%d want:2", visited)
+	}
+	if !b.Visited(simple.Node(0)) {
+		t.Error("expected start node to be visited")
+	}
+
+	n, err = d.WalkContext(context.Background(), g, simple.Node(0), func(n graph.Node) bool { return n.ID() == 3 })
+	if n == nil || n.ID() != 3 || err != nil {
+		t.Errorf("unexpected result for depth-first walk: got:%v,%v want:3,<nil>", n, err)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import "github.com/gonum/graph"
+
+// IterativeDeepening implements iterative-deepening depth-first search.
+// Depth-limited depth-first searches are repeated with increasing depth
+// limits, so nodes are found in order of depth as in a breadth-first search.
+type IterativeDeepening struct {
+	// EdgeFilter, if non-nil, restricts the search
+	// to edges for which EdgeFilter(edge) is true.
+	EdgeFilter func(graph.Edge) bool
+
+	// depth holds the shallowest depth at which each
+	// node has been reached in the current iteration,
+	// prev holds the depths from the previous iteration
+	// and path holds the current search path.
+	depth, prev map[int]int
+	path        []graph.Node
+}
+
+// Walk performs iterative-deepening depth-first searches of the graph g starting
+// from the given node, following edges for which EdgeFilter(edge) is true if
+// EdgeFilter is non-nil, to a depth of at most limit. If limit is negative the
+// depth is not limited. Walk returns the first node for which until(node, depth)
+// is true, or nil if no such node is found. The function until is called once
+// for each node reached, with the number of edges on a shortest path to the node
+// from the start of the search.
+func (d *IterativeDeepening) Walk(g graph.Graph, from graph.Node, limit int, until func(n graph.Node, depth int) bool) graph.Node {
+	if !g.Has(from) {
+		return nil
+	}
+	defer func() { d.depth, d.prev = nil, nil }()
+	for max := 0; limit < 0 || max <= limit; max++ {
+		d.prev, d.depth = d.depth, make(map[int]int)
+		n, cutoff := d.search(g, from, 0, max, until)
+		if n != nil || !cutoff {
+			return n
+		}
+	}
+	return nil
+}
+
+// Path returns a path in g with the fewest edges from the node from to the
+// node to, following edges for which EdgeFilter(edge) is true if EdgeFilter
+// is non-nil, and with at most limit edges unless limit is negative. Path
+// returns nil if no such path exists.
+func (d *IterativeDeepening) Path(g graph.Graph, from, to graph.Node, limit int) []graph.Node {
+	var path []graph.Node
+	d.Walk(g, from, limit, func(n graph.Node, _ int) bool {
+		if n.ID() != to.ID() {
+			return false
+		}
+		path = append([]graph.Node(nil), d.path...)
+		return true
+	})
+	return path
+}
+
+// search performs a depth-limited search from u at the given depth, returning
+// the first node satisfying until and whether the search was cut off by max.
+func (d *IterativeDeepening) search(g graph.Graph, u graph.Node, depth, max int, until func(graph.Node, int) bool) (found graph.Node, cutoff bool) {
+	d.depth[u.ID()] = depth
+	d.path = append(d.path, u)
+	defer func() { d.path = d.path[:len(d.path)-1] }()
+	// Nodes reached in the previous iteration have
+	// already been offered to until at their depth.
+	if _, ok := d.prev[u.ID()]; !ok && until != nil && until(u, depth) {
+		return u, false
+	}
+	for _, v := range g.From(u) {
+		if d.EdgeFilter != nil && !d.EdgeFilter(g.Edge(u, v)) {
+			continue
+		}
+		if depth == max {
+			cutoff = true
+			break
+		}
+		if dv, ok := d.depth[v.ID()]; ok && dv <= depth+1 {
+			continue
+		}
+		n, c := d.search(g, v, depth+1, max, until)
+		if n != nil {
+			return n, false
+		}
+		cutoff = cutoff || c
+	}
+	return nil, cutoff
+}
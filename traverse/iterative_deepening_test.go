@@ -0,0 +1,111 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+var iterativeDeepeningTests = []struct {
+	g        []set
+	directed bool
+	from     int
+	limit    int
+	until    func(graph.Node, int) bool
+	want     int
+	wantNil  bool
+}{
+	{
+		g:     batageljZaversnikGraph,
+		from:  6,
+		limit: -1,
+		until: func(n graph.Node, _ int) bool { return n.ID() > 16 },
+		want:  17,
+	},
+	{
+		g:       batageljZaversnikGraph,
+		from:    6,
+		limit:   1,
+		until:   func(n graph.Node, _ int) bool { return n.ID() > 16 },
+		wantNil: true,
+	},
+	{
+		g:     batageljZaversnikGraph,
+		from:  1,
+		limit: -1,
+		until: func(_ graph.Node, d int) bool { return d == 3 },
+		want:  5,
+	},
+	{
+		g:        batageljZaversnikGraph,
+		directed: true,
+		from:     4,
+		limit:    -1,
+		until:    func(n graph.Node, _ int) bool { return n.ID() == 1 },
+		wantNil:  true,
+	},
+	{
+		g:       batageljZaversnikGraph,
+		from:    21,
+		limit:   -1,
+		until:   func(graph.Node, int) bool { return true },
+		wantNil: true,
+	},
+}
+
+func TestIterativeDeepening(t *testing.T) {
+	for i, test := range iterativeDeepeningTests {
+		var g graph.Graph
+		if test.directed {
+			g = directedFrom(test.g)
+		} else {
+			g = undirectedFrom(test.g)
+		}
+		var d IterativeDeepening
+		got := d.Walk(g, simple.Node(test.from), test.limit, test.until)
+		switch {
+		case test.wantNil:
+			if got != nil {
+				t.Errorf("unexpected result for test %d: got:%d want:nil", i, got.ID())
+			}
+		case got == nil:
+			t.Errorf("unexpected result for test %d: got:nil want:%d", i, test.want)
+		case got.ID() != test.want:
+			t.Errorf("unexpected result for test %d: got:%d want:%d", i, got.ID(), test.want)
+		}
+	}
+}
+
+func TestIterativeDeepeningPath(t *testing.T) {
+	filter := func(e graph.Edge) bool {
+		return (e.From().ID()+e.To().ID())%3 != 0
+	}
+	for _, test := range parallelTestGraphs() {
+		if len(test.g.Nodes()) > 60 {
+			// Keep the quadratic number of searches small.
+			continue
+		}
+		for _, f := range []func(graph.Edge) bool{nil, filter} {
+			d := IterativeDeepening{EdgeFilter: f}
+			for _, from := range test.g.Nodes() {
+				want := hops(test.g, from, f)
+				for _, to := range test.g.Nodes() {
+					name := fmt.Sprintf("%s from=%d to=%d filtered=%t", test.name, from.ID(), to.ID(), f != nil)
+					checkPath(t, name, test.g, d.Path(test.g, from, to, -1), from, to, want[to.ID()], f)
+
+					limited := want[to.ID()]
+					if limited > 2 {
+						limited = -1
+					}
+					checkPath(t, name+" limit=2", test.g, d.Path(test.g, from, to, 2), from, to, limited, f)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"sort"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+)
+
+// LexBFS returns the nodes of the undirected graph g in lexicographic
+// breadth-first order. Ties are broken in favour of the node with the
+// lowest ID. LexBFS runs in linear time using partition refinement.
+//
+//	Rose, Tarjan and Lueker "Algorithmic aspects of vertex elimination on
+//	graphs" doi:10.1137/0205021
+func LexBFS(g graph.Undirected) []graph.Node {
+	nodes, adj := indexed(g)
+	n := len(nodes)
+	if n == 0 {
+		return nil
+	}
+
+	// The unvisited nodes are held in an ordered list
+	// of cells. Cells hold their members in ascending
+	// order, with members that have been visited or
+	// moved to another cell removed lazily.
+	all := &lexCell{members: make([]int, n), count: n, round: -1}
+	cellOf := make([]*lexCell, n)
+	for i := range all.members {
+		all.members[i] = i
+		cellOf[i] = all
+	}
+	head := all
+	remove := func(c *lexCell) {
+		if c.prev != nil {
+			c.prev.next = c.next
+		} else {
+			head = c.next
+		}
+		if c.next != nil {
+			c.next.prev = c.prev
+		}
+	}
+
+	visited := make([]bool, n)
+	order := make([]graph.Node, 0, n)
+	for round := 0; head != nil; round++ {
+		c := head
+		for visited[c.members[c.head]] || cellOf[c.members[c.head]] != c {
+			c.head++
+		}
+		v := c.members[c.head]
+		c.head++
+		visited[v] = true
+		order = append(order, nodes[v])
+		if c.count--; c.count == 0 {
+			remove(c)
+		}
+
+		// Move unvisited neighbours of v into new cells
+		// immediately preceding their current cells.
+		for _, w := range adj[v] {
+			if visited[w] {
+				continue
+			}
+			old := cellOf[w]
+			if old.round != round {
+				split := &lexCell{round: round, prev: old.prev, next: old}
+				if old.prev != nil {
+					old.prev.next = split
+				} else {
+					head = split
+				}
+				old.prev = split
+				old.split = split
+				old.round = round
+			}
+			split := old.split
+			split.members = append(split.members, w)
+			split.count++
+			cellOf[w] = split
+			if old.count--; old.count == 0 {
+				remove(old)
+			}
+		}
+	}
+	return order
+}
+
+// lexCell is a cell of the partition refined by LexBFS.
+type lexCell struct {
+	members []int
+	head    int
+	count   int
+
+	prev, next *lexCell
+
+	// split is the cell created from this cell
+	// during the given round of refinement.
+	split *lexCell
+	round int
+}
+
+// MaximumCardinalitySearch returns the nodes of the undirected graph g in
+// maximum cardinality search order, where each node visited is an unvisited
+// node with the greatest number of visited neighbours. The first node visited
+// is the node with the lowest ID. MaximumCardinalitySearch runs in linear time.
+//
+//	Tarjan and Yannakakis "Simple linear-time algorithms to test chordality of
+//	graphs, test acyclicity of hypergraphs, and selectively reduce acyclic
+//	hypergraphs" doi:10.1137/0213035
+func MaximumCardinalitySearch(g graph.Undirected) []graph.Node {
+	nodes, adj := indexed(g)
+	n := len(nodes)
+	if n == 0 {
+		return nil
+	}
+
+	// Nodes are held in buckets by weight with stale
+	// entries removed lazily. The initial bucket is
+	// filled in reverse so the lowest ID is taken first.
+	weight := make([]int, n)
+	buckets := make([][]int, n)
+	for i := n - 1; i >= 0; i-- {
+		buckets[0] = append(buckets[0], i)
+	}
+	var max int
+	visited := make([]bool, n)
+	order := make([]graph.Node, 0, n)
+	for len(order) < n {
+		b := buckets[max]
+		if len(b) == 0 {
+			max--
+			continue
+		}
+		v := b[len(b)-1]
+		buckets[max] = b[:len(b)-1]
+		if visited[v] || weight[v] != max {
+			continue
+		}
+		visited[v] = true
+		order = append(order, nodes[v])
+		for _, w := range adj[v] {
+			if visited[w] {
+				continue
+			}
+			weight[w]++
+			buckets[weight[w]] = append(buckets[weight[w]], w)
+			if weight[w] > max {
+				max = weight[w]
+			}
+		}
+	}
+	return order
+}
+
+// indexed returns the nodes of g ordered by ID and the sorted indices of
+// the neighbours of each node, excluding self loops.
+func indexed(g graph.Undirected) (nodes []graph.Node, adj [][]int) {
+	nodes = g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+	indexOf := make(map[int]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+	adj = make([][]int, len(nodes))
+	for i, u := range nodes {
+		for _, v := range g.From(u) {
+			if j := indexOf[v.ID()]; j != i {
+				adj[i] = append(adj[i], j)
+			}
+		}
+		sort.Ints(adj[i])
+	}
+	return nodes, adj
+}
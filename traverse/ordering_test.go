@@ -0,0 +1,151 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/graphs/gen"
+	"github.com/gonum/graph/internal/ordered"
+	"github.com/gonum/graph/simple"
+)
+
+func orderingTestGraphs() []struct {
+	name string
+	g    graph.Undirected
+} {
+	tests := []struct {
+		name string
+		g    graph.Undirected
+	}{
+		{name: "empty", g: simple.NewUndirectedGraph(0, math.Inf(1))},
+		{name: "Batagelj-Zaversnik", g: undirectedFrom(batageljZaversnikGraph)},
+		{name: "Bron-Kerbosch", g: undirectedFrom(wpBronKerboschGraph)},
+	}
+	for i := 0; i < 20; i++ {
+		g := simple.NewUndirectedGraph(0, math.Inf(1))
+		gen.Gnp(g, 20, 0.2, rand.New(rand.NewSource(int64(i))))
+		tests = append(tests, struct {
+			name string
+			g    graph.Undirected
+		}{name: fmt.Sprintf("random %d", i), g: g})
+	}
+	return tests
+}
+
+func TestLexBFS(t *testing.T) {
+	for _, test := range orderingTestGraphs() {
+		got := LexBFS(test.g)
+		want := naiveLexBFS(test.g)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("unexpected LexBFS order for %q:\ngot: %v\nwant:%v", test.name, got, want)
+		}
+	}
+}
+
+func TestMaximumCardinalitySearch(t *testing.T) {
+	for _, test := range orderingTestGraphs() {
+		order := MaximumCardinalitySearch(test.g)
+		nodes := test.g.Nodes()
+		if len(order) != len(nodes) {
+			t.Errorf("unexpected order length for %q: got:%d want:%d", test.name, len(order), len(nodes))
+			continue
+		}
+		if len(nodes) == 0 {
+			continue
+		}
+		sort.Sort(ordered.ByID(nodes))
+		if order[0].ID() != nodes[0].ID() {
+			t.Errorf("unexpected first node for %q: got:%d want:%d", test.name, order[0].ID(), nodes[0].ID())
+		}
+
+		// Each node must have the greatest number of
+		// visited neighbours when it is visited.
+		visited := make(map[int]bool)
+		count := func(n graph.Node) int {
+			var c int
+			for _, v := range test.g.From(n) {
+				if visited[v.ID()] && v.ID() != n.ID() {
+					c++
+				}
+			}
+			return c
+		}
+		for _, v := range order {
+			if visited[v.ID()] {
+				t.Errorf("node %d visited twice for %q", v.ID(), test.name)
+			}
+			cv := count(v)
+			for _, u := range nodes {
+				if !visited[u.ID()] && count(u) > cv {
+					t.Errorf("node %d visited with %d visited neighbours for %q, but node %d has %d",
+						v.ID(), cv, test.name, u.ID(), count(u))
+				}
+			}
+			visited[v.ID()] = true
+		}
+	}
+}
+
+func undirectedFrom(adj []set) graph.Undirected {
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	for u, e := range adj {
+		if !g.Has(simple.Node(u)) {
+			g.AddNode(simple.Node(u))
+		}
+		for v := range e {
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+		}
+	}
+	return g
+}
+
+// naiveLexBFS returns the lexicographic breadth-first order of g by
+// explicitly constructing node labels.
+func naiveLexBFS(g graph.Undirected) []graph.Node {
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+	n := len(nodes)
+	label := make(map[int][]int)
+	visited := make(map[int]bool)
+	var order []graph.Node
+	for i := 0; i < n; i++ {
+		var best graph.Node
+		for _, u := range nodes {
+			if visited[u.ID()] {
+				continue
+			}
+			if best == nil || greaterLabel(label[u.ID()], label[best.ID()]) {
+				best = u
+			}
+		}
+		visited[best.ID()] = true
+		order = append(order, best)
+		for _, v := range g.From(best) {
+			if !visited[v.ID()] {
+				label[v.ID()] = append(label[v.ID()], n-i)
+			}
+		}
+	}
+	return order
+}
+
+func greaterLabel(a, b []int) bool {
+	for i := range a {
+		if i == len(b) {
+			return true
+		}
+		if a[i] != b[i] {
+			return a[i] > b[i]
+		}
+	}
+	return false
+}
@@ -0,0 +1,303 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+)
+
+// ParallelBreadthFirst implements a parallel level-synchronous breadth-first
+// search. Each level of the search is expanded concurrently, either top-down
+// from the nodes in the frontier or bottom-up from the unvisited nodes,
+// switching between the two depending on the size of the frontier.
+//
+// The search is described in:
+//
+//	Beamer, Asanović and Patterson "Direction-Optimizing Breadth-First
+//	Search" doi:10.1109/SC.2012.50
+//
+// The graph being searched must be safe for concurrent reads and a non-nil
+// EdgeFilter must be safe for concurrent use.
+type ParallelBreadthFirst struct {
+	// EdgeFilter, if non-nil, restricts the
+	// search to edges for which it returns true.
+	// EdgeFilter is called concurrently from
+	// the search's workers, so it must be safe
+	// for concurrent use.
+	EdgeFilter func(graph.Edge) bool
+
+	// Workers is the number of goroutines used
+	// to expand each level. If Workers is not
+	// positive, runtime.GOMAXPROCS(0) is used.
+	Workers int
+}
+
+// Levels holds the result of a level-synchronous breadth-first search.
+type Levels struct {
+	// Nodes holds the nodes of the searched
+	// graph, sorted by ID. Dist, Parent and
+	// Root are indexed by position in Nodes.
+	Nodes []graph.Node
+
+	// Dist holds the number of edges between
+	// each node and the root of the search that
+	// reached it, or -1 if the node was not reached.
+	Dist []int
+
+	// Parent holds the index of the parent of each
+	// node in the breadth-first tree, or -1 if the
+	// node is a search root or was not reached.
+	// The parent is one of the node's neighbours
+	// in the previous level. Which one is chosen
+	// depends on the order in which workers reach
+	// the node and the order neighbours are
+	// returned by the graph, so it may differ
+	// between searches of the same graph.
+	Parent []int
+
+	// Root holds the index of the root of the search
+	// that reached each node, or -1 if the node was
+	// not reached.
+	Root []int
+
+	indexOf map[int]int
+}
+
+// Index returns the position of n in l.Nodes and whether n is in the
+// searched graph.
+func (l *Levels) Index(n graph.Node) (int, bool) {
+	i, ok := l.indexOf[n.ID()]
+	return i, ok
+}
+
+// DistanceTo returns the number of edges between n and the root of the search
+// that reached it, or -1 if n was not reached.
+func (l *Levels) DistanceTo(n graph.Node) int {
+	i, ok := l.indexOf[n.ID()]
+	if !ok {
+		return -1
+	}
+	return l.Dist[i]
+}
+
+// ParentOf returns the parent of n in the breadth-first tree, or nil if n is
+// a search root or was not reached. As described for Levels.Parent, the
+// parent may differ between searches of the same graph.
+func (l *Levels) ParentOf(n graph.Node) graph.Node {
+	i, ok := l.indexOf[n.ID()]
+	if !ok || l.Parent[i] < 0 {
+		return nil
+	}
+	return l.Nodes[l.Parent[i]]
+}
+
+// Walk performs a breadth-first search of the graph g starting from the node
+// from and returns the levels of the search. If from is not in g, no node is
+// reached.
+func (b *ParallelBreadthFirst) Walk(g graph.Graph, from graph.Node) *Levels {
+	s := b.newSearch(g)
+	if i, ok := s.Index(from); ok {
+		s.search(i)
+	}
+	return s.Levels
+}
+
+// WalkAll performs breadth-first searches of the undirected graph g from each
+// node not reached by an earlier search, taking nodes in order of ID, and
+// returns the levels of the searches. Nodes in the same connected component
+// share a Root.
+func (b *ParallelBreadthFirst) WalkAll(g graph.Undirected) *Levels {
+	s := b.newSearch(g)
+	for i := range s.Nodes {
+		if s.visited[i] == 0 {
+			s.search(i)
+		}
+	}
+	return s.Levels
+}
+
+const (
+	// bottomUpFactor and topDownFactor control
+	// switching between top-down and bottom-up
+	// expansion of the frontier.
+	bottomUpFactor = 14
+	topDownFactor  = 24
+)
+
+// levelSearch holds the state of a level-synchronous breadth-first search.
+type levelSearch struct {
+	*Levels
+
+	g      graph.Graph
+	to     func(graph.Node) []graph.Node
+	filter func(graph.Edge) bool
+
+	workers    int
+	visited    []int32
+	inFrontier []bool
+	unvisited  int
+}
+
+func (b *ParallelBreadthFirst) newSearch(g graph.Graph) *levelSearch {
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+	l := &Levels{
+		Nodes:   nodes,
+		Dist:    make([]int, len(nodes)),
+		Parent:  make([]int, len(nodes)),
+		Root:    make([]int, len(nodes)),
+		indexOf: make(map[int]int, len(nodes)),
+	}
+	for i, n := range nodes {
+		l.Dist[i] = -1
+		l.Parent[i] = -1
+		l.Root[i] = -1
+		l.indexOf[n.ID()] = i
+	}
+
+	s := &levelSearch{
+		Levels:     l,
+		g:          g,
+		to:         g.From,
+		filter:     b.EdgeFilter,
+		workers:    b.Workers,
+		visited:    make([]int32, len(nodes)),
+		inFrontier: make([]bool, len(nodes)),
+		unvisited:  len(nodes),
+	}
+	if d, ok := g.(graph.Directed); ok {
+		s.to = d.To
+	}
+	if s.workers <= 0 {
+		s.workers = runtime.GOMAXPROCS(0)
+	}
+	return s
+}
+
+// search performs a breadth-first search from the node at index root.
+func (s *levelSearch) search(root int) {
+	s.visited[root] = 1
+	s.Dist[root] = 0
+	s.Root[root] = root
+	s.unvisited--
+
+	frontier := []int{root}
+	var bottomUp bool
+	for depth := 1; len(frontier) != 0; depth++ {
+		switch {
+		case !bottomUp && len(frontier)*bottomUpFactor > s.unvisited:
+			bottomUp = true
+		case bottomUp && len(frontier)*topDownFactor < len(s.Nodes):
+			bottomUp = false
+		}
+		if bottomUp {
+			frontier = s.bottomUp(frontier, root, depth)
+		} else {
+			frontier = s.topDown(frontier, root, depth)
+		}
+		s.unvisited -= len(frontier)
+	}
+}
+
+// topDown returns the nodes at the given depth by claiming the unvisited
+// neighbours of the nodes in the frontier.
+func (s *levelSearch) topDown(frontier []int, root, depth int) []int {
+	return s.parallel(len(frontier), func(lo, hi int) []int {
+		var next []int
+		for _, ui := range frontier[lo:hi] {
+			u := s.Nodes[ui]
+			for _, v := range s.g.From(u) {
+				if s.filter != nil && !s.filter(s.g.Edge(u, v)) {
+					continue
+				}
+				vi := s.indexOf[v.ID()]
+				if !atomic.CompareAndSwapInt32(&s.visited[vi], 0, 1) {
+					continue
+				}
+				s.Dist[vi] = depth
+				s.Parent[vi] = ui
+				s.Root[vi] = root
+				next = append(next, vi)
+			}
+		}
+		return next
+	})
+}
+
+// bottomUp returns the nodes at the given depth by searching for a parent
+// in the frontier for each unvisited node.
+func (s *levelSearch) bottomUp(frontier []int, root, depth int) []int {
+	for _, ui := range frontier {
+		s.inFrontier[ui] = true
+	}
+	next := s.parallel(len(s.Nodes), func(lo, hi int) []int {
+		var next []int
+		for vi := lo; vi < hi; vi++ {
+			if s.visited[vi] != 0 {
+				continue
+			}
+			v := s.Nodes[vi]
+			for _, u := range s.to(v) {
+				ui := s.indexOf[u.ID()]
+				if !s.inFrontier[ui] {
+					continue
+				}
+				if s.filter != nil && !s.filter(s.g.Edge(u, v)) {
+					continue
+				}
+				s.visited[vi] = 1
+				s.Dist[vi] = depth
+				s.Parent[vi] = ui
+				s.Root[vi] = root
+				next = append(next, vi)
+				break
+			}
+		}
+		return next
+	})
+	for _, ui := range frontier {
+		s.inFrontier[ui] = false
+	}
+	return next
+}
+
+// parallel partitions [0, n) between the search's workers, calls fn on each
+// partition and returns the concatenated results.
+func (s *levelSearch) parallel(n int, fn func(lo, hi int) []int) []int {
+	workers := s.workers
+	if workers > n {
+		workers = n
+	}
+	if workers <= 1 {
+		return fn(0, n)
+	}
+
+	parts := make([][]int, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			parts[w] = fn(n*w/workers, n*(w+1)/workers)
+		}(w)
+	}
+	wg.Wait()
+
+	var size int
+	for _, p := range parts {
+		size += len(p)
+	}
+	all := make([]int, 0, size)
+	for _, p := range parts {
+		all = append(all, p...)
+	}
+	return all
+}
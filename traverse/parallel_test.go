@@ -0,0 +1,164 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/graphs/gen"
+	"github.com/gonum/graph/simple"
+)
+
+func parallelTestGraphs() []struct {
+	name string
+	g    graph.Graph
+} {
+	path := simple.NewUndirectedGraph(0, math.Inf(1))
+	for i := 0; i < 50; i++ {
+		path.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(i + 1)})
+	}
+	gnpDirected := func(n int, p float64, seed int64) graph.Graph {
+		g := simple.NewDirectedGraph(0, math.Inf(1))
+		gen.Gnp(g, n, p, rand.New(rand.NewSource(seed)))
+		return g
+	}
+	gnp := func(n int, p float64, seed int64) graph.Graph {
+		g := simple.NewUndirectedGraph(0, math.Inf(1))
+		gen.Gnp(g, n, p, rand.New(rand.NewSource(seed)))
+		return g
+	}
+	return []struct {
+		name string
+		g    graph.Graph
+	}{
+		{name: "batagelj-zaversnik", g: undirectedFrom(batageljZaversnikGraph)},
+		{name: "batagelj-zaversnik directed", g: directedFrom(batageljZaversnikGraph)},
+		{name: "path", g: path},
+		{name: "gnp sparse", g: gnp(200, 0.01, 1)},
+		{name: "gnp dense", g: gnp(200, 0.2, 1)},
+		{name: "gnp directed sparse", g: gnpDirected(200, 0.01, 1)},
+		{name: "gnp directed dense", g: gnpDirected(200, 0.2, 1)},
+	}
+}
+
+func TestParallelBreadthFirst(t *testing.T) {
+	for _, test := range parallelTestGraphs() {
+		for _, workers := range []int{1, 4} {
+			for _, from := range test.g.Nodes() {
+				b := ParallelBreadthFirst{Workers: workers}
+				l := b.Walk(test.g, from)
+				want := hops(test.g, from, nil)
+				checkLevels(t, fmt.Sprintf("%s workers=%d from=%d", test.name, workers, from.ID()), test.g, l, want, nil)
+			}
+		}
+	}
+}
+
+func TestParallelBreadthFirstEdgeFilter(t *testing.T) {
+	filter := func(e graph.Edge) bool {
+		return (e.From().ID()+e.To().ID())%3 != 0
+	}
+	for _, test := range parallelTestGraphs() {
+		for _, from := range test.g.Nodes() {
+			b := ParallelBreadthFirst{EdgeFilter: filter, Workers: 4}
+			l := b.Walk(test.g, from)
+			want := hops(test.g, from, filter)
+			checkLevels(t, fmt.Sprintf("%s filtered from=%d", test.name, from.ID()), test.g, l, want, filter)
+		}
+	}
+}
+
+func TestParallelBreadthFirstAbsent(t *testing.T) {
+	var b ParallelBreadthFirst
+	l := b.Walk(undirectedFrom(wpBronKerboschGraph), simple.Node(-1))
+	for i, d := range l.Dist {
+		if d != -1 {
+			t.Errorf("unexpected distance for node %d from absent node: %d", l.Nodes[i].ID(), d)
+		}
+	}
+}
+
+func TestParallelBreadthFirstWalkAll(t *testing.T) {
+	g := undirectedFrom(batageljZaversnikGraph)
+	for _, workers := range []int{1, 4} {
+		b := ParallelBreadthFirst{Workers: workers}
+		l := b.WalkAll(g)
+		for i, n := range l.Nodes {
+			if l.Root[i] < 0 {
+				t.Fatalf("node %d not reached", n.ID())
+			}
+			root := l.Nodes[l.Root[i]]
+			if got := hops(g, root, nil)[n.ID()]; got != l.Dist[i] {
+				t.Errorf("unexpected distance from %d to %d: got:%d want:%d", root.ID(), n.ID(), l.Dist[i], got)
+			}
+			for _, c := range l.Nodes {
+				if c.ID() < root.ID() && hops(g, c, nil)[n.ID()] >= 0 {
+					t.Errorf("unexpected root for %d: got:%d want lower ID %d", n.ID(), root.ID(), c.ID())
+					break
+				}
+			}
+		}
+	}
+}
+
+// hops returns the number of edges on a shortest path from
+// the node from to each node in g, or -1 for unreachable nodes.
+func hops(g graph.Graph, from graph.Node, filter func(graph.Edge) bool) map[int]int {
+	dist := make(map[int]int)
+	for _, n := range g.Nodes() {
+		dist[n.ID()] = -1
+	}
+	if _, ok := dist[from.ID()]; !ok {
+		return dist
+	}
+	dist[from.ID()] = 0
+	queue := []graph.Node{from}
+	for len(queue) != 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, v := range g.From(u) {
+			if dist[v.ID()] >= 0 || (filter != nil && !filter(g.Edge(u, v))) {
+				continue
+			}
+			dist[v.ID()] = dist[u.ID()] + 1
+			queue = append(queue, v)
+		}
+	}
+	return dist
+}
+
+func checkLevels(t *testing.T, name string, g graph.Graph, l *Levels, want map[int]int, filter func(graph.Edge) bool) {
+	for i, n := range l.Nodes {
+		if i > 0 && l.Nodes[i-1].ID() >= n.ID() {
+			t.Errorf("%s: nodes not sorted by ID", name)
+		}
+		if got := l.DistanceTo(n); got != want[n.ID()] {
+			t.Errorf("%s: unexpected distance to %d: got:%d want:%d", name, n.ID(), got, want[n.ID()])
+		}
+		p := l.ParentOf(n)
+		switch {
+		case l.Dist[i] <= 0:
+			if p != nil {
+				t.Errorf("%s: unexpected parent for %d: %d", name, n.ID(), p.ID())
+			}
+		case p == nil:
+			t.Errorf("%s: missing parent for %d", name, n.ID())
+		default:
+			if g.Edge(p, n) == nil {
+				t.Errorf("%s: parent %d not adjacent to %d", name, p.ID(), n.ID())
+			}
+			if filter != nil && !filter(g.Edge(p, n)) {
+				t.Errorf("%s: filtered edge used for parent %d of %d", name, p.ID(), n.ID())
+			}
+			if got := l.DistanceTo(p); got != l.Dist[i]-1 {
+				t.Errorf("%s: unexpected parent distance for %d: got:%d want:%d", name, n.ID(), got, l.Dist[i]-1)
+			}
+		}
+	}
+}
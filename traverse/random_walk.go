@@ -0,0 +1,145 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"math/rand"
+	"sort"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+)
+
+// RandomWalk implements random walks on a graph. The zero value performs
+// uniform first-order random walks, choosing each step with equal probability
+// among the nodes reachable from the current node.
+type RandomWalk struct {
+	// EdgeFilter, if non-nil, restricts the
+	// walk to edges for which it returns true.
+	EdgeFilter func(graph.Edge) bool
+
+	// Weighted specifies that steps are chosen
+	// with probability proportional to edge weight
+	// when the graph implements graph.Weighter.
+	Weighted bool
+
+	// Restart is the probability of returning
+	// to the start node of the walk at each step.
+	Restart float64
+
+	// P and Q are the return and in-out parameters
+	// of node2vec second-order biased walks. A step
+	// back to the previous node is weighted by 1/P,
+	// a step to a neighbour of the previous node by
+	// 1, and any other step by 1/Q. If P or Q is
+	// zero, it is treated as 1, so the zero value
+	// gives a first-order walk.
+	//
+	// The walks are described in:
+	//
+	//	Grover and Leskovec "node2vec: Scalable Feature Learning for
+	//	Networks" doi:10.1145/2939672.2939754
+	P, Q float64
+}
+
+// Walk returns a random walk of length nodes in g starting from the node from.
+// The walk ends early if it reaches a node with no steps that can be taken.
+// If src is not nil it is used as the random source, otherwise rand.Float64
+// is used. When Weighted is true, Walk will panic if any edge that could be
+// followed from a node on the walk has a negative weight, whether or not that
+// edge is chosen.
+func (w *RandomWalk) Walk(g graph.Graph, from graph.Node, length int, src *rand.Rand) []graph.Node {
+	if length <= 0 || !g.Has(from) {
+		return nil
+	}
+	rnd := rand.Float64
+	if src != nil {
+		rnd = src.Float64
+	}
+	var weight func(x, y graph.Node) (float64, bool)
+	if wg, ok := g.(graph.Weighter); ok && w.Weighted {
+		weight = wg.Weight
+	}
+	p, q := w.P, w.Q
+	if p == 0 {
+		p = 1
+	}
+	if q == 0 {
+		q = 1
+	}
+	biased := p != 1 || q != 1
+
+	walk := make([]graph.Node, 1, length)
+	walk[0] = from
+	var prev graph.Node
+	cum := make([]float64, 0, 16)
+	for len(walk) < length {
+		cur := walk[len(walk)-1]
+		if w.Restart > 0 && rnd() < w.Restart {
+			walk = append(walk, from)
+			prev = nil
+			continue
+		}
+
+		next := g.From(cur)
+		sort.Sort(ordered.ByID(next))
+		cum = cum[:0]
+		var total float64
+		for _, n := range next {
+			var f float64
+			if w.EdgeFilter == nil || w.EdgeFilter(g.Edge(cur, n)) {
+				f = 1
+				if weight != nil {
+					f, _ = weight(cur, n)
+					if f < 0 {
+						panic("traverse: negative edge weight")
+					}
+				}
+				if biased && prev != nil {
+					switch {
+					case n.ID() == prev.ID():
+						f /= p
+					case !g.HasEdgeBetween(prev, n):
+						f /= q
+					}
+				}
+			}
+			total += f
+			cum = append(cum, total)
+		}
+		if total == 0 {
+			break
+		}
+		r := rnd() * total
+		i := sort.SearchFloat64s(cum, r)
+		for i < len(cum)-1 && cum[i] <= r {
+			// Skip zero weight steps.
+			i++
+		}
+		prev = cur
+		walk = append(walk, next[i])
+	}
+	return walk
+}
+
+// Corpus returns a set of random walks of length nodes in g, with walks rounds
+// of one walk from each node. Within each round the start nodes are taken in
+// a random order. If src is not nil it is used as the random source, otherwise
+// the global rand source is used.
+func (w *RandomWalk) Corpus(g graph.Graph, walks, length int, src *rand.Rand) [][]graph.Node {
+	perm := rand.Perm
+	if src != nil {
+		perm = src.Perm
+	}
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+	corpus := make([][]graph.Node, 0, walks*len(nodes))
+	for i := 0; i < walks; i++ {
+		for _, j := range perm(len(nodes)) {
+			corpus = append(corpus, w.Walk(g, nodes[j], length, src))
+		}
+	}
+	return corpus
+}
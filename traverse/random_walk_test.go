@@ -0,0 +1,196 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+func TestRandomWalk(t *testing.T) {
+	g := undirectedFrom(batageljZaversnikGraph)
+	for _, w := range []RandomWalk{
+		{},
+		{P: 0.5, Q: 2},
+		{P: 2, Q: 0.5},
+		{Restart: 0.2},
+	} {
+		for _, n := range g.Nodes() {
+			walk := w.Walk(g, n, 20, rand.New(rand.NewSource(1)))
+			if walk[0].ID() != n.ID() {
+				t.Errorf("unexpected start of walk for %+v: got:%d want:%d", w, walk[0].ID(), n.ID())
+			}
+			if len(g.From(n)) != 0 && len(walk) != 20 {
+				t.Errorf("unexpected walk length for %+v from %d: got:%d want:20", w, n.ID(), len(walk))
+			}
+			for i := 1; i < len(walk); i++ {
+				if !g.HasEdgeBetween(walk[i-1], walk[i]) && !(w.Restart > 0 && walk[i].ID() == n.ID()) {
+					t.Errorf("invalid step for %+v from %d: %d -> %d", w, n.ID(), walk[i-1].ID(), walk[i].ID())
+				}
+			}
+
+			again := w.Walk(g, n, 20, rand.New(rand.NewSource(1)))
+			if !reflect.DeepEqual(walk, again) {
+				t.Errorf("walk for %+v from %d not reproducible with same source", w, n.ID())
+			}
+		}
+	}
+}
+
+func TestRandomWalkDeadEnd(t *testing.T) {
+	g := directedFrom([]set{
+		0: linksTo(1),
+		1: linksTo(2),
+		2: nil,
+	})
+	var w RandomWalk
+	got := ids(w.Walk(g, simple.Node(0), 10, rand.New(rand.NewSource(1))))
+	want := []int{0, 1, 2}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected walk: got:%v want:%v", got, want)
+	}
+
+	w.EdgeFilter = func(e graph.Edge) bool { return e.To().ID() != 2 }
+	got = ids(w.Walk(g, simple.Node(0), 10, rand.New(rand.NewSource(1))))
+	want = []int{0, 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected filtered walk: got:%v want:%v", got, want)
+	}
+
+	if walk := w.Walk(g, simple.Node(-1), 10, nil); walk != nil {
+		t.Errorf("unexpected walk from absent node: %v", ids(walk))
+	}
+}
+
+func TestRandomWalkRestart(t *testing.T) {
+	g := undirectedFrom(batageljZaversnikGraph)
+	w := RandomWalk{Restart: 1}
+	for _, n := range w.Walk(g, simple.Node(7), 10, rand.New(rand.NewSource(1))) {
+		if n.ID() != 7 {
+			t.Fatalf("walk left start node with certain restart: %d", n.ID())
+		}
+	}
+}
+
+func TestRandomWalkWeighted(t *testing.T) {
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1), W: 1})
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(2), W: 3})
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(3), W: 0})
+
+	const n = 10000
+	src := rand.New(rand.NewSource(1))
+	w := RandomWalk{Weighted: true}
+	counts := make(map[int]int)
+	for i := 0; i < n; i++ {
+		walk := w.Walk(g, simple.Node(0), 2, src)
+		counts[walk[1].ID()]++
+	}
+	if counts[3] != 0 {
+		t.Errorf("unexpected steps along zero weight edge: %d", counts[3])
+	}
+	if got := float64(counts[2]) / n; math.Abs(got-0.75) > 0.02 {
+		t.Errorf("unexpected proportion of steps along heavy edge: got:%.3f want:0.75", got)
+	}
+}
+
+func TestRandomWalkNode2Vec(t *testing.T) {
+	// Node 0 is adjacent to 1 and 2, and 2 is adjacent
+	// to 1, so from 1 having come from 0, a step to 0
+	// returns, a step to 2 stays close and a step to 3
+	// moves outward.
+	g := undirectedFrom([]set{
+		0: linksTo(1, 2),
+		1: linksTo(2, 3),
+		2: nil,
+		3: nil,
+	})
+	for _, test := range []struct {
+		p, q float64
+	}{
+		{p: 1, q: 1},
+		{p: 4, q: 0.25},
+		{p: 0.25, q: 4},
+	} {
+		w := RandomWalk{P: test.p, Q: test.q}
+		src := rand.New(rand.NewSource(1))
+		counts := make(map[int]float64)
+		var total float64
+		for i := 0; i < 20000; i++ {
+			walk := w.Walk(g, simple.Node(0), 3, src)
+			if walk[1].ID() != 1 {
+				continue
+			}
+			counts[walk[2].ID()]++
+			total++
+		}
+		sum := 1/test.p + 1 + 1/test.q
+		for id, want := range map[int]float64{
+			0: 1 / test.p / sum,
+			2: 1 / sum,
+			3: 1 / test.q / sum,
+		} {
+			if got := counts[id] / total; math.Abs(got-want) > 0.02 {
+				t.Errorf("unexpected proportion of steps to %d for p=%v q=%v: got:%.3f want:%.3f",
+					id, test.p, test.q, got, want)
+			}
+		}
+	}
+}
+
+func TestRandomWalkCorpus(t *testing.T) {
+	g := undirectedFrom(wpBronKerboschGraph)
+	var w RandomWalk
+	corpus := w.Corpus(g, 3, 5, rand.New(rand.NewSource(1)))
+	if len(corpus) != 3*len(g.Nodes()) {
+		t.Fatalf("unexpected corpus size: got:%d want:%d", len(corpus), 3*len(g.Nodes()))
+	}
+	starts := make(map[int]int)
+	for _, walk := range corpus {
+		if len(walk) != 5 {
+			t.Errorf("unexpected walk length: got:%d want:5", len(walk))
+		}
+		starts[walk[0].ID()]++
+	}
+	for _, n := range g.Nodes() {
+		if starts[n.ID()] != 3 {
+			t.Errorf("unexpected number of walks from %d: got:%d want:3", n.ID(), starts[n.ID()])
+		}
+	}
+	if !reflect.DeepEqual(corpus, w.Corpus(g, 3, 5, rand.New(rand.NewSource(1)))) {
+		t.Error("corpus not reproducible with same source")
+	}
+}
+
+func ids(nodes []graph.Node) []int {
+	var id []int
+	for _, n := range nodes {
+		id = append(id, n.ID())
+	}
+	return id
+}
+
+func TestRandomWalkNegativeWeight(t *testing.T) {
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1), W: 1})
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(2), W: -1})
+
+	// Weights are ignored by unweighted walks.
+	var w RandomWalk
+	w.Walk(g, simple.Node(0), 2, rand.New(rand.NewSource(1)))
+
+	w.Weighted = true
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic for negative weight candidate edge")
+		}
+	}()
+	w.Walk(g, simple.Node(0), 2, rand.New(rand.NewSource(1)))
+}
@@ -29,6 +29,14 @@ type BreadthFirst struct {
 // for which until(node, depth) is true. During the traversal, if the Visit field is
 // non-nil, it is called with the nodes joined by each followed edge.
 func (b *BreadthFirst) Walk(g graph.Graph, from graph.Node, until func(n graph.Node, d int) bool) graph.Node {
+	n, _ := b.walk(g, from, until, nil)
+	return n
+}
+
+// walk performs the traversal for Walk. If cancelled is not nil it is called
+// before visiting each node, and the traversal stops and returns the error if
+// it is not nil.
+func (b *BreadthFirst) walk(g graph.Graph, from graph.Node, until func(n graph.Node, d int) bool, cancelled func() error) (graph.Node, error) {
 	if b.visited == nil {
 		b.visited = &intsets.Sparse{}
 	}
@@ -41,9 +49,14 @@ func (b *BreadthFirst) Walk(g graph.Graph, from graph.Node, until func(n graph.N
 		untilNext = 1
 	)
 	for b.queue.Len() > 0 {
+		if cancelled != nil {
+			if err := cancelled(); err != nil {
+				return nil, err
+			}
+		}
 		t := b.queue.Dequeue()
 		if until != nil && until(t, depth) {
-			return t
+			return t, nil
 		}
 		for _, n := range g.From(t) {
 			if b.EdgeFilter != nil && !b.EdgeFilter(g.Edge(t, n)) {
@@ -66,7 +79,7 @@ func (b *BreadthFirst) Walk(g graph.Graph, from graph.Node, until func(n graph.N
 		}
 	}
 
-	return nil
+	return nil, nil
 }
 
 // WalkAll calls Walk for each unvisited node of the graph g using edges independent
@@ -121,6 +134,14 @@ type DepthFirst struct {
 // for which until(node) is true. During the traversal, if the Visit field is non-nil, it
 // is called with the nodes joined by each followed edge.
 func (d *DepthFirst) Walk(g graph.Graph, from graph.Node, until func(graph.Node) bool) graph.Node {
+	n, _ := d.walk(g, from, until, nil)
+	return n
+}
+
+// walk performs the traversal for Walk. If cancelled is not nil it is called
+// before visiting each node, and the traversal stops and returns the error if
+// it is not nil.
+func (d *DepthFirst) walk(g graph.Graph, from graph.Node, until func(graph.Node) bool, cancelled func() error) (graph.Node, error) {
 	if d.visited == nil {
 		d.visited = &intsets.Sparse{}
 	}
@@ -128,9 +149,14 @@ func (d *DepthFirst) Walk(g graph.Graph, from graph.Node, until func(graph.Node)
 	d.visited.Insert(from.ID())
 
 	for d.stack.Len() > 0 {
+		if cancelled != nil {
+			if err := cancelled(); err != nil {
+				return nil, err
+			}
+		}
 		t := d.stack.Pop()
 		if until != nil && until(t) {
-			return t
+			return t, nil
 		}
 		for _, n := range g.From(t) {
 			if d.EdgeFilter != nil && !d.EdgeFilter(g.Edge(t, n)) {
@@ -147,7 +173,7 @@ func (d *DepthFirst) Walk(g graph.Graph, from graph.Node, until func(graph.Node)
 		}
 	}
 
-	return nil
+	return nil, nil
 }
 
 // WalkAll calls Walk for each unvisited node of the graph g using edges independent
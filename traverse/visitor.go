@@ -0,0 +1,200 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"sort"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+)
+
+// EdgeKind is the classification of an edge by a depth-first traversal.
+type EdgeKind int
+
+const (
+	// TreeEdge is an edge followed to discover a node.
+	TreeEdge EdgeKind = iota
+
+	// BackEdge is an edge to an ancestor of the current node
+	// in the depth-first forest, including self loops.
+	BackEdge
+
+	// ForwardEdge is a non-tree edge to a descendant of the
+	// current node in the depth-first forest.
+	ForwardEdge
+
+	// CrossEdge is an edge to a node that is neither an
+	// ancestor nor a descendant of the current node.
+	CrossEdge
+)
+
+func (k EdgeKind) String() string {
+	switch k {
+	case TreeEdge:
+		return "tree"
+	case BackEdge:
+		return "back"
+	case ForwardEdge:
+		return "forward"
+	case CrossEdge:
+		return "cross"
+	default:
+		return "unknown"
+	}
+}
+
+// DepthFirstVisitor implements stateful event-driven depth-first graph
+// traversal. Nodes are given discovery and finish times from a single clock
+// that advances on each discovery and each finish, and the edges followed are
+// classified as tree, back, forward or cross edges.
+//
+// The traversal is iterative, so it is not limited by the depth of the call
+// stack, and visits the neighbours of each node in order of ID.
+type DepthFirstVisitor struct {
+	// EdgeFilter, if non-nil, restricts the
+	// traversal to edges for which it returns
+	// true.
+	EdgeFilter func(graph.Edge) bool
+
+	// Discover and Finish, if non-nil, are called
+	// when a node is discovered and when all of
+	// its descendants have been finished, giving
+	// pre-order and post-order traversals.
+	Discover func(graph.Node)
+	Finish   func(graph.Node)
+
+	// Visit, if non-nil, is called with the nodes
+	// joined by each edge examined during the
+	// traversal and the classification of the edge.
+	// For undirected graphs, each edge is examined
+	// once and is either a tree edge or a back edge.
+	Visit func(u, v graph.Node, kind EdgeKind)
+
+	time     int
+	discover map[int]int
+	finish   map[int]int
+}
+
+// Walk performs a depth-first traversal of the graph g starting from the given
+// node, depending on the the EdgeFilter field and the until parameter if they
+// are non-nil. The traversal returns the first node for which until(node) is
+// true when the node is discovered, leaving the traversal incomplete; nodes
+// already discovered are not finished. Nodes discovered by previous walks
+// since the last call to Reset are not visited again.
+func (d *DepthFirstVisitor) Walk(g graph.Graph, from graph.Node, until func(graph.Node) bool) graph.Node {
+	if d.discover == nil {
+		d.discover = make(map[int]int)
+		d.finish = make(map[int]int)
+	}
+	if _, ok := d.discover[from.ID()]; ok {
+		return nil
+	}
+	_, directed := g.(graph.Directed)
+
+	type frame struct {
+		node   graph.Node
+		parent graph.Node
+		next   []graph.Node
+	}
+	var stack []frame
+	discover := func(n, parent graph.Node) bool {
+		d.discover[n.ID()] = d.time
+		d.time++
+		if d.Discover != nil {
+			d.Discover(n)
+		}
+		if until != nil && until(n) {
+			return true
+		}
+		next := g.From(n)
+		sort.Sort(ordered.ByID(next))
+		stack = append(stack, frame{node: n, parent: parent, next: next})
+		return false
+	}
+
+	if discover(from, nil) {
+		return from
+	}
+	for len(stack) != 0 {
+		f := &stack[len(stack)-1]
+		if len(f.next) == 0 {
+			d.finish[f.node.ID()] = d.time
+			d.time++
+			if d.Finish != nil {
+				d.Finish(f.node)
+			}
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		u, v := f.node, f.next[0]
+		f.next = f.next[1:]
+		if d.EdgeFilter != nil && !d.EdgeFilter(g.Edge(u, v)) {
+			continue
+		}
+
+		var kind EdgeKind
+		_, seen := d.discover[v.ID()]
+		_, done := d.finish[v.ID()]
+		switch {
+		case !seen:
+			kind = TreeEdge
+		case !directed && (done || f.parent != nil && v.ID() == f.parent.ID()):
+			// The edge has already been examined
+			// from its other end.
+			continue
+		case !done:
+			kind = BackEdge
+		case d.discover[u.ID()] < d.discover[v.ID()]:
+			kind = ForwardEdge
+		default:
+			kind = CrossEdge
+		}
+		if d.Visit != nil {
+			d.Visit(u, v, kind)
+		}
+		if kind == TreeEdge && discover(v, u) {
+			return v
+		}
+	}
+	return nil
+}
+
+// WalkAll calls Walk for each undiscovered node of the graph g in order of
+// node ID, producing a depth-first forest of g.
+func (d *DepthFirstVisitor) WalkAll(g graph.Graph) {
+	nodes := g.Nodes()
+	sort.Sort(ordered.ByID(nodes))
+	for _, n := range nodes {
+		d.Walk(g, n, nil)
+	}
+}
+
+// DiscoveryTime returns the time at which n was discovered and whether n has
+// been discovered.
+func (d *DepthFirstVisitor) DiscoveryTime(n graph.Node) (time int, ok bool) {
+	time, ok = d.discover[n.ID()]
+	return time, ok
+}
+
+// FinishTime returns the time at which n was finished and whether n has been
+// finished.
+func (d *DepthFirstVisitor) FinishTime(n graph.Node) (time int, ok bool) {
+	time, ok = d.finish[n.ID()]
+	return time, ok
+}
+
+// Visited returns whether the node n was discovered during a traverse.
+func (d *DepthFirstVisitor) Visited(n graph.Node) bool {
+	_, ok := d.discover[n.ID()]
+	return ok
+}
+
+// Reset resets the state of the traverser for reuse.
+func (d *DepthFirstVisitor) Reset() {
+	d.time = 0
+	d.discover = nil
+	d.finish = nil
+}
@@ -0,0 +1,214 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/simple"
+)
+
+func directedFrom(adj []set) graph.Directed {
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	for u, e := range adj {
+		if !g.Has(simple.Node(u)) {
+			g.AddNode(simple.Node(u))
+		}
+		for v := range e {
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+		}
+	}
+	return g
+}
+
+var depthFirstVisitorTests = []struct {
+	name     string
+	g        graph.Graph
+	edges    []string
+	pre      []int
+	post     []int
+	discover []int
+	finish   []int
+}{
+	{
+		name: "directed",
+		g: directedFrom([]set{
+			0: linksTo(1, 2),
+			1: linksTo(2),
+			2: linksTo(0),
+			3: linksTo(2, 4),
+			4: nil,
+		}),
+		edges: []string{
+			"0->1 tree", "1->2 tree", "2->0 back", "0->2 forward",
+			"3->2 cross", "3->4 tree",
+		},
+		pre:      []int{0, 1, 2, 3, 4},
+		post:     []int{2, 1, 0, 4, 3},
+		discover: []int{0, 1, 2, 6, 7},
+		finish:   []int{5, 4, 3, 9, 8},
+	},
+	{
+		name: "undirected",
+		g: undirectedFrom([]set{
+			0: linksTo(1, 2),
+			1: linksTo(2),
+			2: linksTo(3),
+			4: nil,
+		}),
+		edges: []string{
+			"0->1 tree", "1->2 tree", "2->0 back", "2->3 tree",
+		},
+		pre:      []int{0, 1, 2, 3, 4},
+		post:     []int{3, 2, 1, 0, 4},
+		discover: []int{0, 1, 2, 3, 8},
+		finish:   []int{7, 6, 5, 4, 9},
+	},
+}
+
+func TestDepthFirstVisitor(t *testing.T) {
+	for _, test := range depthFirstVisitorTests {
+		var (
+			edges     []string
+			pre, post []int
+		)
+		d := DepthFirstVisitor{
+			Discover: func(n graph.Node) { pre = append(pre, n.ID()) },
+			Finish:   func(n graph.Node) { post = append(post, n.ID()) },
+			Visit: func(u, v graph.Node, kind EdgeKind) {
+				edges = append(edges, fmt.Sprintf("%d->%d %v", u.ID(), v.ID(), kind))
+			},
+		}
+		d.WalkAll(test.g)
+		if !reflect.DeepEqual(edges, test.edges) {
+			t.Errorf("unexpected edges for %q:\ngot: %v\nwant:%v", test.name, edges, test.edges)
+		}
+		if !reflect.DeepEqual(pre, test.pre) {
+			t.Errorf("unexpected pre-order for %q: got:%v want:%v", test.name, pre, test.pre)
+		}
+		if !reflect.DeepEqual(post, test.post) {
+			t.Errorf("unexpected post-order for %q: got:%v want:%v", test.name, post, test.post)
+		}
+		for id, want := range test.discover {
+			if got, _ := d.DiscoveryTime(simple.Node(id)); got != want {
+				t.Errorf("unexpected discovery time for node %d in %q: got:%d want:%d", id, test.name, got, want)
+			}
+		}
+		for id, want := range test.finish {
+			if got, _ := d.FinishTime(simple.Node(id)); got != want {
+				t.Errorf("unexpected finish time for node %d in %q: got:%d want:%d", id, test.name, got, want)
+			}
+		}
+	}
+}
+
+func TestDepthFirstVisitorUntil(t *testing.T) {
+	g := directedFrom(wpBronKerboschGraph)
+	var d DepthFirstVisitor
+	got := d.Walk(g, simple.Node(0), func(n graph.Node) bool { return n.ID() == 3 })
+	if got == nil || got.ID() != 3 {
+		t.Errorf("unexpected final node: got:%v want:3", got)
+	}
+	if _, ok := d.FinishTime(simple.Node(3)); ok {
+		t.Error("unexpected finish time for final node")
+	}
+
+	d.Reset()
+	d.EdgeFilter = func(e graph.Edge) bool { return e.To().ID() != 2 }
+	d.Walk(g, simple.Node(0), nil)
+	if d.Visited(simple.Node(2)) || d.Visited(simple.Node(3)) {
+		t.Error("unexpected traversal of filtered edge")
+	}
+}
+
+func TestDepthFirstVisitorRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		adj := make([]set, 30)
+		for u := range adj {
+			adj[u] = make(set)
+			for v := range adj {
+				if u != v && rnd.Float64() < 0.08 {
+					adj[u][v] = struct{}{}
+				}
+			}
+		}
+		g := directedFrom(adj)
+
+		var (
+			d      DepthFirstVisitor
+			kinds  = make(map[[2]int]EdgeKind)
+			parent = make(map[int]int)
+		)
+		d.Visit = func(u, v graph.Node, kind EdgeKind) {
+			kinds[[2]int{u.ID(), v.ID()}] = kind
+			if kind == TreeEdge {
+				parent[v.ID()] = u.ID()
+			}
+		}
+		d.WalkAll(g)
+
+		isAncestor := func(a, n int) bool {
+			for {
+				if n == a {
+					return true
+				}
+				p, ok := parent[n]
+				if !ok {
+					return false
+				}
+				n = p
+			}
+		}
+		for _, u := range g.Nodes() {
+			for _, v := range g.From(u) {
+				key := [2]int{u.ID(), v.ID()}
+				kind, ok := kinds[key]
+				if !ok {
+					t.Errorf("edge %v not visited", key)
+					continue
+				}
+				du, _ := d.DiscoveryTime(u)
+				dv, _ := d.DiscoveryTime(v)
+				fu, _ := d.FinishTime(u)
+				fv, _ := d.FinishTime(v)
+				var want EdgeKind
+				switch {
+				case parent[v.ID()] == u.ID() && kind == TreeEdge:
+					want = TreeEdge
+				case isAncestor(v.ID(), u.ID()):
+					want = BackEdge
+				case isAncestor(u.ID(), v.ID()):
+					want = ForwardEdge
+				default:
+					want = CrossEdge
+				}
+				if kind != want {
+					t.Errorf("unexpected kind for edge %v: got:%v want:%v", key, kind, want)
+				}
+				// Check the parenthesis structure of the times.
+				switch want {
+				case TreeEdge, ForwardEdge:
+					if !(du < dv && fv < fu) {
+						t.Errorf("times for %v edge %v not nested", want, key)
+					}
+				case BackEdge:
+					if !(dv < du && fu < fv) {
+						t.Errorf("times for back edge %v not nested", key)
+					}
+				case CrossEdge:
+					if !(fv < du) {
+						t.Errorf("times for cross edge %v not disjoint", key)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,289 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tree
+
+import (
+	"sort"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+	"github.com/gonum/graph/simple"
+)
+
+// Diameter returns a longest path between any two nodes of the tree, and
+// its length as the sum of the edge weights on the path. If the tree has
+// negative edge weights, the returned path is the path with the greatest
+// total weight.
+func (t *Rooted) Diameter() (path []graph.Node, length float64) {
+	// down[i] is the greatest weight of a path from
+	// node i into its subtree, via the child next[i].
+	down := make([]float64, len(t.nodes))
+	next := make([]int, len(t.nodes))
+	best := 0
+	arms := [2]int{-1, -1}
+	for i := len(t.nodes) - 1; i >= 0; i-- {
+		next[i] = -1
+		top := [2]int{-1, -1}
+		var topLen [2]float64
+		for _, c := range t.children[i] {
+			l := down[c] + t.weight[c]
+			if l <= 0 {
+				continue
+			}
+			switch {
+			case top[0] < 0 || l > topLen[0]:
+				top[1], topLen[1] = top[0], topLen[0]
+				top[0], topLen[0] = c, l
+			case top[1] < 0 || l > topLen[1]:
+				top[1], topLen[1] = c, l
+			}
+		}
+		down[i], next[i] = topLen[0], top[0]
+		if l := topLen[0] + topLen[1]; l > length {
+			best, arms, length = i, top, l
+		}
+	}
+
+	for i := arms[0]; i >= 0; i = next[i] {
+		path = append(path, t.nodes[i])
+	}
+	reverse(path)
+	path = append(path, t.nodes[best])
+	for i := arms[1]; i >= 0; i = next[i] {
+		path = append(path, t.nodes[i])
+	}
+	return path, length
+}
+
+// Centroids returns the centroids of the tree, the nodes whose removal leaves
+// no connected component with more than half of the nodes of the tree. A tree
+// has either one or two centroids. The returned nodes are sorted by ID.
+func (t *Rooted) Centroids() []graph.Node {
+	n := len(t.nodes)
+	var centroids []graph.Node
+	for i := range t.nodes {
+		largest := n - t.size[i]
+		for _, c := range t.children[i] {
+			if t.size[c] > largest {
+				largest = t.size[c]
+			}
+		}
+		if 2*largest <= n {
+			centroids = append(centroids, t.nodes[i])
+		}
+	}
+	sort.Sort(ordered.ByID(centroids))
+	return centroids
+}
+
+// CentroidDecomposition returns the centroid decomposition of the tree. The
+// root of the returned tree is a centroid of t, and the children of each node
+// of the returned tree are the centroids of the components left by removing
+// that node from its component of t. The returned tree has depth O(log n),
+// and the path between any two nodes of t passes through their lowest common
+// ancestor in the returned tree. Where a component has two centroids, the
+// centroid with the lower ID is used.
+func (t *Rooted) CentroidDecomposition() *Rooted {
+	n := len(t.nodes)
+	adj := make([][]int, n)
+	for i := range t.nodes {
+		adj[i] = append(adj[i], t.children[i]...)
+		if p := t.parent[i]; p >= 0 {
+			adj[i] = append(adj[i], p)
+		}
+	}
+
+	dst := simple.NewDirectedGraph(0, 0)
+	removed := make([]bool, n)
+	size := make([]int, n)
+	parent := make([]int, n)
+	var root graph.Node
+
+	type component struct{ start, centroidOf int }
+	work := []component{{start: 0, centroidOf: -1}}
+	for len(work) != 0 {
+		comp := work[len(work)-1]
+		work = work[:len(work)-1]
+
+		// Find the nodes of the component in
+		// breadth-first order from its start.
+		order := []int{comp.start}
+		parent[comp.start] = -1
+		for k := 0; k < len(order); k++ {
+			i := order[k]
+			for _, j := range adj[i] {
+				if !removed[j] && j != parent[i] {
+					parent[j] = i
+					order = append(order, j)
+				}
+			}
+		}
+		for k := len(order) - 1; k >= 0; k-- {
+			i := order[k]
+			size[i] = 1
+			for _, j := range adj[i] {
+				if !removed[j] && j != parent[i] {
+					size[i] += size[j]
+				}
+			}
+		}
+		m := len(order)
+		c := -1
+		for _, i := range order {
+			largest := m - size[i]
+			for _, j := range adj[i] {
+				if !removed[j] && j != parent[i] && size[j] > largest {
+					largest = size[j]
+				}
+			}
+			if 2*largest <= m && (c < 0 || t.nodes[i].ID() < t.nodes[c].ID()) {
+				c = i
+			}
+		}
+
+		dst.AddNode(t.nodes[c])
+		if comp.centroidOf < 0 {
+			root = t.nodes[c]
+		} else {
+			dst.SetEdge(simple.Edge{F: t.nodes[comp.centroidOf], T: t.nodes[c], W: 1})
+		}
+		removed[c] = true
+		for _, j := range adj[c] {
+			if !removed[j] {
+				work = append(work, component{start: j, centroidOf: c})
+			}
+		}
+	}
+
+	d, _ := NewRooted(dst, root)
+	return d
+}
+
+// HeavyLight is a heavy-light decomposition of a rooted tree. Each node is
+// joined to the child with the largest subtree, its heavy child, to form
+// heavy paths. The path between any two nodes crosses O(log n) heavy paths.
+//
+// Nodes are assigned positions by a depth first traversal that visits heavy
+// children first, so each heavy path occupies a contiguous range of positions
+// from its head down, and the subtree of each node n occupies the positions
+// from Position(n) up to Position(n)+Size(n).
+type HeavyLight struct {
+	t *Rooted
+
+	// head holds the index of the top
+	// of each node's heavy path.
+	head []int
+	// pos holds each node's position and
+	// order the node index at each position.
+	pos   []int
+	order []int
+}
+
+// NewHeavyLight returns the heavy-light decomposition of the tree t. Where
+// children have equal subtree sizes, the child with the lower ID is heavy.
+func NewHeavyLight(t *Rooted) *HeavyLight {
+	n := len(t.nodes)
+	h := &HeavyLight{
+		t:     t,
+		head:  make([]int, n),
+		pos:   make([]int, n),
+		order: make([]int, 0, n),
+	}
+	stack := []int{0}
+	for len(stack) != 0 {
+		i := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		h.pos[i] = len(h.order)
+		h.order = append(h.order, i)
+
+		heavy := -1
+		for _, c := range t.children[i] {
+			if heavy < 0 || t.size[c] > t.size[heavy] {
+				heavy = c
+			}
+		}
+		children := t.children[i]
+		for k := len(children) - 1; k >= 0; k-- {
+			if c := children[k]; c != heavy {
+				h.head[c] = c
+				stack = append(stack, c)
+			}
+		}
+		if heavy >= 0 {
+			h.head[heavy] = h.head[i]
+			stack = append(stack, heavy)
+		}
+	}
+	return h
+}
+
+// Head returns the top node of the heavy path holding n. Head will panic if
+// n is not in the tree.
+func (h *HeavyLight) Head(n graph.Node) graph.Node {
+	return h.t.nodes[h.head[h.t.index(n)]]
+}
+
+// Position returns the position of n in the decomposition. Position will
+// panic if n is not in the tree.
+func (h *HeavyLight) Position(n graph.Node) int {
+	return h.pos[h.t.index(n)]
+}
+
+// Node returns the node at position p in the decomposition.
+func (h *HeavyLight) Node(p int) graph.Node {
+	return h.t.nodes[h.order[p]]
+}
+
+// Paths returns the heavy paths of the decomposition in position order,
+// each ordered from its head down.
+func (h *HeavyLight) Paths() [][]graph.Node {
+	var paths [][]graph.Node
+	for _, i := range h.order {
+		if h.head[i] == i {
+			paths = append(paths, nil)
+		}
+		paths[len(paths)-1] = append(paths[len(paths)-1], h.t.nodes[i])
+	}
+	return paths
+}
+
+// Segments returns the ranges of positions covering the nodes on the path
+// between u and v. Each segment is a half-open range [from, to) of positions
+// on a single heavy path. At most O(log n) segments are returned. Segments
+// will panic if either node is not in the tree.
+func (h *HeavyLight) Segments(u, v graph.Node) [][2]int {
+	segs, _ := h.walk(h.t.index(u), h.t.index(v))
+	return segs
+}
+
+// LCA returns the lowest common ancestor of u and v. LCA will panic if
+// either node is not in the tree.
+func (h *HeavyLight) LCA(u, v graph.Node) graph.Node {
+	_, a := h.walk(h.t.index(u), h.t.index(v))
+	return h.t.nodes[a]
+}
+
+// walk returns the position segments on the path between the nodes
+// with indices i and j, and the index of their lowest common ancestor.
+func (h *HeavyLight) walk(i, j int) (segs [][2]int, lca int) {
+	depth := h.t.depth
+	for h.head[i] != h.head[j] {
+		if depth[h.head[i]] < depth[h.head[j]] {
+			i, j = j, i
+		}
+		segs = append(segs, [2]int{h.pos[h.head[i]], h.pos[i] + 1})
+		i = h.t.parent[h.head[i]]
+	}
+	if depth[i] > depth[j] {
+		i, j = j, i
+	}
+	return append(segs, [2]int{h.pos[i], h.pos[j] + 1}), i
+}
+
+func reverse(p []graph.Node) {
+	for i, j := 0, len(p)-1; i < j; i, j = i+1, j-1 {
+		p[i], p[j] = p[j], p[i]
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tree
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/set"
+	"github.com/gonum/graph/topo"
+)
+
+// treePath returns the nodes on the path from u to v in t.
+func treePath(t *Rooted, u, v graph.Node) []graph.Node {
+	a := naiveLCA(t, u, v)
+	var up, down []graph.Node
+	for n := u; n.ID() != a.ID(); n = t.Parent(n) {
+		up = append(up, n)
+	}
+	for n := v; n.ID() != a.ID(); n = t.Parent(n) {
+		down = append(down, n)
+	}
+	p := append(up, a)
+	for i := len(down) - 1; i >= 0; i-- {
+		p = append(p, down[i])
+	}
+	return p
+}
+
+func TestDiameter(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 5, 20, 50} {
+		g := randomTree(rnd, n)
+		tr, _ := NewRooted(g, g.Node(10+rnd.Intn(n)))
+		path, length := tr.Diameter()
+
+		var want float64
+		for _, u := range tr.Nodes() {
+			for _, v := range tr.Nodes() {
+				d := tr.Distance(u) + tr.Distance(v) - 2*tr.Distance(naiveLCA(tr, u, v))
+				if d > want {
+					want = d
+				}
+			}
+		}
+		if length != want {
+			t.Errorf("unexpected diameter for n=%d: got:%v want:%v", n, length, want)
+		}
+		if !topo.IsPathIn(g, path) {
+			t.Errorf("diameter is not a path for n=%d: %v", n, ids(path))
+		}
+		got := tr.Distance(path[0]) + tr.Distance(path[len(path)-1]) -
+			2*tr.Distance(naiveLCA(tr, path[0], path[len(path)-1]))
+		if got != length {
+			t.Errorf("unexpected diameter path length for n=%d: got:%v want:%v", n, got, length)
+		}
+	}
+}
+
+func TestCentroids(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 8, 30} {
+		g := randomTree(rnd, n)
+		tr, _ := NewRooted(g, g.Node(10))
+
+		var want []int
+		for _, c := range g.Nodes() {
+			ok := true
+			for _, comp := range componentsWithout(g, c) {
+				if 2*len(comp) > n {
+					ok = false
+				}
+			}
+			if ok {
+				want = append(want, c.ID())
+			}
+		}
+		if got := ids(tr.Centroids()); !reflect.DeepEqual(sortedInts(got), sortedInts(want)) {
+			t.Errorf("unexpected centroids for n=%d: got:%v want:%v", n, got, want)
+		}
+	}
+}
+
+func TestCentroidDecomposition(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 7, 40, 100} {
+		g := randomTree(rnd, n)
+		tr, _ := NewRooted(g, g.Node(10))
+		cd := tr.CentroidDecomposition()
+		if len(cd.Nodes()) != n {
+			t.Errorf("unexpected number of nodes in centroid tree for n=%d: got:%d want:%d", n, len(cd.Nodes()), n)
+			continue
+		}
+		maxDepth := 0
+		for _, c := range cd.Nodes() {
+			if d := cd.Depth(c); d > maxDepth {
+				maxDepth = d
+			}
+			// Each node is a centroid of its subtree
+			// in the centroid tree.
+			sub := make(set.Ints)
+			for _, m := range cd.Nodes() {
+				for a := m; a != nil; a = cd.Parent(a) {
+					if a.ID() == c.ID() {
+						sub.Add(m.ID())
+						break
+					}
+				}
+			}
+			for _, comp := range componentsWithout(g, c) {
+				var k int
+				for _, m := range comp {
+					if sub.Has(m.ID()) {
+						k++
+					}
+				}
+				if 2*k > sub.Count() {
+					t.Errorf("node %d is not a centroid of its component for n=%d", c.ID(), n)
+				}
+			}
+		}
+		if n > 1 && 1<<uint(maxDepth) > n {
+			t.Errorf("centroid tree too deep for n=%d: depth %d", n, maxDepth)
+		}
+		lca := NewEulerTour(cd)
+		for i := 0; i < 50; i++ {
+			u := g.Node(10 + rnd.Intn(n))
+			v := g.Node(10 + rnd.Intn(n))
+			a := lca.LCA(u, v)
+			var found bool
+			for _, p := range treePath(tr, u, v) {
+				if p.ID() == a.ID() {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("centroid LCA %d of %d and %d not on tree path for n=%d", a.ID(), u.ID(), v.ID(), n)
+			}
+		}
+	}
+}
+
+func TestHeavyLight(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 10, 60} {
+		g := randomTree(rnd, n)
+		tr, _ := NewRooted(g, g.Node(10+rnd.Intn(n)))
+		h := NewHeavyLight(tr)
+
+		var seen int
+		for _, p := range h.Paths() {
+			for i, m := range p {
+				if h.Head(m).ID() != p[0].ID() {
+					t.Errorf("unexpected head for %d: got:%d want:%d", m.ID(), h.Head(m).ID(), p[0].ID())
+				}
+				if h.Node(h.Position(m)).ID() != m.ID() {
+					t.Errorf("position of %d does not round trip", m.ID())
+				}
+				if i != 0 && tr.Parent(m).ID() != p[i-1].ID() {
+					t.Errorf("heavy path is not a path: %v", ids(p))
+				}
+				seen++
+			}
+		}
+		if seen != n {
+			t.Errorf("unexpected number of nodes in heavy paths: got:%d want:%d", seen, n)
+		}
+		for _, m := range tr.Nodes() {
+			pos := h.Position(m)
+			for i := pos; i < pos+tr.Size(m); i++ {
+				for a := h.Node(i); a.ID() != m.ID(); a = tr.Parent(a) {
+					if a.ID() == tr.Root().ID() {
+						t.Errorf("position %d not in subtree of %d", i, m.ID())
+						break
+					}
+				}
+			}
+		}
+
+		for i := 0; i < 50; i++ {
+			u := g.Node(10 + rnd.Intn(n))
+			v := g.Node(10 + rnd.Intn(n))
+			var got []int
+			for _, s := range h.Segments(u, v) {
+				for p := s[0]; p < s[1]; p++ {
+					got = append(got, h.Node(p).ID())
+				}
+			}
+			want := ids(treePath(tr, u, v))
+			if !reflect.DeepEqual(sortedInts(got), sortedInts(want)) {
+				t.Errorf("unexpected segment nodes for %d-%d: got:%v want:%v", u.ID(), v.ID(), got, want)
+			}
+		}
+	}
+}
+
+// componentsWithout returns the connected components of g after
+// removing the node n.
+func componentsWithout(g graph.Undirected, n graph.Node) [][]graph.Node {
+	return topo.ConnectedComponents(without{g, n})
+}
+
+// without is an undirected graph with one node hidden.
+type without struct {
+	graph.Undirected
+	n graph.Node
+}
+
+func (g without) Has(n graph.Node) bool {
+	return n.ID() != g.n.ID() && g.Undirected.Has(n)
+}
+
+func (g without) Nodes() []graph.Node {
+	var nodes []graph.Node
+	for _, n := range g.Undirected.Nodes() {
+		if n.ID() != g.n.ID() {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
+func (g without) From(n graph.Node) []graph.Node {
+	var nodes []graph.Node
+	for _, m := range g.Undirected.From(n) {
+		if m.ID() != g.n.ID() {
+			nodes = append(nodes, m)
+		}
+	}
+	return nodes
+}
+
+func sortedInts(s []int) []int {
+	s = append([]int(nil), s...)
+	sort.Ints(s)
+	return s
+}
@@ -0,0 +1,11 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This repository is no longer maintained.
+// Development has moved to https://github.com/gonum/gonum.
+//
+// Package tree provides a rooted tree view of graphs and tree algorithms,
+// including lowest common ancestor queries, tree diameter, centroid
+// decomposition and heavy-light decomposition.
+package tree
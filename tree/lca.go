@@ -0,0 +1,180 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tree
+
+import "github.com/gonum/graph"
+
+// BinaryLifting answers lowest common ancestor and level ancestor queries
+// on a rooted tree using ancestor tables. Construction takes O(n log n) time
+// and space, and each query takes O(log n) time.
+type BinaryLifting struct {
+	t *Rooted
+
+	// up[k][i] is the index of the 2^k-th
+	// ancestor of node i, or -1 if there is
+	// no such ancestor.
+	up [][]int
+}
+
+// NewBinaryLifting returns a BinaryLifting for the tree t.
+func NewBinaryLifting(t *Rooted) *BinaryLifting {
+	n := len(t.nodes)
+	up := [][]int{append([]int(nil), t.parent...)}
+	for k := 1; 1<<uint(k) < n; k++ {
+		prev := up[k-1]
+		next := make([]int, n)
+		for i, p := range prev {
+			if p < 0 {
+				next[i] = -1
+			} else {
+				next[i] = prev[p]
+			}
+		}
+		up = append(up, next)
+	}
+	return &BinaryLifting{t: t, up: up}
+}
+
+// LCA returns the lowest common ancestor of u and v. LCA will panic if
+// either node is not in the tree.
+func (b *BinaryLifting) LCA(u, v graph.Node) graph.Node {
+	i, j := b.t.index(u), b.t.index(v)
+	depth := b.t.depth
+	if depth[i] < depth[j] {
+		i, j = j, i
+	}
+	i = b.lift(i, depth[i]-depth[j])
+	if i == j {
+		return b.t.nodes[i]
+	}
+	for k := len(b.up) - 1; k >= 0; k-- {
+		if b.up[k][i] != b.up[k][j] {
+			i, j = b.up[k][i], b.up[k][j]
+		}
+	}
+	return b.t.nodes[b.t.parent[i]]
+}
+
+// Ancestor returns the ancestor of n that is k edges closer to the root. If k
+// is greater than the depth of n, Ancestor returns nil. Ancestor will panic if
+// n is not in the tree or k is negative.
+func (b *BinaryLifting) Ancestor(n graph.Node, k int) graph.Node {
+	if k < 0 {
+		panic("tree: negative ancestor distance")
+	}
+	i := b.t.index(n)
+	if k > b.t.depth[i] {
+		return nil
+	}
+	return b.t.nodes[b.lift(i, k)]
+}
+
+// lift returns the index of the k-th ancestor of node i.
+func (b *BinaryLifting) lift(i, k int) int {
+	for bit := 0; k != 0; bit++ {
+		if k&1 != 0 {
+			i = b.up[bit][i]
+		}
+		k >>= 1
+	}
+	return i
+}
+
+// EulerTour answers lowest common ancestor queries on a rooted tree using
+// range minimum queries over an Euler tour of the tree. Construction takes
+// O(n log n) time and space, and each query takes constant time.
+type EulerTour struct {
+	t *Rooted
+
+	// tour holds the node indices visited by
+	// the Euler tour and first the position
+	// of the first visit to each node.
+	tour  []int
+	first []int
+
+	// sparse[k][i] is the position of the
+	// shallowest node in tour[i:i+2^k].
+	sparse [][]int
+}
+
+// NewEulerTour returns an EulerTour for the tree t.
+func NewEulerTour(t *Rooted) *EulerTour {
+	n := len(t.nodes)
+	e := &EulerTour{
+		t:     t,
+		tour:  make([]int, 0, 2*n-1),
+		first: make([]int, n),
+	}
+
+	// Walk the tree depth first, recording each
+	// node on entry and on return from each child.
+	type frame struct{ node, next int }
+	stack := []frame{{node: 0}}
+	e.first[0] = 0
+	e.tour = append(e.tour, 0)
+	for len(stack) != 0 {
+		f := &stack[len(stack)-1]
+		if f.next == len(t.children[f.node]) {
+			stack = stack[:len(stack)-1]
+			if len(stack) != 0 {
+				e.tour = append(e.tour, stack[len(stack)-1].node)
+			}
+			continue
+		}
+		c := t.children[f.node][f.next]
+		f.next++
+		e.first[c] = len(e.tour)
+		e.tour = append(e.tour, c)
+		stack = append(stack, frame{node: c})
+	}
+
+	m := len(e.tour)
+	base := make([]int, m)
+	for i := range base {
+		base[i] = i
+	}
+	e.sparse = [][]int{base}
+	for k := 1; 1<<uint(k) <= m; k++ {
+		prev := e.sparse[k-1]
+		half := 1 << uint(k-1)
+		next := make([]int, m-1<<uint(k)+1)
+		for i := range next {
+			next[i] = e.shallower(prev[i], prev[i+half])
+		}
+		e.sparse = append(e.sparse, next)
+	}
+	return e
+}
+
+// LCA returns the lowest common ancestor of u and v. LCA will panic if
+// either node is not in the tree.
+func (e *EulerTour) LCA(u, v graph.Node) graph.Node {
+	l, r := e.first[e.t.index(u)], e.first[e.t.index(v)]
+	if l > r {
+		l, r = r, l
+	}
+	k := log2(r - l + 1)
+	p := e.shallower(e.sparse[k][l], e.sparse[k][r-1<<uint(k)+1])
+	return e.t.nodes[e.tour[p]]
+}
+
+// shallower returns whichever of the tour positions i and j
+// holds the node with the lesser depth.
+func (e *EulerTour) shallower(i, j int) int {
+	if e.t.depth[e.tour[j]] < e.t.depth[e.tour[i]] {
+		return j
+	}
+	return i
+}
+
+// log2 returns the floor of the base 2 logarithm of n for positive n.
+func log2(n int) int {
+	var k int
+	for n > 1 {
+		n >>= 1
+		k++
+	}
+	return k
+}
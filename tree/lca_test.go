@@ -0,0 +1,76 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tree
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/graph"
+)
+
+// naiveLCA returns the lowest common ancestor of u and v by walking
+// parent links.
+func naiveLCA(t *Rooted, u, v graph.Node) graph.Node {
+	for t.Depth(u) > t.Depth(v) {
+		u = t.Parent(u)
+	}
+	for t.Depth(v) > t.Depth(u) {
+		v = t.Parent(v)
+	}
+	for u.ID() != v.ID() {
+		u, v = t.Parent(u), t.Parent(v)
+	}
+	return u
+}
+
+func TestLCA(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 10, 50} {
+		g := randomTree(rnd, n)
+		nodes := g.Nodes()
+		root := nodes[rnd.Intn(len(nodes))]
+		tr, ok := NewRooted(g, root)
+		if !ok {
+			t.Fatalf("expected tree")
+		}
+		lifting := NewBinaryLifting(tr)
+		euler := NewEulerTour(tr)
+		hld := NewHeavyLight(tr)
+		for _, u := range nodes {
+			for _, v := range nodes {
+				want := naiveLCA(tr, u, v).ID()
+				if got := lifting.LCA(u, v).ID(); got != want {
+					t.Errorf("unexpected binary lifting LCA of %d and %d: got:%d want:%d", u.ID(), v.ID(), got, want)
+				}
+				if got := euler.LCA(u, v).ID(); got != want {
+					t.Errorf("unexpected Euler tour LCA of %d and %d: got:%d want:%d", u.ID(), v.ID(), got, want)
+				}
+				if got := hld.LCA(u, v).ID(); got != want {
+					t.Errorf("unexpected heavy-light LCA of %d and %d: got:%d want:%d", u.ID(), v.ID(), got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestAncestor(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	g := randomTree(rnd, 40)
+	tr, _ := NewRooted(g, g.Node(10))
+	lifting := NewBinaryLifting(tr)
+	for _, n := range tr.Nodes() {
+		a := n
+		for k := 0; k <= tr.Depth(n); k++ {
+			if got := lifting.Ancestor(n, k); got.ID() != a.ID() {
+				t.Errorf("unexpected ancestor %d of %d: got:%d want:%d", k, n.ID(), got.ID(), a.ID())
+			}
+			a = tr.Parent(a)
+		}
+		if got := lifting.Ancestor(n, tr.Depth(n)+1); got != nil {
+			t.Errorf("unexpected ancestor beyond root of %d: %d", n.ID(), got.ID())
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tree
+
+import (
+	"sort"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/internal/ordered"
+)
+
+// Rooted is a rooted tree view of the nodes of a graph reachable from a root
+// node.
+type Rooted struct {
+	// nodes holds the tree nodes in
+	// breadth-first order from the root.
+	nodes   []graph.Node
+	indexOf map[int]int
+
+	// parent holds the index of each
+	// node's parent, -1 for the root.
+	parent   []int
+	children [][]int
+	depth    []int
+	size     []int
+
+	// weight holds the weight of the edge
+	// from each node's parent and dist the
+	// sum of weights on the path from the
+	// root.
+	weight []float64
+	dist   []float64
+}
+
+// NewRooted returns a rooted tree view of the nodes of g reachable from root,
+// and whether those nodes form a tree. If g is directed, edges are followed
+// from parent to child and the reachable nodes form a tree only if each is
+// reachable by exactly one path from root. If g is undirected, the tree is
+// oriented away from root and the reachable nodes form a tree only if they
+// hold no cycle. If g implements graph.Weighter, its Weight method is used to
+// obtain edge weights, otherwise every edge has unit weight. NewRooted will
+// panic if root is not in g.
+func NewRooted(g graph.Graph, root graph.Node) (t *Rooted, ok bool) {
+	if !g.Has(root) {
+		panic("tree: root not in graph")
+	}
+	var weight func(x, y graph.Node) (w float64, ok bool)
+	if wg, ok := g.(graph.Weighter); ok {
+		weight = wg.Weight
+	} else {
+		weight = func(x, y graph.Node) (float64, bool) { return 1, true }
+	}
+	_, directed := g.(graph.Directed)
+
+	t = &Rooted{
+		nodes:   []graph.Node{root},
+		indexOf: map[int]int{root.ID(): 0},
+		parent:  []int{-1},
+		depth:   []int{0},
+		weight:  []float64{0},
+		dist:    []float64{0},
+	}
+	for i := 0; i < len(t.nodes); i++ {
+		u := t.nodes[i]
+		next := g.From(u)
+		sort.Sort(ordered.ByID(next))
+		var children []int
+		for _, v := range next {
+			if !directed && t.parent[i] >= 0 && v.ID() == t.nodes[t.parent[i]].ID() {
+				continue
+			}
+			if _, seen := t.indexOf[v.ID()]; seen {
+				return nil, false
+			}
+			w, _ := weight(u, v)
+			j := len(t.nodes)
+			t.indexOf[v.ID()] = j
+			t.nodes = append(t.nodes, v)
+			t.parent = append(t.parent, i)
+			t.depth = append(t.depth, t.depth[i]+1)
+			t.weight = append(t.weight, w)
+			t.dist = append(t.dist, t.dist[i]+w)
+			children = append(children, j)
+		}
+		t.children = append(t.children, children)
+	}
+	t.size = make([]int, len(t.nodes))
+	for i := len(t.nodes) - 1; i >= 0; i-- {
+		t.size[i]++
+		if p := t.parent[i]; p >= 0 {
+			t.size[p] += t.size[i]
+		}
+	}
+	return t, true
+}
+
+// Root returns the root of the tree.
+func (t *Rooted) Root() graph.Node {
+	return t.nodes[0]
+}
+
+// Nodes returns the nodes of the tree in breadth-first order from the root,
+// with the children of each node ordered by ID.
+func (t *Rooted) Nodes() []graph.Node {
+	nodes := make([]graph.Node, len(t.nodes))
+	copy(nodes, t.nodes)
+	return nodes
+}
+
+// Has returns whether n is in the tree.
+func (t *Rooted) Has(n graph.Node) bool {
+	_, ok := t.indexOf[n.ID()]
+	return ok
+}
+
+// Parent returns the parent of n in the tree. If n is the root, Parent
+// returns nil. Parent will panic if n is not in the tree.
+func (t *Rooted) Parent(n graph.Node) graph.Node {
+	p := t.parent[t.index(n)]
+	if p < 0 {
+		return nil
+	}
+	return t.nodes[p]
+}
+
+// Children returns the children of n in the tree ordered by ID. Children
+// will panic if n is not in the tree.
+func (t *Rooted) Children(n graph.Node) []graph.Node {
+	c := t.children[t.index(n)]
+	children := make([]graph.Node, len(c))
+	for i, j := range c {
+		children[i] = t.nodes[j]
+	}
+	return children
+}
+
+// Depth returns the number of edges on the path from the root to n. Depth
+// will panic if n is not in the tree.
+func (t *Rooted) Depth(n graph.Node) int {
+	return t.depth[t.index(n)]
+}
+
+// Distance returns the sum of the edge weights on the path from the root to
+// n. Distance will panic if n is not in the tree.
+func (t *Rooted) Distance(n graph.Node) float64 {
+	return t.dist[t.index(n)]
+}
+
+// Size returns the number of nodes in the subtree rooted at n. Size will
+// panic if n is not in the tree.
+func (t *Rooted) Size(n graph.Node) int {
+	return t.size[t.index(n)]
+}
+
+// index returns the index of n, panicking if n is not in the tree.
+func (t *Rooted) index(n graph.Node) int {
+	i, ok := t.indexOf[n.ID()]
+	if !ok {
+		panic("tree: node not in tree")
+	}
+	return i
+}
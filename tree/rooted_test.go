@@ -0,0 +1,133 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tree
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/graph"
+	"github.com/gonum/graph/path"
+	"github.com/gonum/graph/simple"
+)
+
+// randomTree returns a random undirected tree on n nodes with IDs offset by
+// 10 and integer edge weights in [1, 10].
+func randomTree(rnd *rand.Rand, n int) *simple.UndirectedGraph {
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	g.AddNode(simple.Node(10))
+	for i := 1; i < n; i++ {
+		g.SetEdge(simple.Edge{
+			F: simple.Node(10 + rnd.Intn(i)),
+			T: simple.Node(10 + i),
+			W: float64(1 + rnd.Intn(10)),
+		})
+	}
+	return g
+}
+
+func TestNewRooted(t *testing.T) {
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.Edge{
+		{F: simple.Node(0), T: simple.Node(1), W: 2},
+		{F: simple.Node(0), T: simple.Node(2), W: 1},
+		{F: simple.Node(2), T: simple.Node(3), W: 4},
+		{F: simple.Node(2), T: simple.Node(4), W: 1},
+	} {
+		g.SetEdge(e)
+	}
+	g.AddNode(simple.Node(5))
+
+	tr, ok := NewRooted(g, simple.Node(2))
+	if !ok {
+		t.Fatal("expected tree")
+	}
+	if got, want := ids(tr.Nodes()), []int{2, 0, 3, 4, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected nodes: got:%v want:%v", got, want)
+	}
+	if tr.Has(simple.Node(5)) {
+		t.Error("unexpected unreachable node in tree")
+	}
+	if p := tr.Parent(simple.Node(2)); p != nil {
+		t.Errorf("unexpected parent for root: %v", p)
+	}
+	if p := tr.Parent(simple.Node(1)); p.ID() != 0 {
+		t.Errorf("unexpected parent for node 1: got:%d want:0", p.ID())
+	}
+	if got, want := ids(tr.Children(simple.Node(2))), []int{0, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected children: got:%v want:%v", got, want)
+	}
+	if d := tr.Depth(simple.Node(1)); d != 2 {
+		t.Errorf("unexpected depth: got:%d want:2", d)
+	}
+	if d := tr.Distance(simple.Node(1)); d != 3 {
+		t.Errorf("unexpected distance: got:%v want:3", d)
+	}
+	if s := tr.Size(simple.Node(0)); s != 2 {
+		t.Errorf("unexpected subtree size: got:%d want:2", s)
+	}
+
+	g.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(3)})
+	if _, ok := NewRooted(g, simple.Node(2)); ok {
+		t.Error("unexpected tree for graph with a cycle")
+	}
+}
+
+func TestNewRootedDirected(t *testing.T) {
+	g := simple.NewDirectedGraph(0, math.Inf(1))
+	for _, e := range [][2]int{{0, 1}, {0, 2}, {1, 3}, {4, 0}} {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	tr, ok := NewRooted(g, simple.Node(0))
+	if !ok {
+		t.Fatal("expected tree")
+	}
+	if got, want := ids(tr.Nodes()), []int{0, 1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected nodes: got:%v want:%v", got, want)
+	}
+
+	g.SetEdge(simple.Edge{F: simple.Node(2), T: simple.Node(3)})
+	if _, ok := NewRooted(g, simple.Node(0)); ok {
+		t.Error("unexpected tree for graph with two paths to a node")
+	}
+}
+
+func TestNewRootedSpanningTree(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	g := simple.NewUndirectedGraph(0, math.Inf(1))
+	for u := 0; u < 20; u++ {
+		for v := u + 1; v < 20; v++ {
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v), W: rnd.Float64()})
+		}
+	}
+	dst := simple.NewUndirectedGraph(0, math.Inf(1))
+	w := path.Kruskal(dst, g)
+	tr, ok := NewRooted(dst, simple.Node(0))
+	if !ok {
+		t.Fatal("expected minimum spanning tree to be a tree")
+	}
+	if n := len(tr.Nodes()); n != 20 {
+		t.Errorf("unexpected number of tree nodes: got:%d want:20", n)
+	}
+	var sum float64
+	for _, n := range tr.Nodes() {
+		if p := tr.Parent(n); p != nil {
+			sum += tr.Distance(n) - tr.Distance(p)
+		}
+	}
+	if math.Abs(sum-w) > 1e-12 {
+		t.Errorf("unexpected tree weight: got:%v want:%v", sum, w)
+	}
+}
+
+func ids(nodes []graph.Node) []int {
+	ids := make([]int, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID()
+	}
+	return ids
+}